@@ -2,68 +2,252 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"redisctl/internal/config"
 	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 )
 
+// addNodeOptions holds the add-node flags
+type addNodeOptions struct {
+	masterID  string            // --master-id: replica of this existing master (single new node)
+	replicaOf map[string]string // --replica-of: new replica -> master (new in the same run or existing)
+	asReplica bool              // --as-replica: replica of an automatically chosen master
+	rebalance bool              // --rebalance: move a fair share of slots to the new masters
+	weight    float64           // --weight: share of each new master relative to existing masters (1)
+	pipeline  int               // --pipeline: keys per MIGRATE when rebalancing
+	strict    bool              // --strict: preflight warnings abort
+}
+
 // NewAddNodeCommand add-node 명령어
 func NewAddNodeCommand() *cobra.Command {
-	var masterID string
+	var opts addNodeOptions
+	var replicaOf []string
 
 	cmd := &cobra.Command{
-		Use:   "add-node [--master-id <str>] new_ip:new_port existing_ip:existing_port",
+		Use:   "add-node [--master-id <str> | --as-replica] [--replica-of new=master] [--rebalance [--weight N]] [--strict] new_ip:new_port [new_ip:new_port...] existing_ip:existing_port[,ip:port...]",
 		Short: "+ 클러스터에 새 노드를 추가합니다",
 		Long: styles.TitleStyle.Render("[+] 클러스터 노드 추가") + "\n\n" +
 			styles.DescStyle.Render("기존 Redis 클러스터에 새로운 노드를 추가합니다.") + "\n" +
-			styles.DescStyle.Render("--master-id가 지정되면 해당 마스터의 복제본으로, 생략되면 마스터로 추가됩니다."),
+			styles.DescStyle.Render("--master-id가 지정되면 해당 마스터의 복제본으로, 생략되면 마스터로 추가됩니다.") + "\n" +
+			styles.DescStyle.Render("--as-replica는 정상 복제본이 가장 적은 마스터(다른 호스트, 같으면 슬롯이 많은 쪽)를 자동으로 골라 복제본으로 추가합니다.") + "\n" +
+			styles.DescStyle.Render("--rebalance는 rebalance와 같은 계획으로 새 마스터에 몫만큼의 슬롯을 바로 옮깁니다.") + "\n" +
+			styles.DescStyle.Render("여러 노드를 한 번에 추가할 수 있으며, --replica-of로 같은 실행에서 추가되는 노드를 마스터로 지정할 수 있습니다.") + "\n" +
+			styles.DescStyle.Render("마지막 인자가 기존 클러스터 주소이며, --seed를 지정하면 모든 인자가 새 노드입니다."),
 		Example: `  # 새 마스터 노드 추가 (슬롯 없음)
   redisctl add-node localhost:7007 localhost:7001
 
   # 특정 마스터의 복제본으로 노드 추가
-  redisctl add-node --master-id <master-node-id> localhost:7008 localhost:7001`,
-		Args: cobra.ExactArgs(2),
+  redisctl add-node --master-id <master-node-id> localhost:7008 localhost:7001
+
+  # 복제본이 가장 적은 마스터를 자동으로 골라 복제본으로 추가
+  redisctl add-node --as-replica 10.0.0.9:7001 10.0.0.1:7001
+
+  # 새 마스터를 추가하고 몫만큼의 슬롯을 바로 이동 (기존 마스터의 2배)
+  redisctl add-node --rebalance --weight 2 localhost:7007 localhost:7001
+
+  # 새 샤드(마스터 7007 + 복제본 7008)를 한 번에 추가
+  redisctl add-node --replica-of localhost:7008=localhost:7007 localhost:7007 localhost:7008 localhost:7001`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
 
-			return runAddNode(args[0], args[1], masterID)
+			parsed, err := parseReplicaOf(replicaOf)
+			if err != nil {
+				return err
+			}
+			opts.replicaOf = parsed
+
+			if cmd.Flags().Changed("weight") && !opts.rebalance {
+				return fmt.Errorf("--weight는 --rebalance와 함께 사용합니다")
+			}
+
+			// 기존 노드는 생략하고 --seed로 지정할 수 있다.
+			// REDIS_SEEDS는 명령줄에 보이지 않으므로 인자 해석을 바꾸지 않는다 (마지막 인자는 계속 기존 노드)
+			newNodes, existingNode := splitAddNodeArgs(args, cmd.Flags().Changed("seed"))
+			return runAddNode(cmd.Context(), newNodes, existingNode, opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&masterID, "master-id", "", "새 노드를 이 마스터의 복제본으로 만듭니다")
+	cmd.Flags().StringVar(&opts.masterID, "master-id", "", "새 노드를 이 마스터의 복제본으로 만듭니다 (새 노드가 하나일 때)")
+	cmd.Flags().BoolVar(&opts.asReplica, "as-replica", false, "복제본이 가장 적은 마스터를 자동으로 골라 복제본으로 추가")
+	cmd.Flags().StringArrayVar(&replicaOf, "replica-of", nil, "새 노드를 복제본으로 추가 new_ip:port=master_ip:port (반복 지정, 마스터는 새 노드 또는 기존 마스터)")
+	cmd.Flags().BoolVar(&opts.rebalance, "rebalance", false, "추가한 새 마스터에 몫만큼의 슬롯을 바로 이동")
+	cmd.Flags().Float64Var(&opts.weight, "weight", 1, "--rebalance 시 새 마스터의 가중치 (기존 마스터는 1)")
+	cmd.Flags().IntVar(&opts.pipeline, "pipeline", 10, "--rebalance 시 MIGRATE당 키 수")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "사전 점검 경고(버전/설정 불일치 등)도 오류로 처리해 중단")
 	return cmd
 }
 
-func runAddNode(newNode, existingNode, masterID string) error {
+// splitAddNodeArgs separates the new nodes from the trailing existing cluster address.
+// With an explicit --seed every argument is a new node; a single argument is always a new node.
+func splitAddNodeArgs(args []string, seeded bool) ([]string, string) {
+	if seeded || len(args) < 2 {
+		return args, ""
+	}
+	return args[:len(args)-1], args[len(args)-1]
+}
+
+// parseReplicaOf parses --replica-of replica=master values
+func parseReplicaOf(values []string) (map[string]string, error) {
+	replicaOf := make(map[string]string, len(values))
+	for _, value := range values {
+		replica, master, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("잘못된 --replica-of %q (형식: new_ip:port=master_ip:port)", value)
+		}
+		for _, addr := range []string{replica, master} {
+			if _, _, err := parseNodeAddress(addr); err != nil {
+				return nil, fmt.Errorf("잘못된 --replica-of %q: %w", value, err)
+			}
+		}
+		replica, master = normalizeClusterAddress(replica), normalizeClusterAddress(master)
+		if replica == master {
+			return nil, fmt.Errorf("잘못된 --replica-of %q: 노드가 자기 자신을 복제할 수 없습니다", value)
+		}
+		if _, dup := replicaOf[replica]; dup {
+			return nil, fmt.Errorf("--replica-of에 %s가 두 번 지정되었습니다", replica)
+		}
+		replicaOf[replica] = master
+	}
+	return replicaOf, nil
+}
+
+// validateAddNodeLayout checks the new nodes and the replica mapping before anything is changed
+func validateAddNodeLayout(newNodes []string, opts addNodeOptions) error {
+	seen := make(map[string]bool, len(newNodes))
+	for _, node := range newNodes {
+		if _, _, err := parseNodeAddress(node); err != nil {
+			return fmt.Errorf("잘못된 새 노드 주소 %q: %w", node, err)
+		}
+		if seen[node] {
+			return fmt.Errorf("새 노드 %s가 두 번 지정되었습니다", node)
+		}
+		seen[node] = true
+	}
+
+	if opts.masterID != "" {
+		if len(newNodes) > 1 {
+			return fmt.Errorf("--master-id는 새 노드가 하나일 때만 사용할 수 있습니다 (여러 노드는 --replica-of 사용)")
+		}
+		if len(opts.replicaOf) > 0 {
+			return fmt.Errorf("--master-id와 --replica-of는 함께 사용할 수 없습니다")
+		}
+	}
+
+	if opts.asReplica && (opts.masterID != "" || len(opts.replicaOf) > 0) {
+		return fmt.Errorf("--as-replica는 --master-id, --replica-of와 함께 사용할 수 없습니다")
+	}
+	if opts.rebalance {
+		if opts.asReplica || opts.masterID != "" || len(opts.replicaOf) == len(newNodes) {
+			return fmt.Errorf("--rebalance는 마스터로 추가되는 새 노드가 있을 때만 사용할 수 있습니다")
+		}
+		if opts.weight <= 0 {
+			return fmt.Errorf("--weight는 0보다 커야 합니다 (%g)", opts.weight)
+		}
+	}
+
+	for replica, master := range opts.replicaOf {
+		if !seen[replica] {
+			return fmt.Errorf("--replica-of의 복제본 %s는 이번에 추가하는 새 노드가 아닙니다", replica)
+		}
+		if _, chained := opts.replicaOf[master]; chained {
+			return fmt.Errorf("--replica-of의 마스터 %s는 복제본으로 추가되는 노드입니다", master)
+		}
+	}
+	return nil
+}
+
+func runAddNode(ctx context.Context, newNodes []string, existingNode string, opts addNodeOptions) error {
+	for i, node := range newNodes {
+		newNodes[i] = normalizeClusterAddress(node)
+	}
+	if err := validateAddNodeLayout(newNodes, opts); err != nil {
+		return err
+	}
+
+	seeds, err := clusterSeeds(existingNode)
+	if err != nil {
+		return err
+	}
+	for _, seed := range seeds {
+		if stringSliceContains(newNodes, normalizeClusterAddress(seed)) {
+			return fmt.Errorf("%s는 새 노드이면서 기존 클러스터 주소로 지정되었습니다", seed)
+		}
+	}
+
+	// 새 노드별 마스터 (주소는 --replica-of와 --as-replica 자동 선택, ID는 --master-id)
+	masters := make(map[string]string, len(newNodes))
+	for replica, master := range opts.replicaOf {
+		masters[replica] = master
+	}
+	reasons := make(map[string]string) // --as-replica 선택 이유
+	masterOf := func(node string) string {
+		if opts.masterID != "" {
+			return opts.masterID
+		}
+		return masters[node]
+	}
+	isReplica := func(node string) bool {
+		return opts.asReplica || masterOf(node) != ""
+	}
+
 	fmt.Println(styles.InfoStyle.Render("노드 추가 시작..."))
-	fmt.Printf("새 노드: %s\n", newNode)
-	fmt.Printf("기존 노드: %s\n", existingNode)
+	fmt.Printf("새 노드: %s\n", strings.Join(newNodes, ", "))
+	fmt.Printf("기존 노드: %s\n", strings.Join(seeds, ", "))
 
-	if masterID != "" {
-		fmt.Printf("복제본으로 추가 (마스터 ID: %s)\n", masterID)
-	} else {
-		fmt.Println("마스터로 추가 (슬롯 없음)")
+	for _, node := range newNodes {
+		prefix := ""
+		if len(newNodes) > 1 {
+			prefix = node + ": "
+		}
+		switch {
+		case opts.asReplica:
+			fmt.Printf("%s복제본으로 추가 (마스터 자동 선택)\n", prefix)
+		case opts.masterID != "":
+			fmt.Printf("%s복제본으로 추가 (마스터 ID: %s)\n", prefix, opts.masterID)
+		case masterOf(node) != "":
+			fmt.Printf("%s복제본으로 추가 (마스터: %s)\n", prefix, masterOf(node))
+		default:
+			fmt.Printf("%s마스터로 추가 (슬롯 없음)\n", prefix)
+		}
+	}
+
+	// 존 라벨 (--label, --topology-file, 로컬 인벤토리)
+	inv, err := loadInventory()
+	if err != nil {
+		return err
 	}
+	zones := inv.Zones()
 
 	user, password := config.GetAuth()
-	cm := redis.NewClusterManager(user, password)
+	cm := redis.NewClusterManager(ctx, user, password)
 	defer cm.Close()
 
-	ctx := context.Background()
-
 	// 1단계: 기존 클러스터 검증
 	fmt.Println(styles.InfoStyle.Render("1단계: 기존 클러스터 검증 중..."))
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("기존 클러스터 연결 실패: %w", err)
+	}
+	existingNode = selection.Address
+
 	fmt.Printf("  %s 연결 및 클러스터 상태 확인...", existingNode)
 
-	_, err := cm.Connect(existingNode)
+	_, err = cm.Connect(existingNode)
 	if err != nil {
 		fmt.Printf(" %s\n", styles.RenderError("연결 실패"))
 		return fmt.Errorf("기존 노드 %s 연결 실패: %w", existingNode, err)
@@ -79,102 +263,196 @@ func runAddNode(newNode, existingNode, masterID string) error {
 
 	// 2단계: 새 노드 검증
 	fmt.Println(styles.InfoStyle.Render("2단계: 새 노드 검증 중..."))
-	fmt.Printf("  %s 연결 및 빈 노드 확인...", newNode)
 
-	newClient, err := cm.Connect(newNode)
-	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderError("연결 실패"))
-		return fmt.Errorf("새 노드 %s 연결 실패: %w", newNode, err)
-	}
+	newClients := make(map[string]*redisv9.Client, len(newNodes))
+	for _, newNode := range newNodes {
+		fmt.Printf("  %s 연결 및 빈 노드 확인...", newNode)
+
+		newClient, err := cm.Connect(newNode)
+		if err != nil {
+			fmt.Printf(" %s\n", styles.RenderError("연결 실패"))
+			return fmt.Errorf("새 노드 %s 연결 실패: %w", newNode, err)
+		}
+		newClients[newNode] = newClient
 
-	// 새 노드가 이미 클러스터에 속해있는지 확인
-	newClusterInfo, err := cm.GetClusterInfo(newNode)
-	if err == nil && newClusterInfo["cluster_state"] != "fail" {
-		fmt.Printf(" %s\n", styles.RenderError("이미 클러스터에 참여 중"))
-		host, port, _ := parseNodeAddress(newNode)
-		return fmt.Errorf(`노드 %s는 이미 다른 클러스터에 참여 중입니다
+		// 새 노드가 이미 클러스터에 속해있는지 확인
+		newClusterInfo, err := cm.GetClusterInfo(newNode)
+		if err == nil && newClusterInfo["cluster_state"] != "fail" {
+			fmt.Printf(" %s\n", styles.RenderError("이미 클러스터에 참여 중"))
+			host, port, _ := parseNodeAddress(newNode)
+			return fmt.Errorf(`노드 %s는 이미 다른 클러스터에 참여 중입니다
 
  해결 방법:
    redis-cli -h %s -p %s cluster reset hard
 
  주의: 이 명령은 노드의 모든 클러스터 데이터를 삭제합니다`,
-			newNode, host, port)
+				newNode, host, port)
+		}
+
+		fmt.Printf(" %s\n", styles.RenderSuccess("검증 완료"))
 	}
 
-	fmt.Printf(" %s\n", styles.RenderSuccess("검증 완료"))
+	// 기존 노드를 기준으로 버전/설정이 맞는지 확인
+	if err := runPreflight(ctx, cm, newNodes, existingNode, opts.strict); err != nil {
+		return err
+	}
 
 	// 3단계: 마스터 노드 검증 (복제본인 경우만)
-	if masterID != "" {
+	replicas := make([]string, 0, len(newNodes))
+	for _, node := range newNodes {
+		if isReplica(node) {
+			replicas = append(replicas, node)
+		}
+	}
+
+	if len(replicas) > 0 {
 		fmt.Println(styles.InfoStyle.Render("3단계: 마스터 노드 검증 중..."))
-		fmt.Printf("  마스터 ID %s 확인...", masterID)
 
-		clusterNodes, err := cm.GetClusterNodes(existingNode)
+		topology, err := cm.LoadTopology(existingNode)
 		if err != nil {
-			fmt.Printf(" %s\n", styles.RenderError("클러스터 노드 조회 실패"))
 			return fmt.Errorf("클러스터 노드 정보 조회 실패: %w", err)
 		}
 
-		masterFound := false
-		for _, node := range clusterNodes {
-			if node.ID == masterID {
-				// 마스터 노드인지 확인
-				for _, flag := range node.Flags {
-					if flag == "master" {
-						masterFound = true
-						break
-					}
+		added := make(map[string]int) // 이번 실행에서 배정한 복제본 수 (마스터 ID별)
+		for _, newNode := range replicas {
+			if opts.asReplica {
+				fmt.Printf("  %s의 마스터 자동 선택...", newNode)
+				choice, err := chooseReplicaMaster(topology, newNode, added)
+				if err != nil {
+					fmt.Printf(" %s\n", styles.RenderError("선택 실패"))
+					return err
 				}
-				break
+				masters[newNode] = choice.Master.HostPort()
+				reasons[newNode] = choice.Reason
+				added[choice.Master.ID]++
+				fmt.Printf(" %s\n", styles.RenderSuccess(choice.Master.HostPort()))
+				fmt.Printf("    %s\n", styles.DescStyle.Render(choice.Reason))
 			}
-		}
 
-		if !masterFound {
-			fmt.Printf(" %s\n", styles.RenderError("마스터 노드를 찾을 수 없음"))
-			return fmt.Errorf("지정된 마스터 ID %s는 존재하지 않거나 마스터 노드가 아닙니다", masterID)
-		}
+			master := masterOf(newNode)
+			fmt.Printf("  %s의 마스터 %s 확인...", newNode, master)
+
+			// 같은 실행에서 추가되는 마스터는 아직 클러스터에 없다
+			masterAddr := master
+			if !stringSliceContains(newNodes, master) {
+				var masterNode redis.ClusterNode
+				var ok bool
+				if opts.masterID != "" {
+					masterNode, ok = topology.NodeByID(master)
+				} else {
+					masterNode, ok = topology.NodeByAddress(master)
+				}
+				if !ok || !masterNode.IsMaster() {
+					fmt.Printf(" %s\n", styles.RenderError("마스터 노드를 찾을 수 없음"))
+					return fmt.Errorf("지정된 마스터 %s는 존재하지 않거나 마스터 노드가 아닙니다", master)
+				}
+				masterAddr = masterNode.HostPort()
+			}
+
+			fmt.Printf(" %s\n", styles.RenderSuccess("마스터 노드 확인됨"))
 
-		fmt.Printf(" %s\n", styles.RenderSuccess("마스터 노드 확인됨"))
+			// 마스터와 같은 장애 도메인이면 함께 잃을 수 있으므로 경고만 표시
+			switch placementConflict(zones, newNode, masterAddr) {
+			case 2:
+				fmt.Printf("  %s\n", styles.RenderWarning(fmt.Sprintf("새 노드가 마스터 %s와 같은 호스트에 있습니다 (호스트 장애 시 샤드 전체 손실)", masterAddr)))
+			case 1:
+				fmt.Printf("  %s\n", styles.RenderWarning(fmt.Sprintf("새 노드가 마스터 %s와 같은 존(%s)에 있습니다 (존 장애 시 샤드 전체 손실)", masterAddr, nodeZone(zones, newNode))))
+			}
+		}
 	}
 
-	// 4단계: CLUSTER MEET으로 노드 추가 (Redis 네이티브 방식)
+	// 4단계: CLUSTER MEET으로 노드 추가 (Redis 네이티브 방식, 새 노드별로 병렬 실행)
+	// 기존 클러스터 멤버가 새 노드를 MEET하고, 나머지 멤버에게는 가십으로 전파된다
 	fmt.Println(styles.InfoStyle.Render("4단계: 클러스터에 노드 추가 중..."))
-	fmt.Printf("  CLUSTER MEET 실행 중...")
 
-	host, port, err := parseNodeAddress(newNode)
+	existingClient, err := cm.Connect(existingNode)
 	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderError("주소 파싱 실패"))
-		return fmt.Errorf("새 노드 주소 파싱 실패: %w", err)
+		return fmt.Errorf("기존 노드 %s 연결 실패: %w", existingNode, err)
 	}
 
-	err = newClient.ClusterMeet(ctx, host, port).Err()
+	// 롤백 후 비교할 MEET 전 멤버십
+	originalNodes, err := cm.GetClusterNodes(existingNode)
 	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderError("CLUSTER MEET 실패"))
-		return fmt.Errorf("CLUSTER MEET 명령 실패: %w", err)
+		return fmt.Errorf("클러스터 노드 정보 조회 실패: %w", err)
+	}
+	original := clusterMembership(originalNodes)
+
+	meetErrs := make([]error, len(newNodes))
+	var wg sync.WaitGroup
+	for i, newNode := range newNodes {
+		wg.Add(1)
+		go func(i int, newNode string) {
+			defer wg.Done()
+			host, port, err := parseNodeAddress(newNode)
+			if err != nil {
+				meetErrs[i] = fmt.Errorf("새 노드 주소 파싱 실패: %w", err)
+				return
+			}
+			if err := existingClient.ClusterMeet(ctx, host, port).Err(); err != nil {
+				meetErrs[i] = fmt.Errorf("CLUSTER MEET 명령 실패 (%s): %w", newNode, err)
+			}
+		}(i, newNode)
+	}
+	wg.Wait()
+
+	// MEET 이후 실패하거나 중단되면 이번에 추가한 모든 노드를 클러스터에서 되돌린다
+	rollback := func(err error) error {
+		rollbackAddNodes(cm.WithContext(context.WithoutCancel(ctx)), existingNode, newNodes, original)
+		return err
+	}
+	abortIfInterrupted := func() error {
+		if ctx.Err() == nil {
+			return nil
+		}
+		fmt.Println()
+		return rollback(ErrInterrupted)
+	}
+
+	var meetErr error
+	for i, newNode := range newNodes {
+		fmt.Printf("  %s → %s CLUSTER MEET...", existingNode, newNode)
+		if meetErrs[i] != nil {
+			fmt.Printf(" %s\n", styles.RenderError("CLUSTER MEET 실패"))
+			meetErr = errors.Join(meetErr, meetErrs[i])
+			continue
+		}
+		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
+	}
+	if meetErr != nil {
+		return rollback(meetErr)
 	}
 
-	fmt.Printf(" %s\n", styles.RenderSuccess("노드 추가 완료"))
+	// 5단계: 모든 멤버가 새 노드를 handshake 없이 알 때까지 한 번 대기한 뒤 복제본 설정
+	// (복제 설정과 슬롯 이동 전에 모든 노드가 새 노드를 알아야 한다)
+	fmt.Println(styles.InfoStyle.Render("5단계: 핸드셰이크 확인 및 복제본 설정 중..."))
+	fmt.Print("  모든 노드가 새 노드를 인식할 때까지 대기 중...")
 
-	// 5단계: 복제본 설정 (필요한 경우만)
-	if masterID != "" {
-		fmt.Println(styles.InfoStyle.Render("5단계: 복제본 설정 중..."))
+	err = waitForNodesJoined(ctx, cm, existingNode, newNodes, 30*time.Second)
+	if errors.Is(err, ErrInterrupted) {
+		return abortIfInterrupted()
+	}
+	if err != nil {
+		fmt.Printf(" %s\n", styles.RenderError("수렴 실패"))
+		fmt.Println(styles.DescStyle.Render("  클러스터 버스 포트(포트+10000)가 방화벽에 막혀 있지 않은지, cluster-announce-ip/port 설정이 맞는지 확인하세요."))
+		return rollback(fmt.Errorf("새 노드 핸드셰이크 실패: %w", err))
+	}
+	fmt.Printf(" %s\n", styles.RenderSuccess("수렴 완료"))
 
-		// Redis 네이티브 방식: 1초 대기 후 클러스터 수렴 대기
-		fmt.Print("  클러스터 수렴 대기 중...")
-		time.Sleep(1 * time.Second)
+	for _, newNode := range replicas {
+		if err := abortIfInterrupted(); err != nil {
+			return err
+		}
 
-		err := waitForClusterJoin(cm, existingNode, 30*time.Second)
+		masterID, err := resolveAddNodeMaster(ctx, cm, existingNode, masterOf(newNode), opts.masterID != "", newClients)
 		if err != nil {
-			fmt.Printf(" %s\n", styles.RenderWarning("타임아웃"))
-			fmt.Println(styles.WarningStyle.Render("  경고: 클러스터 수렴을 기다리다 타임아웃되었습니다. 복제본 설정을 시도합니다."))
-		} else {
-			fmt.Printf(" %s\n", styles.RenderSuccess("수렴 완료"))
+			return rollback(err)
 		}
 
-		fmt.Printf("  복제본 설정 중...")
-		err = newClient.ClusterReplicate(ctx, masterID).Err()
+		fmt.Printf("  %s 복제본 설정 중...", newNode)
+		err = newClients[newNode].ClusterReplicate(ctx, masterID).Err()
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("복제 설정 실패"))
-			return fmt.Errorf("CLUSTER REPLICATE 명령 실패: %w", err)
+			return rollback(fmt.Errorf("CLUSTER REPLICATE 명령 실패 (%s): %w", newNode, err))
 		}
 
 		fmt.Printf(" %s\n", styles.RenderSuccess("복제본 설정 완료"))
@@ -182,145 +460,414 @@ func runAddNode(newNode, existingNode, masterID string) error {
 
 	// 6단계: 최종 확인
 	fmt.Println(styles.InfoStyle.Render("6단계: 추가 완료 확인 중..."))
-	fmt.Print("  새 노드 정보 조회...")
 
-	newNodeID, err := newClient.ClusterMyID(ctx).Result()
-	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderError("실패"))
-		return fmt.Errorf("새 노드 ID 조회 실패: %w", err)
+	newNodeIDs := make(map[string]string, len(newNodes))
+	for _, newNode := range newNodes {
+		fmt.Printf("  %s 노드 정보 조회...", newNode)
+
+		newNodeID, err := newClients[newNode].ClusterMyID(ctx).Result()
+		if err != nil {
+			fmt.Printf(" %s\n", styles.RenderError("실패"))
+			return rollback(fmt.Errorf("새 노드 ID 조회 실패 (%s): %w", newNode, err))
+		}
+		newNodeIDs[newNode] = newNodeID
+
+		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
 	}
 
-	fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
+	// 7단계: 새 마스터에 슬롯 분배 (--rebalance)
+	var finalSlots map[string]int
+	if opts.rebalance {
+		newMasterIDs := make(map[string]bool)
+		for _, newNode := range newNodes {
+			if !isReplica(newNode) {
+				newMasterIDs[newNodeIDs[newNode]] = true
+			}
+		}
+		finalSlots, err = rebalanceNewMasters(ctx, existingNode, newMasterIDs, opts.weight, opts.pipeline)
+		if err != nil {
+			// 슬롯이 옮겨지기 전이면 되돌리고, 이미 슬롯(데이터)을 가진 새 마스터는 남겨둔다
+			if !newMastersHoldSlots(cm.WithContext(context.WithoutCancel(ctx)), existingNode, newMasterIDs) {
+				return rollback(err)
+			}
+			fmt.Println(styles.DescStyle.Render("  새 마스터가 이미 슬롯을 받아 롤백하지 않습니다. 'redisctl rebalance'로 슬롯 분배를 이어서 진행하세요."))
+			return err
+		}
+	}
 
 	// 성공 메시지
 	fmt.Println()
-	fmt.Println(styles.RenderSuccess("노드가 성공적으로 클러스터에 추가되었습니다!"))
+	if len(newNodes) > 1 {
+		fmt.Println(styles.RenderSuccess(fmt.Sprintf("노드 %d개가 성공적으로 클러스터에 추가되었습니다!", len(newNodes))))
+	} else {
+		fmt.Println(styles.RenderSuccess("노드가 성공적으로 클러스터에 추가되었습니다!"))
+	}
 	fmt.Println()
 
 	// 추가된 노드 정보 표시
-	nodeInfo := styles.SubtitleStyle.Render("추가된 노드 정보") + "\n" +
-		fmt.Sprintf("• 노드 ID: %s\n", newNodeID) +
-		fmt.Sprintf("• 주소: %s\n", newNode) +
-		fmt.Sprintf("• 역할: %s\n", func() string {
-			if masterID != "" {
-				return "복제본"
+	nodeInfo := styles.SubtitleStyle.Render("추가된 노드 정보") + "\n"
+	for i, newNode := range newNodes {
+		if i > 0 {
+			nodeInfo += "\n"
+		}
+		nodeInfo += fmt.Sprintf("• 노드 ID: %s\n", newNodeIDs[newNode]) +
+			fmt.Sprintf("• 주소: %s\n", newNode)
+
+		if master := masterOf(newNode); master != "" {
+			nodeInfo += "• 역할: 복제본\n" +
+				fmt.Sprintf("• 마스터: %s\n", master)
+			if reason := reasons[newNode]; reason != "" {
+				nodeInfo += fmt.Sprintf("• 선택 이유: %s\n", reason)
 			}
-			return "마스터"
-		}())
-
-	if masterID != "" {
-		nodeInfo += fmt.Sprintf("• 마스터: %s\n", masterID)
-	} else {
-		nodeInfo += "• 슬롯: 0개 (새 마스터는 슬롯이 없습니다)\n"
+		} else if opts.rebalance {
+			nodeInfo += "• 역할: 마스터\n" +
+				fmt.Sprintf("• 슬롯: %d개 (리밸런싱, 가중치 %g)\n", finalSlots[newNodeIDs[newNode]], opts.weight)
+		} else {
+			nodeInfo += "• 역할: 마스터\n" +
+				"• 슬롯: 0개 (새 마스터는 슬롯이 없습니다)\n"
+		}
 	}
 
-	fmt.Println(styles.BoxStyle.Render(nodeInfo))
+	fmt.Println(styles.BoxStyle.Render(strings.TrimSuffix(nodeInfo, "\n")))
 
-	if masterID == "" {
+	if len(replicas) < len(newNodes) && !opts.rebalance {
 		fmt.Println()
 		fmt.Println(styles.InfoStyle.Render("참고: 새 마스터 노드에는 슬롯이 할당되지 않았습니다."))
-		fmt.Println(styles.DescStyle.Render("   슬롯을 할당하려면 'reshard' 명령을 사용하세요."))
+		fmt.Println(styles.DescStyle.Render("   슬롯을 할당하려면 'reshard' 명령을 사용하거나 --rebalance로 추가하세요."))
 	}
 
 	return nil
 }
 
-// add-node 롤백 함수 (CLUSTER MEET 성공 후 CLUSTER REPLICATE 실패 시)
-func rollbackAddNode(cm *redis.ClusterManager, newNode, existingNode string) {
-	fmt.Println(styles.WarningStyle.Render("노드 추가 실패 - 롤백 중..."))
+// rebalanceNewMasters moves slots to the new (empty) masters with the rebalance planner,
+// counting them as receivers with weight; existing masters weigh 1.
+// Returns the final slot count per master ID.
+func rebalanceNewMasters(ctx context.Context, existingNode string, newMasterIDs map[string]bool, weight float64, pipeline int) (map[string]int, error) {
+	fmt.Println(styles.InfoStyle.Render("7단계: 새 마스터에 슬롯 분배 중..."))
 
-	// 새 노드를 클러스터에서 제거하기 위해 CLUSTER RESET 실행
-	fmt.Printf("  %s 초기화 중...", newNode)
+	client := redis.NewClusterClient(existingNode)
+	defer client.Close()
 
-	newClient, err := cm.Connect(newNode)
+	topology, err := redis.LoadClusterTopology(ctx, client)
 	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderWarning("연결 실패"))
-		return
+		return nil, fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
 	}
+	masters := topology.Masters()
 
-	// 새 노드 리셋
-	ctx := context.Background()
-	err = newClient.Do(ctx, "CLUSTER", "RESET", "HARD").Err()
-	if err != nil {
-		fmt.Printf(" %s\n", styles.RenderWarning("실패"))
+	weights := make(map[string]float64, len(newMasterIDs))
+	for id := range newMasterIDs {
+		node, ok := topology.NodeByID(id)
+		if !ok || !node.IsMaster() {
+			return nil, fmt.Errorf("새 마스터 %s가 아직 클러스터 뷰에 없습니다", id)
+		}
+		weights[id] = weight
+	}
+
+	plan := generateRebalancePlan(masters, weights)
+	if len(plan) == 0 {
+		fmt.Println(styles.DescStyle.Render("  이동할 슬롯이 없습니다"))
 	} else {
-		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
+		displayRebalancePlan(plan, masters)
+		if err := executeRebalancePlan(ctx, client, plan, pipeline); err != nil {
+			return nil, fmt.Errorf("슬롯 분배 실패: %w", err)
+		}
 	}
 
-	// 기존 클러스터에서 새 노드를 잊게 하기 (best effort)
-	existingClient, err := cm.Connect(existingNode)
-	if err == nil {
-		// 새 노드의 ID를 얻기 위해 클러스터 노드 조회 시도
-		clusterNodes, err := cm.GetClusterNodes(existingNode)
-		if err == nil {
-			newHost, newPort, _ := parseNodeAddress(newNode)
-			expectedAddress := fmt.Sprintf("%s:%s", newHost, newPort)
-
-			for _, node := range clusterNodes {
-				nodeAddress := normalizeClusterAddress(node.Address)
-				if nodeAddress == expectedAddress {
-					// CLUSTER FORGET으로 새 노드 제거
-					existingClient.Do(ctx, "CLUSTER", "FORGET", node.ID).Err()
-					break
-				}
-			}
+	// 최종 분배
+	after, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+	finalMasters := after.Masters()
+	finalSlots := make(map[string]int, len(finalMasters))
+
+	fmt.Println()
+	fmt.Println(styles.InfoStyle.Render("최종 슬롯 분배:"))
+	for _, master := range finalMasters {
+		finalSlots[master.ID] = master.SlotCount()
+		marker := ""
+		if newMasterIDs[master.ID] {
+			marker = " " + styles.SuccessStyle.Render("(새 마스터)")
 		}
+		fmt.Printf("  %s: %s 슬롯%s\n",
+			styles.HighlightStyle.Render(master.Endpoint()),
+			styles.HighlightStyle.Render(strconv.Itoa(master.SlotCount())), marker)
+	}
+	// 불균형도는 균등 분배 기준이므로 가중치가 없을 때만 표시
+	if weight == 1 {
+		fmt.Printf("불균형도: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%.1f%%", calculateImbalance(finalMasters))))
 	}
+
+	return finalSlots, nil
 }
 
-// waitForClusterJoin waits for all nodes in the cluster to have consistent configuration
-// This is Redis's native approach: wait for global cluster consistency
-func waitForClusterJoin(cm *redis.ClusterManager, existingNode string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
+// newMastersHoldSlots reports whether any new master owns slots; when the topology cannot be read
+// it assumes so, since a rollback would then risk dropping migrated data
+func newMastersHoldSlots(cm *redis.ClusterManager, existingNode string, newMasterIDs map[string]bool) bool {
+	topology, err := cm.LoadTopology(existingNode)
+	if err != nil {
+		return true
+	}
+	for id := range newMasterIDs {
+		if node, ok := topology.NodeByID(id); ok && node.SlotCount() > 0 {
+			return true
+		}
+	}
+	return false
+}
 
-	for time.Now().Before(deadline) {
-		// Get all nodes from the existing cluster node perspective
-		clusterNodes, err := cm.GetClusterNodes(existingNode)
+// resolveAddNodeMaster returns the node ID of the master a new replica should follow:
+// master is a node ID with --master-id, otherwise the address of a new or existing master
+func resolveAddNodeMaster(ctx context.Context, cm *redis.ClusterManager, existingNode, master string, isID bool, newClients map[string]*redisv9.Client) (string, error) {
+	if isID {
+		return master, nil
+	}
+	if client, ok := newClients[master]; ok {
+		id, err := client.ClusterMyID(ctx).Result()
 		if err != nil {
-			time.Sleep(1 * time.Second)
-			continue
+			return "", fmt.Errorf("새 마스터 %s의 노드 ID 조회 실패: %w", master, err)
 		}
+		return id, nil
+	}
+
+	topology, err := cm.LoadTopology(existingNode)
+	if err != nil {
+		return "", fmt.Errorf("클러스터 노드 정보 조회 실패: %w", err)
+	}
+	node, ok := topology.NodeByAddress(master)
+	if !ok {
+		return "", fmt.Errorf("마스터 %s를 클러스터에서 찾을 수 없습니다", master)
+	}
+	return node.ID, nil
+}
 
-		// Check if all nodes have consistent view of the cluster
-		consistent := true
-		var firstSignature string
+// rollbackAddNodes undoes a failed add-node after MEET. Every original member forgets the nodes it
+// did not know before (new nodes and their handshake entries) in parallel, so that all of them are
+// blacklisted at once and gossip cannot re-add them, then the new nodes are hard reset (new node ID)
+// within the blacklist window. Finally the membership is compared with the one before MEET.
+func rollbackAddNodes(cm *redis.ClusterManager, existingNode string, newNodes []string, original map[string]bool) {
+	ctx := context.Background()
+	fmt.Println()
+	fmt.Println(styles.WarningStyle.Render("노드 추가 실패 - 롤백 중..."))
 
-		for i, node := range clusterNodes {
-			// Skip nodes that are unreachable or have connection issues
-			if stringSliceContains(node.Flags, "fail") || stringSliceContains(node.Flags, "disconnected") {
-				continue
+	// 1. 잊게 할 노드: 기존 멤버가 아는 노드 중 MEET 전에 없던 노드와 새 노드 자신의 ID
+	forget := make(map[string]bool)
+	members := make([]string, 0, len(original))
+	if nodes, err := cm.GetClusterNodes(existingNode); err == nil {
+		for _, id := range extraClusterNodes(original, nodes) {
+			forget[id] = true
+		}
+		for _, node := range nodes {
+			if original[node.ID] && !node.IsFail() && !node.IsNoAddr() {
+				// FORGET과 멤버십 확인은 TLS 클러스터에서도 연결되는 주소로 보낸다
+				members = append(members, node.Endpoint())
 			}
-
-			nodeAddr := node.Address
-			if nodeAddr == "" {
-				continue
+		}
+	} else {
+		fmt.Printf("  %s\n", styles.RenderWarning(fmt.Sprintf("%s 클러스터 노드 조회 실패: %v", existingNode, err)))
+		members = append(members, existingNode)
+	}
+	for _, newNode := range newNodes {
+		if client, err := cm.Connect(newNode); err == nil {
+			if id, err := client.ClusterMyID(ctx).Result(); err == nil {
+				forget[id] = true
 			}
-
-			nodeClusterNodes, err := cm.GetClusterNodes(nodeAddr)
+		}
+	}
+	forgetIDs := make([]string, 0, len(forget))
+	for id := range forget {
+		forgetIDs = append(forgetIDs, id)
+	}
+	sort.Strings(forgetIDs)
+
+	// 2. 모든 기존 멤버에서 CLUSTER FORGET (60초 블랙리스트 안에 끝나도록 병렬 실행)
+	fmt.Printf("  1) 기존 노드 %d개에서 CLUSTER FORGET (노드 %d개)\n", len(members), len(forgetIDs))
+	forgetErrs := make([]error, len(members))
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func(i int, member string) {
+			defer wg.Done()
+			client, err := cm.Connect(member)
 			if err != nil {
-				// If we can't reach this node, wait and try again
-				consistent = false
-				break
+				forgetErrs[i] = err
+				return
 			}
+			for _, id := range forgetIDs {
+				// 이미 모르는 노드는 Unknown node 오류를 낸다
+				err := client.ClusterForget(ctx, id).Err()
+				if err != nil && !strings.Contains(err.Error(), "Unknown node") {
+					forgetErrs[i] = errors.Join(forgetErrs[i], fmt.Errorf("%s: %w", id, err))
+				}
+			}
+		}(i, member)
+	}
+	wg.Wait()
+	for i, member := range members {
+		if forgetErrs[i] != nil {
+			fmt.Printf("     %s %s\n", member, styles.RenderWarning(fmt.Sprintf("실패 (%v)", forgetErrs[i])))
+		} else {
+			fmt.Printf("     %s %s\n", member, styles.RenderSuccess("완료"))
+		}
+	}
 
-			// Create a simple signature from the cluster nodes info
-			signature := createClusterSignature(nodeClusterNodes)
-			if i == 0 {
-				firstSignature = signature
-			} else if firstSignature != signature {
-				consistent = false
-				break
+	// 3. 새 노드 초기화 (새 노드 ID로 바뀌고 다른 노드를 모두 잊는다)
+	fmt.Printf("  2) 새 노드 %d개 CLUSTER RESET HARD\n", len(newNodes))
+	for _, newNode := range newNodes {
+		client, err := cm.Connect(newNode)
+		if err == nil {
+			err = client.ClusterResetHard(ctx).Err()
+		}
+		if err != nil {
+			fmt.Printf("     %s %s\n", newNode, styles.RenderWarning(fmt.Sprintf("실패 (%v)", err)))
+		} else {
+			fmt.Printf("     %s %s\n", newNode, styles.RenderSuccess("완료"))
+		}
+	}
+
+	// 4. 모든 기존 멤버의 노드 목록이 MEET 전과 같은지 확인
+	fmt.Print("  3) 클러스터 멤버십 확인...")
+	var remaining []string
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		remaining = remaining[:0]
+		for _, member := range members {
+			nodes, err := cm.GetClusterNodes(member)
+			if err != nil {
+				remaining = append(remaining, fmt.Sprintf("%s: 조회 실패 (%v)", member, err))
+				continue
 			}
+			if extra := extraClusterNodes(original, nodes); len(extra) > 0 {
+				remaining = append(remaining, fmt.Sprintf("%s가 아직 %s를 알고 있습니다", member, strings.Join(extra, ", ")))
+			}
+		}
+		if len(remaining) == 0 || !time.Now().Before(deadline) {
+			break
 		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	if len(remaining) == 0 {
+		fmt.Printf(" %s\n", styles.RenderSuccess(fmt.Sprintf("원래 구성으로 복구됨 (노드 %d개)", len(original))))
+		return
+	}
+	fmt.Printf(" %s\n", styles.RenderWarning("원래 구성과 다름"))
+	for _, problem := range remaining {
+		fmt.Printf("     - %s\n", problem)
+	}
+	fmt.Println(styles.DescStyle.Render("  60초 안에 남은 노드에서 'redis-cli -h <host> -p <port> cluster forget <node-id>'를 실행하고 'redisctl check'로 확인하세요."))
+}
+
+// clusterMembership returns the IDs of the nodes a member knows
+func clusterMembership(nodes []redis.ClusterNode) map[string]bool {
+	ids := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		ids[node.ID] = true
+	}
+	return ids
+}
+
+// extraClusterNodes returns the IDs in nodes that are not in the original membership, sorted
+func extraClusterNodes(original map[string]bool, nodes []redis.ClusterNode) []string {
+	var extra []string
+	for _, node := range nodes {
+		if !original[node.ID] {
+			extra = append(extra, node.ID)
+		}
+	}
+	sort.Strings(extra)
+	return extra
+}
 
-		if consistent {
+// waitForClusterJoin waits for all nodes in the cluster to have consistent configuration
+// This is Redis's native approach: wait for global cluster consistency
+func waitForClusterJoin(ctx context.Context, cm *redis.ClusterManager, existingNode string, timeout time.Duration) error {
+	return waitForNodesJoined(ctx, cm, existingNode, nil, timeout)
+}
+
+// waitForNodesJoined waits until every cluster member lists the new nodes (without handshake)
+// and all members agree on the cluster view. On timeout the error lists what was still missing.
+func waitForNodesJoined(ctx context.Context, cm *redis.ClusterManager, existingNode string, newNodes []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	var problems []string
+	for {
+		problems = clusterJoinProblems(collectClusterViews(cm, existingNode), newNodes)
+		if len(problems) == 0 {
 			return nil
 		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		if err := sleepContext(ctx, 1*time.Second); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("클러스터가 %s 내에 수렴하지 않았습니다:\n  - %s", timeout, strings.Join(problems, "\n  - "))
+}
+
+// clusterView is the CLUSTER NODES output of one member
+type clusterView struct {
+	Address  string
+	Topology *redis.Topology
+	Err      error
+}
+
+// collectClusterViews loads the topology seen by existingNode and by every member it knows,
+// skipping failed, disconnected and not yet joined (handshake, noaddr) members
+func collectClusterViews(cm *redis.ClusterManager, existingNode string) []clusterView {
+	topology, err := cm.LoadTopology(existingNode)
+	if err != nil {
+		return []clusterView{{Address: existingNode, Err: err}}
+	}
 
-		time.Sleep(1 * time.Second)
+	views := []clusterView{{Address: existingNode, Topology: topology}}
+	for _, node := range topology.Nodes {
+		if node.IsMyself() || node.IsFail() || !node.IsConnected() || node.IsHandshake() || node.IsNoAddr() {
+			continue
+		}
+		// TLS 클러스터에서는 tls-port로 연결하고, 표시는 노드 주소로 한다
+		nodeTopology, err := cm.LoadTopology(node.Endpoint())
+		views = append(views, clusterView{Address: node.HostPort(), Topology: nodeTopology, Err: err})
 	}
+	return views
+}
+
+// clusterJoinProblems describes why the views do not show a joined cluster yet:
+// a member that cannot be read, a new node that a member does not know or still
+// sees in handshake, or a member whose view differs from the first one
+func clusterJoinProblems(views []clusterView, newNodes []string) []string {
+	var problems []string
+	var firstSignature, firstAddress string
+
+	for _, view := range views {
+		if view.Err != nil {
+			problems = append(problems, fmt.Sprintf("%s: 클러스터 토폴로지 조회 실패 (%v)", view.Address, view.Err))
+			continue
+		}
 
-	return fmt.Errorf("timeout waiting for cluster to join")
+		topology := view.Topology
+		for _, newNode := range newNodes {
+			node, ok := topology.NodeByAddress(newNode)
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s가 %s를 아직 모릅니다", view.Address, newNode))
+			case node.IsHandshake():
+				problems = append(problems, fmt.Sprintf("%s에서 %s가 아직 handshake 상태입니다", view.Address, newNode))
+			case node.IsNoAddr():
+				problems = append(problems, fmt.Sprintf("%s에서 %s의 주소를 알 수 없습니다 (noaddr)", view.Address, newNode))
+			}
+		}
+
+		signature := createClusterSignature(topology.Nodes)
+		if firstAddress == "" {
+			firstSignature, firstAddress = signature, view.Address
+		} else if signature != firstSignature {
+			problems = append(problems, fmt.Sprintf("%s의 클러스터 뷰가 %s와 다릅니다 (노드 %d개)", view.Address, firstAddress, len(topology.Nodes)))
+		}
+	}
+	return problems
 }
 
 // createClusterSignature creates a simple signature from cluster nodes info
@@ -328,11 +875,17 @@ func waitForClusterJoin(cm *redis.ClusterManager, existingNode string, timeout t
 func createClusterSignature(clusterNodes []redis.ClusterNode) string {
 	var parts []string
 	for _, node := range clusterNodes {
-		// Include node ID and flags for signature
-		flagStr := strings.Join(node.Flags, ",")
-		parts = append(parts, fmt.Sprintf("%s:%s", node.ID, flagStr))
+		// Include node ID and flags for signature; myself differs per member
+		var flags []string
+		for _, flag := range node.Flags {
+			if flag != "myself" {
+				flags = append(flags, flag)
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%s:%s", node.ID, strings.Join(flags, ",")))
 	}
 	// Sort to ensure consistent ordering
+	sort.Strings(parts)
 	return strings.Join(parts, "|")
 }
 
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"redisctl/internal/redis"
+)
+
+// TestSplitAddNodeArgs tests that the trailing argument is the existing node unless --seed is given
+func TestSplitAddNodeArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		seeded       bool
+		wantNew      []string
+		wantExisting string
+	}{
+		{"single node", []string{"a:1"}, false, []string{"a:1"}, ""},
+		{"node and existing", []string{"a:1", "c:1"}, false, []string{"a:1"}, "c:1"},
+		{"many nodes and existing", []string{"a:1", "b:1", "c:1"}, false, []string{"a:1", "b:1"}, "c:1"},
+		{"many nodes with seed", []string{"a:1", "b:1"}, true, []string{"a:1", "b:1"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newNodes, existing := splitAddNodeArgs(tt.args, tt.seeded)
+			if !reflect.DeepEqual(newNodes, tt.wantNew) || existing != tt.wantExisting {
+				t.Errorf("splitAddNodeArgs() = %v, %q, want %v, %q", newNodes, existing, tt.wantNew, tt.wantExisting)
+			}
+		})
+	}
+}
+
+// TestParseReplicaOf tests parsing of --replica-of replica=master values
+func TestParseReplicaOf(t *testing.T) {
+	got, err := parseReplicaOf([]string{"localhost:7008=localhost:7007", "10.0.0.2:7001=10.0.0.1:7001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"127.0.0.1:7008": "127.0.0.1:7007", "10.0.0.2:7001": "10.0.0.1:7001"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseReplicaOf() = %v, want %v", got, want)
+	}
+
+	for _, bad := range [][]string{
+		{"10.0.0.2:7001"},
+		{"10.0.0.2:7001=nope"},
+		{"10.0.0.2:7001=10.0.0.2:7001"},
+		{"10.0.0.2:7001=10.0.0.1:7001", "10.0.0.2:7001=10.0.0.3:7001"},
+	} {
+		if _, err := parseReplicaOf(bad); err == nil {
+			t.Errorf("parseReplicaOf(%v) succeeded", bad)
+		}
+	}
+}
+
+// TestValidateAddNodeLayout tests the checks on new nodes and replica mappings
+func TestValidateAddNodeLayout(t *testing.T) {
+	nodes := []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"}
+	tests := []struct {
+		name    string
+		nodes   []string
+		opts    addNodeOptions
+		wantErr bool
+	}{
+		{"masters only", nodes, addNodeOptions{}, false},
+		{"replica of new master", nodes, addNodeOptions{replicaOf: map[string]string{"10.0.0.2:7001": "10.0.0.1:7001"}}, false},
+		{"replica of existing master", nodes, addNodeOptions{replicaOf: map[string]string{"10.0.0.2:7001": "10.0.0.9:7001"}}, false},
+		{"master id for one node", nodes[:1], addNodeOptions{masterID: "abc"}, false},
+		{"master id for many nodes", nodes, addNodeOptions{masterID: "abc"}, true},
+		{"duplicate node", []string{"10.0.0.1:7001", "10.0.0.1:7001"}, addNodeOptions{}, true},
+		{"replica not new", nodes, addNodeOptions{replicaOf: map[string]string{"10.0.0.9:7001": "10.0.0.1:7001"}}, true},
+		{"rebalance new masters", nodes, addNodeOptions{rebalance: true, weight: 2}, false},
+		{"rebalance without new master", nodes[:1], addNodeOptions{rebalance: true, weight: 1, asReplica: true}, true},
+		{"rebalance zero weight", nodes, addNodeOptions{rebalance: true}, true},
+		{"replica of replica", nodes, addNodeOptions{replicaOf: map[string]string{"10.0.0.2:7001": "10.0.0.1:7001", "10.0.0.3:7001": "10.0.0.2:7001"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAddNodeLayout(tt.nodes, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAddNodeLayout() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestChooseReplicaMaster tests automatic master selection for --as-replica
+func TestChooseReplicaMaster(t *testing.T) {
+	// bbbb has no replica, cccc's replica failed; cccc has more slots
+	topology := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-5000
+bbbb 10.0.2.1:7001@17001 master - 0 0 2 connected 5001-10000
+cccc 10.0.3.1:7001@17001 master - 0 0 3 connected 10001-16383
+dddd 10.0.2.2:7001@17001 slave aaaa 0 0 1 connected
+eeee 10.0.1.2:7001@17001 slave,fail cccc 0 0 3 connected`)
+
+	tests := []struct {
+		name       string
+		newNode    string
+		added      map[string]int
+		wantMaster string
+		wantReason string
+		wantErr    bool
+	}{
+		{"tie broken by slots", "10.0.9.1:7001", nil, "cccc", "슬롯이 가장 많음", false},
+		{"same host skipped", "10.0.3.1:7002", nil, "bbbb", "같은 호스트의 마스터 1개 제외", false},
+		{"replicas added in the same run", "10.0.9.1:7001", map[string]int{"cccc": 1}, "bbbb", "(0개)", false},
+		{"fewest replicas wins", "10.0.9.1:7001", map[string]int{"bbbb": 1, "cccc": 2}, "aaaa", "(1개)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			choice, err := chooseReplicaMaster(topology, tt.newNode, tt.added)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("chooseReplicaMaster() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if choice.Master.ID != tt.wantMaster || !strings.Contains(choice.Reason, tt.wantReason) {
+				t.Errorf("chooseReplicaMaster() = %s (%s), want %s (%s)", choice.Master.ID, choice.Reason, tt.wantMaster, tt.wantReason)
+			}
+		})
+	}
+
+	// 모든 마스터가 같은 호스트에 있으면 선택할 수 없다
+	single := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383`)
+	if _, err := chooseReplicaMaster(single, "10.0.1.1:7002", nil); err == nil {
+		t.Error("chooseReplicaMaster() on the master's host succeeded")
+	}
+}
+
+// TestGenerateRebalancePlanNewMaster tests that an empty new master receives its (weighted) share
+func TestGenerateRebalancePlanNewMaster(t *testing.T) {
+	topology := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-5461
+bbbb 10.0.2.1:7001@17001 master - 0 0 2 connected 5462-10922
+cccc 10.0.3.1:7001@17001 master - 0 0 3 connected 10923-16383
+nnnn 10.0.4.1:7001@17001 master - 0 0 0 connected`)
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		want    int
+	}{
+		{"equal share", nil, 4096},
+		{"weight 1", map[string]float64{"nnnn": 1}, 4096},
+		{"weight 2", map[string]float64{"nnnn": 2}, 6553},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := generateRebalancePlan(topology.Masters(), tt.weights)
+			received := 0
+			seen := make(map[int]bool)
+			for _, step := range plan {
+				if step.To != "nnnn" {
+					t.Errorf("step moves slots to %s, want only the new master", step.To)
+				}
+				if step.SlotCount != len(step.Slots) {
+					t.Errorf("step SlotCount = %d, len(Slots) = %d", step.SlotCount, len(step.Slots))
+				}
+				for _, slot := range step.Slots {
+					if seen[slot] {
+						t.Errorf("slot %d planned twice", slot)
+					}
+					seen[slot] = true
+				}
+				received += step.SlotCount
+			}
+			if received != tt.want {
+				t.Errorf("new master receives %d slots, want %d", received, tt.want)
+			}
+		})
+	}
+}
+
+// TestClusterJoinProblems tests the diagnostics of the add-node convergence wait
+func TestClusterJoinProblems(t *testing.T) {
+	parse := redis.ParseTopology
+
+	joined := `aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383
+nnnn 10.0.4.1:7001@17001 master - 0 0 0 connected`
+	joinedOnNew := `aaaa 10.0.1.1:7001@17001 master - 0 0 1 connected 0-16383
+nnnn 10.0.4.1:7001@17001 myself,master - 0 0 0 connected`
+	handshake := `aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383
+nnnn 10.0.4.1:7001@17001 handshake - 0 0 0 connected`
+	unknown := `aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383`
+
+	tests := []struct {
+		name  string
+		views []clusterView
+		want  []string
+	}{
+		{"joined", []clusterView{{Address: "10.0.1.1:7001", Topology: parse(joined)}, {Address: "10.0.4.1:7001", Topology: parse(joinedOnNew)}}, nil},
+		{"handshake", []clusterView{{Address: "10.0.1.1:7001", Topology: parse(handshake)}}, []string{"handshake"}},
+		{"unknown", []clusterView{{Address: "10.0.1.1:7001", Topology: parse(unknown)}}, []string{"모릅니다"}},
+		{"different views", []clusterView{{Address: "10.0.1.1:7001", Topology: parse(joined)}, {Address: "10.0.2.1:7001", Topology: parse(unknown + "\nnnnn 10.0.4.1:7001@17001 master - 0 0 0 connected\nbbbb 10.0.2.1:7001@17001 master - 0 0 0 connected")}}, []string{"다릅니다"}},
+		{"unreachable member", []clusterView{{Address: "10.0.1.1:7001", Topology: parse(joined)}, {Address: "10.0.2.1:7001", Err: errors.New("timeout")}}, []string{"조회 실패"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := clusterJoinProblems(tt.views, []string{"10.0.4.1:7001"})
+			if len(problems) != len(tt.want) {
+				t.Fatalf("clusterJoinProblems() = %q, want %d problems", problems, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %q does not mention %q", problems[i], want)
+				}
+			}
+		})
+	}
+}
+
+// TestExtraClusterNodes tests the membership comparison used by the add-node rollback
+func TestExtraClusterNodes(t *testing.T) {
+	before := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383
+bbbb 10.0.2.1:7001@17001 slave aaaa 0 0 1 connected`).Nodes
+	original := clusterMembership(before)
+
+	after := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16383
+bbbb 10.0.2.1:7001@17001 slave aaaa 0 0 1 connected
+nnnn 10.0.4.1:7001@17001 master - 0 0 0 connected
+hhhh 10.0.4.2:7001@17001 handshake - 0 0 0 connected`).Nodes
+
+	if extra := extraClusterNodes(original, before); len(extra) != 0 {
+		t.Errorf("extraClusterNodes(before) = %v, want none", extra)
+	}
+	if extra := extraClusterNodes(original, after); !reflect.DeepEqual(extra, []string{"hhhh", "nnnn"}) {
+		t.Errorf("extraClusterNodes(after) = %v, want [hhhh nnnn]", extra)
+	}
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"redisctl/internal/config"
+	"redisctl/internal/inventory"
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+// NewApplyCommand 'apply' 명령어
+func NewApplyCommand() *cobra.Command {
+	var file string
+	var yes bool
+	var pipeline int
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <spec.yaml> [--yes] [<cluster-node-ip:port[,ip:port...]>]",
+		Short: "= 선언형 스펙에 맞춰 클러스터를 조정합니다",
+		Long: styles.TitleStyle.Render("[=] 선언형 클러스터 스펙 적용") + "\n\n" +
+			styles.DescStyle.Render("YAML/JSON 스펙(마스터, 마스터별 복제본, 가중치, 라벨)과 현재 클러스터를 비교해") + "\n" +
+			styles.DescStyle.Render("노드 추가, 복제 설정, 슬롯 이동, 노드 제거 순서의 실행 계획을 만듭니다.") + "\n\n" +
+			styles.DescStyle.Render("• --yes 없이 실행하면 계획만 표시") + "\n" +
+			styles.DescStyle.Render("• 스펙과 일치하는 클러스터에서는 아무것도 하지 않음 (반복 실행 안전)") + "\n" +
+			styles.DescStyle.Render("• 중단되거나 실패하면 다시 실행해 남은 단계부터 이어서 적용"),
+		Example: `  # 변경 계획 미리보기
+  redisctl apply -f cluster.yaml localhost:7001
+
+  # 계획 실행
+  redisctl apply -f cluster.yaml --yes localhost:7001`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.ValidateAuth(); err != nil {
+				return err
+			}
+			clusterAddr, _ := splitClusterArgs(args, 1)
+			return runApply(cmd.Context(), clusterAddr, file, yes, pipeline)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "클러스터 스펙 파일 (YAML 또는 JSON)")
+	cmd.Flags().BoolVar(&yes, "yes", false, "확인 없이 계획을 실행")
+	cmd.Flags().IntVar(&pipeline, "pipeline", 10, "MIGRATE당 키 수 (기본: 10)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runApply(ctx context.Context, clusterAddr, file string, yes bool, pipeline int) error {
+	spec, err := loadClusterSpec(file)
+	if err != nil {
+		return err
+	}
+
+	seeds, err := clusterSeeds(clusterAddr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.InfoStyle.Render("클러스터 스펙 적용"))
+	fmt.Printf("스펙: %s\n", styles.HighlightStyle.Render(file))
+	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
+	seed := selection.Address
+	fmt.Println()
+
+	// 스펙의 라벨이 인벤토리보다 우선
+	inv, err := loadInventory()
+	if err != nil {
+		return err
+	}
+	zones := inv.Zones()
+	for addr, labels := range spec.Labels() {
+		if zone, ok := labels[inventory.ZoneLabel]; ok {
+			zones[addr] = zone
+		}
+	}
+
+	user, password := config.GetAuth()
+	cm := redis.NewClusterManager(ctx, user, password)
+	defer cm.Close()
+
+	topology, err := cm.LoadTopology(seed)
+	if err != nil {
+		return fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+
+	steps, err := planApply(spec, topology)
+	if err != nil {
+		return err
+	}
+
+	masters, replicaMap := specLayout(spec)
+	for _, warning := range layoutAntiAffinityWarnings(masters, replicaMap, zones) {
+		fmt.Println(styles.RenderWarning(warning))
+	}
+
+	if len(steps) == 0 {
+		fmt.Println(styles.SuccessStyle.Render("OK 클러스터가 이미 스펙과 일치합니다"))
+		return nil
+	}
+
+	displayApplyPlan(steps)
+
+	if !yes {
+		fmt.Println()
+		fmt.Println(styles.InfoStyle.Render("계획을 실행하려면 --yes 플래그를 추가하세요"))
+		return nil
+	}
+
+	// 스펙의 라벨을 인벤토리에 기록해 이후 명령(check, add-node)에서도 사용
+	if labels := spec.Labels(); len(labels) > 0 {
+		inv.Merge(labels)
+		if err := inv.Save(); err != nil {
+			fmt.Println(styles.RenderWarning(fmt.Sprintf("라벨을 인벤토리에 저장하지 못했습니다: %v", err)))
+		}
+	}
+
+	for i, step := range steps {
+		if ctx.Err() != nil {
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("중단됨: %d/%d 단계 완료 - 같은 명령을 다시 실행하면 남은 단계부터 적용합니다", i, len(steps))))
+			return ErrInterrupted
+		}
+
+		fmt.Println()
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("[%d/%d] %s", i+1, len(steps), describeApplyStep(step))))
+
+		err := executeApplyStep(ctx, cm, seed, step, pipeline)
+		if errors.Is(err, ErrInterrupted) {
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("중단됨: %d/%d 단계 완료 - 같은 명령을 다시 실행하면 남은 단계부터 적용합니다", i, len(steps))))
+			return err
+		}
+		if err != nil {
+			fmt.Println(styles.DescStyle.Render("  같은 명령을 다시 실행하면 현재 상태에서 남은 단계를 다시 계산합니다"))
+			return fmt.Errorf("%d단계 (%s) 실패: %w", i+1, step.Kind, err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("OK 스펙 적용 완료 (%d단계)", len(steps))))
+	return nil
+}
+
+// displayApplyPlan prints the numbered steps
+func displayApplyPlan(steps []applyStep) {
+	fmt.Println(styles.TitleStyle.Render("실행 계획"))
+	for i, step := range steps {
+		fmt.Printf("  %d. %s\n", i+1, describeApplyStep(step))
+	}
+}
+
+// describeApplyStep returns a one-line description of a step
+func describeApplyStep(step applyStep) string {
+	switch step.Kind {
+	case applyAddMaster:
+		return fmt.Sprintf("add-node: %s (마스터)", step.Node)
+	case applyFailover:
+		return fmt.Sprintf("failover: %s를 마스터로 승격 (기존 마스터 %s)", step.Node, step.Target)
+	case applyReshard:
+		return fmt.Sprintf("reshard: %s → %s 슬롯 %d개", step.Node, step.Target, step.Slots)
+	case applyAddReplica:
+		return fmt.Sprintf("add-node: %s (%s의 복제본)", step.Node, step.Target)
+	case applyReplicate:
+		return fmt.Sprintf("replicate: %s → 마스터 %s", step.Node, step.Target)
+	case applyDelNode:
+		return fmt.Sprintf("del-node: %s (%s)", step.Node, step.NodeID)
+	}
+	return step.Kind
+}
+
+// executeApplyStep runs one step with the existing command logic, resolving node IDs from the current topology
+func executeApplyStep(ctx context.Context, cm *redis.ClusterManager, seed string, step applyStep, pipeline int) error {
+	switch step.Kind {
+	case applyAddMaster:
+		// add-node는 모든 노드가 새 마스터를 알 때까지 기다리므로 이후 슬롯 이동이 가능하다
+		return runAddNode(ctx, []string{step.Node}, seed, addNodeOptions{})
+
+	case applyAddReplica:
+		masterID, err := applyNodeID(cm, seed, step.Target)
+		if err != nil {
+			return err
+		}
+		return runAddNode(ctx, []string{step.Node}, seed, addNodeOptions{masterID: masterID})
+
+	case applyReplicate:
+		masterID, err := applyNodeID(cm, seed, step.Target)
+		if err != nil {
+			return err
+		}
+		client, err := cm.Connect(step.Node)
+		if err != nil {
+			return fmt.Errorf("노드 %s 연결 실패: %w", step.Node, err)
+		}
+		if err := client.ClusterReplicate(ctx, masterID).Err(); err != nil {
+			return fmt.Errorf("CLUSTER REPLICATE 명령 실패: %w", err)
+		}
+		fmt.Printf("  %s\n", styles.RenderSuccess("복제 대상 변경 완료"))
+		return nil
+
+	case applyFailover:
+		return applyFailoverStep(ctx, cm, step)
+
+	case applyReshard:
+		return applyReshardStep(ctx, cm, seed, step, pipeline)
+
+	case applyDelNode:
+		return runDelNode(ctx, seed, step.NodeID)
+	}
+	return fmt.Errorf("알 수 없는 단계: %s", step.Kind)
+}
+
+// applyNodeID looks up the ID of the node at address in the current topology
+func applyNodeID(cm *redis.ClusterManager, seed, address string) (string, error) {
+	topology, err := cm.LoadTopology(seed)
+	if err != nil {
+		return "", fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+	node, ok := topology.NodeByAddress(address)
+	if !ok {
+		return "", fmt.Errorf("노드 %s가 클러스터에 없습니다", address)
+	}
+	return node.ID, nil
+}
+
+// applyFailoverStep promotes a replica with CLUSTER FAILOVER and waits until it reports itself as master
+func applyFailoverStep(ctx context.Context, cm *redis.ClusterManager, step applyStep) error {
+	client, err := cm.Connect(step.Node)
+	if err != nil {
+		return fmt.Errorf("노드 %s 연결 실패: %w", step.Node, err)
+	}
+
+	fmt.Print("  CLUSTER FAILOVER 실행 중...")
+	if err := client.ClusterFailover(ctx).Err(); err != nil {
+		fmt.Printf(" %s\n", styles.RenderError("실패"))
+		return fmt.Errorf("CLUSTER FAILOVER 명령 실패: %w", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		topology, err := cm.LoadTopology(step.Node)
+		if err == nil {
+			if node, ok := topology.Myself(); ok && node.IsMaster() {
+				fmt.Printf(" %s\n", styles.RenderSuccess("승격 완료"))
+				return nil
+			}
+		}
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(" %s\n", styles.RenderError("타임아웃"))
+	return fmt.Errorf("노드 %s가 30초 내에 마스터로 승격되지 않았습니다", step.Node)
+}
+
+// applyReshardStep moves step.Slots slots from the source master to the target master
+func applyReshardStep(ctx context.Context, cm *redis.ClusterManager, seed string, step applyStep, pipeline int) error {
+	topology, err := cm.LoadTopology(seed)
+	if err != nil {
+		return fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+	from, ok := topology.NodeByAddress(step.Node)
+	if !ok {
+		return fmt.Errorf("노드 %s가 클러스터에 없습니다", step.Node)
+	}
+	to, ok := topology.NodeByAddress(step.Target)
+	if !ok {
+		return fmt.Errorf("노드 %s가 클러스터에 없습니다", step.Target)
+	}
+
+	// 소스의 마지막 슬롯부터 이동해 남는 슬롯 범위가 연속되도록 한다
+	slots := from.SlotList()
+	if len(slots) < step.Slots {
+		return fmt.Errorf("노드 %s의 슬롯이 %d개뿐입니다 (이동 예정 %d개)", step.Node, len(slots), step.Slots)
+	}
+	slots = slots[len(slots)-step.Slots:]
+
+	client := redis.NewClusterClient(seed)
+	defer client.Close()
+
+	start := time.Now()
+	moved, err := reshardSlots(ctx, client, from.ID, to.ID, slots, pipeline)
+	if errors.Is(err, ErrInterrupted) {
+		displayInterruptSummary(ctx, topology, moved, len(slots))
+		return err
+	}
+	if err != nil {
+		fmt.Printf("  부분 완료: %d/%d 슬롯 이동됨\n", len(moved), len(slots))
+		return err
+	}
+
+	fmt.Printf("  %s\n", styles.RenderSuccess(fmt.Sprintf("슬롯 %d개 이동 완료 (%.1fs)", len(moved), time.Since(start).Seconds())))
+	return nil
+}
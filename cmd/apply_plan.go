@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"redisctl/internal/inventory"
+	"redisctl/internal/redis"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ClusterSpec is the desired cluster layout for apply (YAML or JSON):
+//
+//	masters:
+//	  - address: 10.0.1.1:7001
+//	    weight: 2
+//	    labels: {zone: a}
+//	    replicas:
+//	      - 10.0.2.1:7001
+//	      - {address: 10.0.3.1:7001, labels: {zone: c}}
+type ClusterSpec struct {
+	Masters []SpecMaster `yaml:"masters" json:"masters"`
+}
+
+// SpecMaster is one shard of the spec; Weight is the relative share of slots (omitted = 1)
+type SpecMaster struct {
+	Address  string           `yaml:"address" json:"address"`
+	Weight   float64          `yaml:"weight,omitempty" json:"weight,omitempty"`
+	Labels   inventory.Labels `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Replicas []SpecReplica    `yaml:"replicas,omitempty" json:"replicas,omitempty"`
+}
+
+// SpecReplica is a replica of a spec master; a plain address is accepted as shorthand
+type SpecReplica struct {
+	Address string           `yaml:"address" json:"address"`
+	Labels  inventory.Labels `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// UnmarshalYAML accepts either "host:port" or {address: host:port, labels: {...}}
+func (r *SpecReplica) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		r.Address = value.Value
+		return nil
+	}
+	type plain SpecReplica
+	return value.Decode((*plain)(r))
+}
+
+// loadClusterSpec reads and validates a spec file; unknown fields are rejected to catch typos
+func loadClusterSpec(path string) (*ClusterSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("스펙 파일 읽기 실패 (%s): %w", path, err)
+	}
+
+	var spec ClusterSpec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("스펙 파일 해석 실패 (%s): %w", path, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("잘못된 스펙 파일 (%s): %w", path, err)
+	}
+	return &spec, nil
+}
+
+// Validate checks addresses, weights and that every node appears once
+func (s *ClusterSpec) Validate() error {
+	if len(s.Masters) < 3 {
+		return fmt.Errorf("클러스터를 위해 최소 3개의 마스터가 필요합니다 (스펙: %d개)", len(s.Masters))
+	}
+
+	seen := make(map[string]bool)
+	check := func(address string) error {
+		key, err := specKey(address)
+		if err != nil {
+			return fmt.Errorf("잘못된 노드 주소 %q: %w", address, err)
+		}
+		if seen[key] {
+			return fmt.Errorf("노드 %s가 스펙에 두 번 이상 있습니다", address)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for _, master := range s.Masters {
+		if err := check(master.Address); err != nil {
+			return err
+		}
+		if master.Weight < 0 {
+			return fmt.Errorf("마스터 %s의 가중치는 0보다 커야 합니다: %g", master.Address, master.Weight)
+		}
+		for _, replica := range master.Replicas {
+			if err := check(replica.Address); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Labels returns the labels of every spec node keyed by normalized address
+func (s *ClusterSpec) Labels() map[string]inventory.Labels {
+	labels := make(map[string]inventory.Labels)
+	for _, master := range s.Masters {
+		if len(master.Labels) > 0 {
+			key, _ := specKey(master.Address)
+			labels[key] = master.Labels
+		}
+		for _, replica := range master.Replicas {
+			if len(replica.Labels) > 0 {
+				key, _ := specKey(replica.Address)
+				labels[key] = replica.Labels
+			}
+		}
+	}
+	return labels
+}
+
+// specKey normalizes an address so that spec entries match CLUSTER NODES addresses
+func specKey(address string) (string, error) {
+	addr, err := redis.ParseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// apply step kinds, in the order they are executed
+const (
+	applyAddMaster  = "add-node"
+	applyFailover   = "failover"
+	applyReshard    = "reshard"
+	applyAddReplica = "add-replica"
+	applyReplicate  = "replicate"
+	applyDelNode    = "del-node"
+)
+
+// applyStep is one change apply makes. Nodes are referred to by address because nodes added
+// by earlier steps have no ID yet; IDs are resolved from the live topology when the step runs.
+type applyStep struct {
+	Kind   string
+	Node   string // node the step acts on (reshard: source master)
+	Target string // master to replicate / receive slots
+	NodeID string // del-node: ID of the node to remove
+	Slots  int    // reshard: number of slots to move
+}
+
+// applyNode is the simulated state of one node while planning
+type applyNode struct {
+	ID     string
+	Master string // address of the master when replica, empty for masters
+	Slots  int
+}
+
+// planApply diffs the live topology against the spec and returns the ordered steps:
+// add new masters, promote replicas the spec lists as masters, move slots to match the weights,
+// attach replicas, then remove nodes the spec does not mention.
+// An empty plan means the cluster already matches the spec.
+func planApply(spec *ClusterSpec, topology *redis.Topology) ([]applyStep, error) {
+	live := make(map[string]*applyNode)
+	idToAddr := make(map[string]string)
+	var liveOrder []string
+	for _, node := range topology.Nodes {
+		if node.IsHandshake() || node.IsNoAddr() {
+			continue
+		}
+		addr := node.HostPort()
+		idToAddr[node.ID] = addr
+		live[addr] = &applyNode{ID: node.ID, Slots: node.SlotCount()}
+		liveOrder = append(liveOrder, addr)
+	}
+	for _, node := range topology.Nodes {
+		if n, ok := live[node.HostPort()]; ok && node.IsReplica() {
+			n.Master = idToAddr[node.Master]
+		}
+	}
+
+	isSpecMaster := make(map[string]bool)
+	inSpec := make(map[string]bool)
+	var masters []string
+	var weights []float64
+	for _, m := range spec.Masters {
+		key, _ := specKey(m.Address)
+		isSpecMaster[key] = true
+		inSpec[key] = true
+		masters = append(masters, key)
+		weight := m.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		weights = append(weights, weight)
+		for _, r := range m.Replicas {
+			key, _ := specKey(r.Address)
+			inSpec[key] = true
+		}
+	}
+
+	for _, node := range topology.Nodes {
+		if inSpec[node.HostPort()] && node.IsFail() {
+			return nil, fmt.Errorf("스펙에 있는 노드 %s가 실패 상태입니다. 노드를 복구한 뒤 다시 실행하세요", node.HostPort())
+		}
+	}
+
+	var steps []applyStep
+
+	// 1. 새 마스터 추가, 복제본으로 있는 스펙 마스터 승격
+	for _, addr := range masters {
+		node, ok := live[addr]
+		switch {
+		case !ok:
+			steps = append(steps, applyStep{Kind: applyAddMaster, Node: addr})
+			live[addr] = &applyNode{}
+		case node.Master != "":
+			oldMaster := node.Master
+			current := live[oldMaster]
+			if current == nil {
+				return nil, fmt.Errorf("노드 %s는 스펙상 마스터이지만 알 수 없는 마스터의 복제본입니다", addr)
+			}
+			if isSpecMaster[oldMaster] {
+				return nil, fmt.Errorf("노드 %s는 스펙상 마스터이지만 마스터 %s의 복제본입니다. 두 노드가 모두 스펙의 마스터라 자동으로 바꿀 수 없습니다", addr, oldMaster)
+			}
+			// 페일오버 후 슬롯을 넘겨받고 기존 마스터와 그 복제본들은 새 마스터의 복제본이 된다
+			steps = append(steps, applyStep{Kind: applyFailover, Node: addr, Target: oldMaster})
+			node.Slots, current.Slots = current.Slots, 0
+			for _, other := range live {
+				if other.Master == oldMaster {
+					other.Master = addr
+				}
+			}
+			node.Master, current.Master = "", addr
+		}
+	}
+
+	// 2. 가중치에 맞춰 슬롯 이동 (스펙에 없는 마스터와 복제본이 될 마스터는 목표 0개)
+	ranges, err := distributeSlots(weights)
+	if err != nil {
+		return nil, err
+	}
+	target := make(map[string]int)
+	for i, addr := range masters {
+		target[addr] = ranges[i].Count()
+	}
+
+	var donors, receivers []string
+	for _, addr := range append(append([]string(nil), masters...), liveOrder...) {
+		node := live[addr]
+		if node.Master != "" || stringSliceContains(donors, addr) || stringSliceContains(receivers, addr) {
+			continue
+		}
+		switch {
+		case node.Slots > target[addr]:
+			donors = append(donors, addr)
+		case node.Slots < target[addr]:
+			receivers = append(receivers, addr)
+		}
+	}
+
+	for len(donors) > 0 && len(receivers) > 0 {
+		from, to := live[donors[0]], live[receivers[0]]
+		count := min(from.Slots-target[donors[0]], target[receivers[0]]-to.Slots)
+		steps = append(steps, applyStep{Kind: applyReshard, Node: donors[0], Target: receivers[0], Slots: count})
+		from.Slots -= count
+		to.Slots += count
+		if from.Slots == target[donors[0]] {
+			donors = donors[1:]
+		}
+		if to.Slots == target[receivers[0]] {
+			receivers = receivers[1:]
+		}
+	}
+
+	// 3. 복제본 추가 및 마스터 변경
+	for _, m := range spec.Masters {
+		master, _ := specKey(m.Address)
+		for _, r := range m.Replicas {
+			addr, _ := specKey(r.Address)
+			node, ok := live[addr]
+			switch {
+			case !ok:
+				steps = append(steps, applyStep{Kind: applyAddReplica, Node: addr, Target: master})
+			case node.Master != master:
+				steps = append(steps, applyStep{Kind: applyReplicate, Node: addr, Target: master})
+			}
+		}
+	}
+
+	// 4. 스펙에 없는 노드 제거 (복제본 먼저)
+	var removeReplicas, removeMasters []applyStep
+	for _, addr := range liveOrder {
+		if inSpec[addr] {
+			continue
+		}
+		step := applyStep{Kind: applyDelNode, Node: addr, NodeID: live[addr].ID}
+		if live[addr].Master != "" {
+			removeReplicas = append(removeReplicas, step)
+		} else {
+			removeMasters = append(removeMasters, step)
+		}
+	}
+	steps = append(steps, removeReplicas...)
+	steps = append(steps, removeMasters...)
+
+	return steps, nil
+}
+
+// specLayout returns the spec masters and replica -> master mapping (normalized addresses)
+func specLayout(spec *ClusterSpec) ([]string, map[string]string) {
+	var masters []string
+	replicaMap := make(map[string]string)
+	for _, m := range spec.Masters {
+		master, _ := specKey(m.Address)
+		masters = append(masters, master)
+		for _, r := range m.Replicas {
+			replica, _ := specKey(r.Address)
+			replicaMap[replica] = master
+		}
+	}
+	return masters, replicaMap
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"redisctl/internal/inventory"
+	"redisctl/internal/redis"
+)
+
+// applyTestTopology is a 3 master / 3 replica cluster whose slots match three equal weights
+const applyTestTopology = `aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-5461
+bbbb 10.0.2.1:7001@17001 master - 0 0 2 connected 5462-10922
+cccc 10.0.3.1:7001@17001 master - 0 0 3 connected 10923-16383
+dddd 10.0.1.2:7001@17001 slave aaaa 0 0 1 connected
+eeee 10.0.3.2:7001@17001 slave bbbb 0 0 2 connected
+ffff 10.0.1.3:7001@17001 slave cccc 0 0 3 connected`
+
+// applyTestSpec returns the spec matching applyTestTopology
+func applyTestSpec() *ClusterSpec {
+	return &ClusterSpec{Masters: []SpecMaster{
+		{Address: "10.0.1.1:7001", Replicas: []SpecReplica{{Address: "10.0.1.2:7001"}}},
+		{Address: "10.0.2.1:7001", Replicas: []SpecReplica{{Address: "10.0.3.2:7001"}}},
+		{Address: "10.0.3.1:7001", Replicas: []SpecReplica{{Address: "10.0.1.3:7001"}}},
+	}}
+}
+
+// TestPlanApply tests the steps planned for differences between the spec and the live cluster
+func TestPlanApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology string
+		spec     func() *ClusterSpec
+		expected []applyStep
+		wantErr  bool
+	}{
+		{
+			name:     "matching cluster",
+			topology: applyTestTopology,
+			spec:     applyTestSpec,
+		},
+		{
+			name:     "new master takes its share of slots",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters = append(spec.Masters, SpecMaster{Address: "10.0.4.1:7001"})
+				return spec
+			},
+			expected: []applyStep{
+				{Kind: applyAddMaster, Node: "10.0.4.1:7001"},
+				{Kind: applyReshard, Node: "10.0.1.1:7001", Target: "10.0.4.1:7001", Slots: 1366},
+				{Kind: applyReshard, Node: "10.0.2.1:7001", Target: "10.0.4.1:7001", Slots: 1365},
+				{Kind: applyReshard, Node: "10.0.3.1:7001", Target: "10.0.4.1:7001", Slots: 1365},
+			},
+		},
+		{
+			name:     "weight change",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[0].Weight = 2
+				return spec
+			},
+			expected: []applyStep{
+				{Kind: applyReshard, Node: "10.0.2.1:7001", Target: "10.0.1.1:7001", Slots: 1365},
+				{Kind: applyReshard, Node: "10.0.3.1:7001", Target: "10.0.1.1:7001", Slots: 1365},
+			},
+		},
+		{
+			name:     "new and moved replicas",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[0].Replicas = append(spec.Masters[0].Replicas, SpecReplica{Address: "10.0.3.2:7001"})
+				spec.Masters[1].Replicas = []SpecReplica{{Address: "10.0.4.1:7001"}}
+				return spec
+			},
+			expected: []applyStep{
+				{Kind: applyReplicate, Node: "10.0.3.2:7001", Target: "10.0.1.1:7001"},
+				{Kind: applyAddReplica, Node: "10.0.4.1:7001", Target: "10.0.2.1:7001"},
+			},
+		},
+		{
+			name:     "node missing from spec",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[2].Replicas = nil
+				return spec
+			},
+			expected: []applyStep{
+				{Kind: applyDelNode, Node: "10.0.1.3:7001", NodeID: "ffff"},
+			},
+		},
+		{
+			name:     "replica promoted in place of a removed master",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[0] = SpecMaster{Address: "10.0.1.2:7001"}
+				return spec
+			},
+			expected: []applyStep{
+				{Kind: applyFailover, Node: "10.0.1.2:7001", Target: "10.0.1.1:7001"},
+				{Kind: applyDelNode, Node: "10.0.1.1:7001", NodeID: "aaaa"},
+			},
+		},
+		{
+			name:     "spec master replicating another spec master",
+			topology: applyTestTopology,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[0].Replicas = nil
+				spec.Masters = append(spec.Masters, SpecMaster{Address: "10.0.1.2:7001"})
+				return spec
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed spec node",
+			topology: applyTestTopology + `
+gggg 10.0.2.2:7001@17001 slave,fail aaaa 0 0 1 disconnected`,
+			spec: func() *ClusterSpec {
+				spec := applyTestSpec()
+				spec.Masters[0].Replicas = append(spec.Masters[0].Replicas, SpecReplica{Address: "10.0.2.2:7001"})
+				return spec
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := planApply(tt.spec(), redis.ParseTopology(tt.topology))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planApply() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(steps, tt.expected) {
+				t.Errorf("planApply() = %+v, want %+v", steps, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLoadClusterSpec tests YAML/JSON spec files and their validation
+func TestLoadClusterSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			data: `masters:
+  - address: 10.0.1.1:7001
+    weight: 2
+    labels: {zone: a}
+    replicas:
+      - 10.0.2.1:7002
+      - {address: 10.0.3.1:7002, labels: {zone: c}}
+  - address: 10.0.2.1:7001
+  - address: localhost:7003
+`,
+		},
+		{
+			name: "json",
+			data: `{"masters": [{"address": "10.0.1.1:7001", "labels": {"zone": "a"},
+  "replicas": [{"address": "10.0.2.1:7002"}, {"address": "10.0.3.1:7002", "labels": {"zone": "c"}}]},
+  {"address": "10.0.2.1:7001"}, {"address": "127.0.0.1:7003"}]}`,
+		},
+		{
+			name:    "unknown field",
+			data:    "masters:\n  - address: 10.0.1.1:7001\n    replica: [10.0.2.1:7002]\n  - address: 10.0.2.1:7001\n  - address: 10.0.3.1:7001\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate node",
+			data:    "masters:\n  - address: 10.0.1.1:7001\n    replicas: [10.0.2.1:7001]\n  - address: 10.0.2.1:7001\n  - address: 10.0.3.1:7001\n",
+			wantErr: true,
+		},
+		{
+			name:    "too few masters",
+			data:    "masters:\n  - address: 10.0.1.1:7001\n  - address: 10.0.2.1:7001\n",
+			wantErr: true,
+		},
+		{
+			name:    "negative weight",
+			data:    "masters:\n  - {address: 10.0.1.1:7001, weight: -1}\n  - address: 10.0.2.1:7001\n  - address: 10.0.3.1:7001\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cluster.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			spec, err := loadClusterSpec(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadClusterSpec() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			masters, replicaMap := specLayout(spec)
+			if want := []string{"10.0.1.1:7001", "10.0.2.1:7001", "127.0.0.1:7003"}; !reflect.DeepEqual(masters, want) {
+				t.Errorf("masters = %v, want %v", masters, want)
+			}
+			if want := map[string]string{"10.0.2.1:7002": "10.0.1.1:7001", "10.0.3.1:7002": "10.0.1.1:7001"}; !reflect.DeepEqual(replicaMap, want) {
+				t.Errorf("replicas = %v, want %v", replicaMap, want)
+			}
+			wantLabels := map[string]inventory.Labels{"10.0.1.1:7001": {"zone": "a"}, "10.0.3.1:7002": {"zone": "c"}}
+			if labels := spec.Labels(); !reflect.DeepEqual(labels, wantLabels) {
+				t.Errorf("Labels() = %v, want %v", labels, wantLabels)
+			}
+		})
+	}
+}
@@ -13,10 +13,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 
 	"redisctl/internal/config"
+	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 )
 
@@ -27,13 +28,14 @@ func NewCheckCommand() *cobra.Command {
 	var dbsize bool
 
 	cmd := &cobra.Command{
-		Use:   "check <cluster-node-ip:port>",
+		Use:   "check <cluster-node-ip:port[,ip:port...]>",
 		Short: "^ Redis 클러스터 상태를 확인합니다",
 		Long: styles.TitleStyle.Render("[S] Redis 클러스터 상태 확인") + "\n\n" +
 			styles.DescStyle.Render("Redis 클러스터의 전반적인 상태를 확인하고 보고서를 생성합니다.") + "\n\n" +
 			styles.DescStyle.Render("확인하는 항목들:") + "\n" +
 			styles.DescStyle.Render("• 클러스터 연결 및 노드 상태") + "\n" +
 			styles.DescStyle.Render("• 슬롯 분배 및 커버리지 (0-16383)") + "\n" +
+			styles.DescStyle.Render("• 마이그레이션 중 멈춘 열린 슬롯 (MIGRATING/IMPORTING)") + "\n" +
 			styles.DescStyle.Render("• 마스터-레플리카 관계") + "\n" +
 			styles.DescStyle.Render("• 노드 간 일관성 검증") + "\n" +
 			styles.DescStyle.Render("• 클러스터 성능 통계"),
@@ -50,13 +52,17 @@ func NewCheckCommand() *cobra.Command {
   redisctl check --raw localhost:9001
 
   # 정확한 키 개수 확인 (느림)
-  redisctl check --dbsize localhost:9001`,
-		Args: cobra.ExactArgs(1),
+  redisctl check --dbsize localhost:9001
+
+  # 여러 시드 노드 지정 (앞의 노드가 다운되면 다음 노드 사용)
+  redisctl check localhost:9001,localhost:9002 --seed localhost:9003`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
-			return runCheckCluster(args[0], verbose, raw, dbsize)
+			clusterAddr, _ := splitClusterArgs(args, 1)
+			return runCheckCluster(cmd.Context(), clusterAddr, verbose, raw, dbsize)
 		},
 	}
 
@@ -67,51 +73,50 @@ func NewCheckCommand() *cobra.Command {
 	return cmd
 }
 
-type ClusterNode struct {
-	ID          string
-	Addr        string
-	Flags       []string
-	MasterID    string
-	Slots       []int
-	IsMaster    bool
-	IsReplica   bool
-	IsFail      bool
-	IsHandshake bool
-	Replicas    []string
-}
-
 type ClusterStatus struct {
-	Nodes           []ClusterNode
+	Topology        *redis.Topology
+	Nodes           []redis.ClusterNode
 	TotalSlots      int
 	TotalKeys       int64
 	Masters         int
 	Replicas        int
 	FailedNodes     int
-	SlotsCovered    []bool // 16384 elements, true if slot is covered
 	KnownNodesCount int
 	ClusterSize     int
 	CurrentEpoch    int64
 	MyEpoch         int64
 	ClusterState    string
 	PreciseKeyCount bool // true if dbsize was used for accurate count
+	LoadingNodes    int
+	OpenSlots       []redis.SlotMigration // slots left MIGRATING/IMPORTING
+	Seed            string                // seed node used as entry point
+	SeedCount       int
+	Zones           map[string]string // zone labels from the local inventory (host:port -> zone)
 }
 
-func runCheckCluster(clusterAddr string, verbose, raw, dbsize bool) error {
+// criticalIssuePrefix marks health issues that need immediate action
+const criticalIssuePrefix = "[심각] "
+
+func runCheckCluster(ctx context.Context, clusterAddr string, verbose, raw, dbsize bool) error {
+	seeds, err := clusterSeeds(clusterAddr)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(styles.InfoStyle.Render("[::] Redis 클러스터 상태 확인"))
-	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(clusterAddr))
+	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
+
+	// 시드 중 처음으로 일관된 뷰를 가진 노드를 진입점으로 사용
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
 	fmt.Println()
 
 	// 클러스터 연결
-	user, password := config.GetAuth()
-	client := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:    []string{clusterAddr},
-		Username: user,
-		Password: password,
-	})
+	client := redis.NewClusterClient(selection.Addresses()...)
 	defer client.Close()
 
-	ctx := context.Background()
-
 	// Validate cluster connectivity
 	if err := validateCheckConnectivity(ctx, client); err != nil {
 		return fmt.Errorf("클러스터 연결 실패: %w", err)
@@ -122,6 +127,15 @@ func runCheckCluster(clusterAddr string, verbose, raw, dbsize bool) error {
 	if err != nil {
 		return fmt.Errorf("클러스터 상태 조회 실패: %w", err)
 	}
+	status.Seed = selection.Address
+	status.SeedCount = len(seeds)
+
+	// 존 라벨 (--label, --topology-file, 로컬 인벤토리)
+	inv, err := loadInventory()
+	if err != nil {
+		return err
+	}
+	status.Zones = inv.Zones()
 
 	// 클러스터 상태가 'fail'인 경우 재확인 (일시적 상태 감지)
 	if status.ClusterState == "fail" {
@@ -168,7 +182,7 @@ func runCheckCluster(clusterAddr string, verbose, raw, dbsize bool) error {
 	return nil
 }
 
-func validateCheckConnectivity(ctx context.Context, client *redis.ClusterClient) error {
+func validateCheckConnectivity(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("1. 클러스터 연결 확인..."))
 
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -180,66 +194,44 @@ func validateCheckConnectivity(ctx context.Context, client *redis.ClusterClient)
 	return nil
 }
 
-func getClusterStatus(ctx context.Context, client *redis.ClusterClient, dbsize bool) (*ClusterStatus, error) {
+func getClusterStatus(ctx context.Context, client *redisv9.ClusterClient, dbsize bool) (*ClusterStatus, error) {
 	fmt.Print(styles.InfoStyle.Render("2. 클러스터 상태 수집..."))
 
-	// 클러스터 노드들 정보 가져오기
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
+	// 클러스터 토폴로지 가져오기 (CLUSTER SHARDS 우선, 구버전은 CLUSTER NODES)
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
 		fmt.Println(styles.ErrorStyle.Render(" 실패"))
-		return nil, result.Err()
+		return nil, err
 	}
 
 	status := &ClusterStatus{
-		SlotsCovered: make([]bool, 16384),
+		Topology:   topology,
+		Nodes:      topology.Nodes,
+		TotalSlots: topology.CoveredSlots(),
 	}
 
-	// 클러스터 노드 파싱
-	lines := strings.Split(result.Val(), "\n")
-	malformedLines := 0
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		node, err := parseClusterNode(line)
-		if err != nil {
-			malformedLines++
-			continue // Skip malformed lines
-		}
-
-		status.Nodes = append(status.Nodes, *node)
-
-		// 카운터 업데이트
-		if node.IsMaster {
+	// 카운터 업데이트
+	for _, node := range status.Nodes {
+		if node.IsMaster() {
 			status.Masters++
 		}
-		if node.IsReplica {
+		if node.IsReplica() {
 			status.Replicas++
 		}
-		if node.IsFail {
+		if node.IsFail() {
 			status.FailedNodes++
 		}
-
-		// 커버된 슬롯 표시
-		for _, slot := range node.Slots {
-			if slot >= 0 && slot < 16384 {
-				status.SlotsCovered[slot] = true
-			}
+		if node.IsLoading() {
+			status.LoadingNodes++
 		}
 	}
 
-	// malformed 라인에 대한 경고
-	if malformedLines > 0 {
-		fmt.Printf("\n  경고: %d개의 잘못된 노드 라인이 무시되었습니다\n", malformedLines)
-	}
-
-	// 총 커버된 슬롯 수 계산
-	for _, covered := range status.SlotsCovered {
-		if covered {
-			status.TotalSlots++
-		}
+	// 열린 슬롯 수집 (MIGRATING/IMPORTING 상태는 각 마스터 자신의 라인에만 표시됨)
+	openSlots, err := redis.CollectOpenSlots(ctx, topology)
+	if err != nil {
+		fmt.Printf("\n  경고: 일부 노드에서 열린 슬롯을 확인하지 못했습니다: %v", err)
 	}
+	status.OpenSlots = redis.GroupOpenSlots(openSlots)
 
 	// 추가 통계를 위한 클러스터 정보 가져오기
 	infoResult := client.ClusterInfo(ctx)
@@ -290,81 +282,7 @@ func getClusterStatus(ctx context.Context, client *redis.ClusterClient, dbsize b
 	return status, nil
 }
 
-func parseClusterNode(line string) (*ClusterNode, error) {
-	parts := strings.Fields(line)
-	if len(parts) < 8 {
-		return nil, fmt.Errorf("malformed node line")
-	}
-
-	node := &ClusterNode{
-		ID:   parts[0],
-		Addr: parts[1],
-	}
-
-	// 플래그 파싱 using unified utility
-	flags := strings.Split(parts[2], ",")
-	node.Flags = flags
-	nodeFlags := parseNodeFlagsSlice(flags)
-	node.IsMaster = nodeFlags.IsMaster
-	node.IsReplica = nodeFlags.IsReplica
-	node.IsFail = nodeFlags.IsFail
-	node.IsHandshake = nodeFlags.IsHandshake
-
-	// 레플리카를 위한 마스터 ID 파싱
-	if len(parts) > 3 && parts[3] != "-" {
-		node.MasterID = parts[3]
-	}
-
-	// 슬롯 파싱 (인덱스 8부터 시작)
-	if len(parts) > 8 {
-		for i := 8; i < len(parts); i++ {
-			slotRange := parts[i]
-
-			// 임포팅/마이그레이팅 상태 슬롯 처리 (예: [1234->-node_id] [<-1234-node_id])
-			if strings.HasPrefix(slotRange, "[") {
-				continue // 임시 상태 슬롯은 건너뛰기
-			}
-
-			if strings.Contains(slotRange, "-") {
-				// Range like "0-5460"
-				rangeParts := strings.Split(slotRange, "-")
-				if len(rangeParts) == 2 {
-					start, err1 := strconv.Atoi(rangeParts[0])
-					end, err2 := strconv.Atoi(rangeParts[1])
-					if err1 == nil && err2 == nil && start >= 0 && end < 16384 && start <= end {
-						for slot := start; slot <= end; slot++ {
-							node.Slots = append(node.Slots, slot)
-						}
-					}
-				}
-			} else {
-				// Single slot
-				if slot, err := strconv.Atoi(slotRange); err == nil && slot >= 0 && slot < 16384 {
-					node.Slots = append(node.Slots, slot)
-				}
-			}
-		}
-	}
-
-	// 역할 재검증: 더 정확한 마스터/복제본 판단
-	// 복제본은 마스터 ID가 있어야 하고, 마스터는 슬롯을 가지거나 master 플래그가 있어야 함
-	if node.MasterID != "" && node.MasterID != "-" {
-		// 마스터 ID가 있으면 복제본
-		node.IsReplica = true
-		node.IsMaster = false
-	} else if len(node.Slots) > 0 {
-		// 슬롯을 가지고 있으면 마스터
-		node.IsMaster = true
-		node.IsReplica = false
-	} else {
-		// 플래그 기반으로만 판단 (기존 로직 유지)
-		// 이미 위에서 플래그 파싱됨
-	}
-
-	return node, nil
-}
-
-func getEstimatedKeyCount(ctx context.Context, client *redis.ClusterClient, dbsize bool) int64 {
+func getEstimatedKeyCount(ctx context.Context, client *redisv9.ClusterClient, dbsize bool) int64 {
 	if dbsize {
 		// 정확한 키 개수: 모든 슬롯에서 키 개수 합산
 		return getPreciseKeyCount(ctx, client)
@@ -374,7 +292,7 @@ func getEstimatedKeyCount(ctx context.Context, client *redis.ClusterClient, dbsi
 	return getSampledKeyCount(ctx, client)
 }
 
-func getPreciseKeyCount(ctx context.Context, client *redis.ClusterClient) int64 {
+func getPreciseKeyCount(ctx context.Context, client *redisv9.ClusterClient) int64 {
 	var totalKeys int64
 
 	// 모든 16384 슬롯에서 키 개수 확인 (느릴 수 있음)
@@ -388,7 +306,7 @@ func getPreciseKeyCount(ctx context.Context, client *redis.ClusterClient) int64
 	return totalKeys
 }
 
-func getSampledKeyCount(ctx context.Context, client *redis.ClusterClient) int64 {
+func getSampledKeyCount(ctx context.Context, client *redisv9.ClusterClient) int64 {
 	// 클러스터에서 키 개수 가져오기 시도
 	var totalKeys int64
 
@@ -445,6 +363,13 @@ func displayClusterReport(status *ClusterStatus, verbose bool) {
 		fmt.Printf("실패한 노드: %s\n", styles.ErrorStyle.Render(strconv.Itoa(status.FailedNodes)))
 	}
 
+	if status.LoadingNodes > 0 {
+		fmt.Printf("로딩 중인 노드: %s\n", styles.WarningStyle.Render(strconv.Itoa(status.LoadingNodes)))
+	}
+
+	fmt.Printf("토폴로지 소스: %s\n", styles.DescStyle.Render(status.Topology.Source))
+	fmt.Printf("사용한 시드: %s\n", styles.DescStyle.Render(fmt.Sprintf("%s (시드 %d개 중)", status.Seed, status.SeedCount)))
+
 	// 추가 클러스터 통계
 	if status.ClusterState != "" {
 		stateStyle := styles.SuccessStyle
@@ -488,33 +413,72 @@ func displayClusterReport(status *ClusterStatus, verbose bool) {
 	fmt.Println(styles.TitleStyle.Render("노드 상세"))
 
 	// 노드 정렬: 마스터 먼저, 그 다음 레플리카
-	sortedNodes := append([]ClusterNode{}, status.Nodes...)
+	sortedNodes := append([]redis.ClusterNode{}, status.Nodes...)
 	sort.Slice(sortedNodes, func(i, j int) bool {
-		if sortedNodes[i].IsMaster && !sortedNodes[j].IsMaster {
+		if sortedNodes[i].IsMaster() && !sortedNodes[j].IsMaster() {
 			return true
 		}
-		if !sortedNodes[i].IsMaster && sortedNodes[j].IsMaster {
+		if !sortedNodes[i].IsMaster() && sortedNodes[j].IsMaster() {
 			return false
 		}
-		return sortedNodes[i].Addr < sortedNodes[j].Addr
+		return sortedNodes[i].Address < sortedNodes[j].Address
 	})
 
 	for _, node := range sortedNodes {
 		displayNodeInfo(node, verbose)
 	}
+
+	if len(status.OpenSlots) > 0 {
+		displayOpenSlots(status)
+	}
 }
 
-func displayNodeInfo(node ClusterNode, verbose bool) {
+// displayOpenSlots lists slots left open by an interrupted migration
+func displayOpenSlots(status *ClusterStatus) {
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("열린 슬롯 (중단된 마이그레이션)"))
+
+	nodeAddr := func(id string) string {
+		if node, ok := status.Topology.NodeByID(id); ok {
+			return node.Endpoint()
+		}
+		if len(id) > 8 {
+			return id[:8] + "... (알 수 없는 노드)"
+		}
+		return id + " (알 수 없는 노드)"
+	}
+
+	for _, m := range status.OpenSlots {
+		var states []string
+		if m.Migrating {
+			states = append(states, "소스 MIGRATING")
+		}
+		if m.Importing {
+			states = append(states, "대상 IMPORTING")
+		}
+
+		fmt.Printf("  슬롯 %s: %s → %s (%s)\n",
+			styles.HighlightStyle.Render(strconv.Itoa(m.Slot)),
+			styles.WarningStyle.Render(nodeAddr(m.SourceID)),
+			styles.SuccessStyle.Render(nodeAddr(m.TargetID)),
+			styles.ErrorStyle.Render(strings.Join(states, ", ")))
+	}
+}
+
+func displayNodeInfo(node redis.ClusterNode, verbose bool) {
 	var nodeType string
 	var styledType string
 
-	if node.IsFail {
+	if node.IsFail() {
 		nodeType = "실패"
 		styledType = styles.ErrorStyle.Render(nodeType)
-	} else if node.IsMaster {
+	} else if node.IsLoading() {
+		nodeType = "로딩 중"
+		styledType = styles.WarningStyle.Render(nodeType)
+	} else if node.IsMaster() {
 		nodeType = "마스터"
 		styledType = styles.SuccessStyle.Render(nodeType)
-	} else if node.IsReplica {
+	} else if node.IsReplica() {
 		nodeType = "레플리카"
 		styledType = styles.InfoStyle.Render(nodeType)
 	} else {
@@ -522,21 +486,14 @@ func displayNodeInfo(node ClusterNode, verbose bool) {
 		styledType = styles.WarningStyle.Render(nodeType)
 	}
 
-	// 주소 정리 (클러스터 포트 제거)
-	addr := node.Addr
-	if strings.Contains(addr, "@") {
-		addr = strings.Split(addr, "@")[0]
-	}
-
-	// 주소 유효성 검사 및 정리
-	if addr == "" || addr == ":0" || addr == ":" || strings.HasPrefix(addr, ":") {
+	// 주소 정리 (클러스터 포트/호스트명 제거) 및 유효성 검사
+	addr := node.Address
+	if addr == "" || strings.HasPrefix(addr, ":") {
 		addr = "주소 불명"
+	} else if !isValidNodeAddress(addr) {
+		addr = fmt.Sprintf("%s (주소 오류)", addr)
 	} else {
-		// 유효한 주소인지 기본 검증
-		parts := strings.Split(addr, ":")
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" || parts[1] == "0" {
-			addr = fmt.Sprintf("%s (주소 오류)", addr)
-		}
+		addr = normalizeClusterAddress(addr)
 	}
 
 	if verbose {
@@ -545,15 +502,31 @@ func displayNodeInfo(node ClusterNode, verbose bool) {
 			styledType,
 			styles.HighlightStyle.Render(addr))
 		fmt.Printf("    ID: %s\n", styles.DescStyle.Render(node.ID))
-		fmt.Printf("    주소: %s\n", styles.DescStyle.Render(node.Addr))
+		fmt.Printf("    주소: %s\n", styles.DescStyle.Render(node.Address))
 
 		if len(node.Flags) > 0 {
 			fmt.Printf("    플래그: %s\n", styles.DescStyle.Render(strings.Join(node.Flags, ",")))
 		}
 
+		if node.Health != "" {
+			fmt.Printf("    상태: %s\n", styles.DescStyle.Render(node.Health))
+		}
+
+		if node.IsReplica() && node.ReplicationOffset > 0 {
+			fmt.Printf("    복제 오프셋: %s\n", styles.DescStyle.Render(strconv.FormatInt(node.ReplicationOffset, 10)))
+		}
+
+		if node.ConfigEpoch > 0 {
+			fmt.Printf("    설정 에포크: %s\n", styles.DescStyle.Render(strconv.FormatInt(node.ConfigEpoch, 10)))
+		}
+
+		if node.LinkState != "" {
+			fmt.Printf("    링크 상태: %s\n", styles.DescStyle.Render(node.LinkState))
+		}
+
 		if len(node.Slots) > 0 {
-			slotRanges := formatCheckSlotRanges(node.Slots)
-			fmt.Printf("    슬롯: %s개", styles.HighlightStyle.Render(strconv.Itoa(len(node.Slots))))
+			slotRanges := formatCheckSlotRanges(node.SlotList())
+			fmt.Printf("    슬롯: %s개", styles.HighlightStyle.Render(strconv.Itoa(node.SlotCount())))
 			if len(slotRanges) <= 5 {
 				fmt.Printf(" (%s)", strings.Join(slotRanges, ", "))
 			} else {
@@ -562,8 +535,8 @@ func displayNodeInfo(node ClusterNode, verbose bool) {
 			fmt.Println()
 		}
 
-		if node.IsReplica && node.MasterID != "" {
-			fmt.Printf("    마스터 ID: %s\n", styles.DescStyle.Render(node.MasterID))
+		if node.IsReplica() && node.HasMaster() {
+			fmt.Printf("    마스터 ID: %s\n", styles.DescStyle.Render(node.Master))
 		}
 		fmt.Println()
 	} else {
@@ -574,15 +547,15 @@ func displayNodeInfo(node ClusterNode, verbose bool) {
 			styles.DescStyle.Render(node.ID[:8]+"..."))
 
 		if len(node.Slots) > 0 {
-			slotRanges := formatCheckSlotRanges(node.Slots)
-			fmt.Printf(" | 슬롯: %s", styles.HighlightStyle.Render(strconv.Itoa(len(node.Slots))))
+			slotRanges := formatCheckSlotRanges(node.SlotList())
+			fmt.Printf(" | 슬롯: %s", styles.HighlightStyle.Render(strconv.Itoa(node.SlotCount())))
 			if len(slotRanges) <= 3 {
 				fmt.Printf(" (%s)", strings.Join(slotRanges, ", "))
 			}
 		}
 
-		if node.IsReplica && node.MasterID != "" {
-			fmt.Printf(" | 마스터: %s", styles.DescStyle.Render(node.MasterID[:8]+"..."))
+		if node.IsReplica() && node.HasMaster() {
+			fmt.Printf(" | 마스터: %s", styles.DescStyle.Render(node.Master[:8]+"..."))
 		}
 
 		fmt.Println()
@@ -630,7 +603,7 @@ func runHealthChecks(status *ClusterStatus) []string {
 
 	// 체크 1: 모든 슬롯 커버되었는지
 	if status.TotalSlots != 16384 {
-		issues = append(issues, fmt.Sprintf("슬롯 커버리지 불완전: %d/16384 슬롯", status.TotalSlots))
+		issues = append(issues, fmt.Sprintf("슬롯 커버리지 불완전: %d/16384 슬롯 ('redisctl fix'로 미할당 슬롯 할당 가능)", status.TotalSlots))
 	}
 
 	// 체크 2: 실패한 노드들
@@ -638,6 +611,17 @@ func runHealthChecks(status *ClusterStatus) []string {
 		issues = append(issues, fmt.Sprintf("실패한 노드: %d개", status.FailedNodes))
 	}
 
+	// 체크 1-1: 열린 슬롯 (중단된 리샤딩)
+	if len(status.OpenSlots) > 0 {
+		issues = append(issues, fmt.Sprintf("%s열린 슬롯: %d개 (중단된 슬롯 마이그레이션 - 'redisctl fix'로 복구)",
+			criticalIssuePrefix, len(status.OpenSlots)))
+	}
+
+	// 체크 2-1: 데이터 로딩 중인 노드들 (CLUSTER SHARDS health)
+	if status.LoadingNodes > 0 {
+		issues = append(issues, fmt.Sprintf("로딩 중인 노드: %d개 (RDB/AOF 로딩 완료 후 재확인 권장)", status.LoadingNodes))
+	}
+
 	// 체크 3: 클러스터 상태 (fail이지만 슬롯이 완전히 커버되어 있으면 경고 수준으로)
 	if status.ClusterState == "fail" {
 		if status.TotalSlots == 16384 && status.FailedNodes == 0 {
@@ -652,10 +636,10 @@ func runHealthChecks(status *ClusterStatus) []string {
 	// 체크 4: 복제본 없는 마스터들
 	mastersWithoutReplicas := 0
 	for _, node := range status.Nodes {
-		if node.IsMaster && !node.IsFail {
+		if node.IsMaster() && !node.IsFail() {
 			hasReplica := false
-			for _, otherNode := range status.Nodes {
-				if otherNode.IsReplica && otherNode.MasterID == node.ID && !otherNode.IsFail {
+			for _, replica := range status.Topology.ReplicasOf(node.ID) {
+				if !replica.IsFail() {
 					hasReplica = true
 					break
 				}
@@ -674,8 +658,8 @@ func runHealthChecks(status *ClusterStatus) []string {
 	if status.Masters > 1 {
 		slotCounts := make([]int, 0, status.Masters)
 		for _, node := range status.Nodes {
-			if node.IsMaster && !node.IsFail {
-				slotCounts = append(slotCounts, len(node.Slots))
+			if node.IsMaster() && !node.IsFail() {
+				slotCounts = append(slotCounts, node.SlotCount())
 			}
 		}
 
@@ -709,7 +693,7 @@ func runHealthChecks(status *ClusterStatus) []string {
 	// 체크 6: 핸드셰이크 상태 노드들
 	handshakeNodes := 0
 	for _, node := range status.Nodes {
-		if node.IsHandshake {
+		if node.IsHandshake() {
 			handshakeNodes++
 		}
 	}
@@ -721,18 +705,8 @@ func runHealthChecks(status *ClusterStatus) []string {
 	// 체크 7: 주소 정보가 잘못된 노드들
 	malformedAddressNodes := 0
 	for _, node := range status.Nodes {
-		addr := node.Addr
-		if strings.Contains(addr, "@") {
-			addr = strings.Split(addr, "@")[0]
-		}
-
-		if addr == "" || addr == ":0" || addr == ":" || strings.HasPrefix(addr, ":") {
+		if !isValidNodeAddress(node.Address) {
 			malformedAddressNodes++
-		} else {
-			parts := strings.Split(addr, ":")
-			if len(parts) != 2 || parts[0] == "" || parts[1] == "" || parts[1] == "0" {
-				malformedAddressNodes++
-			}
 		}
 	}
 
@@ -740,6 +714,9 @@ func runHealthChecks(status *ClusterStatus) []string {
 		issues = append(issues, fmt.Sprintf("주소 정보 오류 노드: %d개 (클러스터 동기화 문제 가능성)", malformedAddressNodes))
 	}
 
+	// 체크 8: 존 장애 시 샤드 전체 손실 (zone 라벨이 있는 노드만)
+	issues = append(issues, zoneOutageRisks(status.Topology, status.Zones)...)
+
 	fmt.Println(styles.SuccessStyle.Render(" 완료"))
 	return issues
 }
@@ -755,7 +732,11 @@ func displayHealthReport(issues []string) {
 	} else {
 		fmt.Println(styles.WarningStyle.Render("  발견된 문제들:"))
 		for i, issue := range issues {
-			fmt.Printf("  %d. %s\n", i+1, styles.WarningStyle.Render(issue))
+			issueStyle := styles.WarningStyle
+			if strings.HasPrefix(issue, criticalIssuePrefix) {
+				issueStyle = styles.ErrorStyle
+			}
+			fmt.Printf("  %d. %s\n", i+1, issueStyle.Render(issue))
 		}
 		fmt.Println()
 		fmt.Printf(styles.WarningStyle.Render("⚠️  발견된 문제: %d개\n"), len(issues))
@@ -763,11 +744,10 @@ func displayHealthReport(issues []string) {
 	fmt.Println() // Add final newline
 }
 
-func checkClusterConsistency(ctx context.Context, client *redis.ClusterClient, status *ClusterStatus) ([]string, error) {
+func checkClusterConsistency(ctx context.Context, client *redisv9.ClusterClient, status *ClusterStatus) ([]string, error) {
 	fmt.Print(styles.InfoStyle.Render("4. 클러스터 정보 일관성 검사..."))
 
 	var issues []string
-	user, password := config.GetAuth()
 
 	// 각 노드에서 클러스터 노드 출력 저장
 	nodeClusterInfo := make(map[string]string)
@@ -776,44 +756,34 @@ func checkClusterConsistency(ctx context.Context, client *redis.ClusterClient, s
 
 	// 병렬로 각 연결 가능한 노드에서 클러스터 노드 정보 가져오기
 	for _, node := range status.Nodes {
-		if node.IsFail {
+		if node.IsFail() {
 			continue // 실패한 노드는 건너뛰기
 		}
 
 		wg.Add(1)
-		go func(nodeAddr string) {
+		go func(node redis.ClusterNode) {
 			defer wg.Done()
 
 			// 주소 정리
-			addr := nodeAddr
-			if strings.Contains(addr, "@") {
-				addr = strings.Split(addr, "@")[0]
-			}
+			addr := normalizeClusterAddress(node.Address)
 
-			// 개별 노드에 연결 (타임아웃 설정)
-			nodeClient := redis.NewClient(&redis.Options{
-				Addr:         addr,
-				Username:     user,
-				Password:     password,
-				DialTimeout:  time.Second * 3,
-				ReadTimeout:  time.Second * 3,
-				WriteTimeout: time.Second * 3,
-			})
+			// 개별 노드에 연결 (설정된 타임아웃 적용, TLS 클러스터는 tls-port)
+			nodeClient := redis.NewNodeClient(node.Endpoint())
 			defer nodeClient.Close()
 
-			// 이 특정 노드에서 CLUSTER NODES 가져오기
-			result := nodeClient.ClusterNodes(ctx)
-			if result.Err() != nil {
+			// 이 특정 노드가 보는 토폴로지 (CLUSTER SHARDS 우선)
+			topology, err := redis.LoadTopology(ctx, nodeClient)
+			if err != nil {
 				mu.Lock()
-				issues = append(issues, fmt.Sprintf("노드 %s에서 클러스터 정보 조회 실패: %v", addr, result.Err()))
+				issues = append(issues, fmt.Sprintf("노드 %s에서 클러스터 정보 조회 실패: %v", addr, err))
 				mu.Unlock()
 				return
 			}
 
 			mu.Lock()
-			nodeClusterInfo[addr] = normalizeClusterNodesOutput(result.Val())
+			nodeClusterInfo[addr] = normalizeClusterView(topology.Nodes)
 			mu.Unlock()
-		}(node.Addr)
+		}(node)
 	}
 
 	wg.Wait()
@@ -856,53 +826,43 @@ func checkClusterConsistency(ctx context.Context, client *redis.ClusterClient, s
 	return issues, nil
 }
 
-func normalizeClusterNodesOutput(output string) string {
-	lines := strings.Split(output, "\n")
+// normalizeClusterView reduces one node's view of the cluster to the parts every
+// node should agree on, so views can be compared as strings
+func normalizeClusterView(nodes []redis.ClusterNode) string {
 	var normalizedLines []string
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// 일관성 있는 비교를 위해 노드 라인 파싱하고 정렬
+	for _, node := range nodes {
 		// 일관성 체크를 위해 모든 동적/변수 부분 제거
-		parts := strings.Fields(line)
-		if len(parts) >= 8 {
-			// 포맷: <id> <ip:port@cport> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot1> ... <slotN>
-			// 진짜 안정적인 부분들만 유지: ID(0), addr(1), normalized_flags(2), master(3), slots(8+)
-
-			var stableParts []string
-			stableParts = append(stableParts, parts[0]) // ID
-
-			// 주소 정규화 (클러스터 포트 제거)
-			addr := parts[1]
-			if strings.Contains(addr, "@") {
-				addr = strings.Split(addr, "@")[0]
-			}
-			stableParts = append(stableParts, addr)
-
-			// 플래그 정규화 (노드별 특성인 myself, handshake 플래그 제거)
-			flags := strings.Split(parts[2], ",")
-			var normalizedFlags []string
-			for _, flag := range flags {
-				if flag != "myself" && flag != "handshake" {
-					normalizedFlags = append(normalizedFlags, flag)
-				}
+		// 진짜 안정적인 부분들만 유지: ID, addr, normalized_flags, master, slots
+		stableParts := []string{node.ID, node.Endpoint()}
+
+		// 플래그 정규화 (노드별 특성인 myself, handshake 플래그 제거)
+		var normalizedFlags []string
+		for _, flag := range node.Flags {
+			if flag != "myself" && flag != "handshake" {
+				normalizedFlags = append(normalizedFlags, flag)
 			}
-			sort.Strings(normalizedFlags) // 일관성을 위해 플래그 정렬
-			stableParts = append(stableParts, strings.Join(normalizedFlags, ","))
+		}
+		sort.Strings(normalizedFlags) // 일관성을 위해 플래그 정렬
+		stableParts = append(stableParts, strings.Join(normalizedFlags, ","))
 
-			stableParts = append(stableParts, parts[3]) // master
+		stableParts = append(stableParts, node.Master)
 
-			// 슬롯 추가 (있다면) - 이것들은 일관되어야 함
-			if len(parts) > 8 {
-				stableParts = append(stableParts, parts[8:]...)
-			}
+		// CLUSTER SHARDS의 노드 상태 (online/failed/loading)
+		if node.Health != "" {
+			stableParts = append(stableParts, node.Health)
+		}
 
-			normalizedLines = append(normalizedLines, strings.Join(stableParts, " "))
+		// 슬롯 추가 (있다면) - 이것들은 일관되어야 함
+		for _, r := range node.Slots {
+			if r.Start == r.End {
+				stableParts = append(stableParts, strconv.Itoa(r.Start))
+			} else {
+				stableParts = append(stableParts, fmt.Sprintf("%d-%d", r.Start, r.End))
+			}
 		}
+
+		normalizedLines = append(normalizedLines, strings.Join(stableParts, " "))
 	}
 
 	// 일관된 비교를 위해 라인 정렬
@@ -911,7 +871,7 @@ func normalizeClusterNodesOutput(output string) string {
 }
 
 // getClusterStatusQuick quickly checks only the cluster state without full analysis
-func getClusterStatusQuick(ctx context.Context, client *redis.ClusterClient) (string, error) {
+func getClusterStatusQuick(ctx context.Context, client *redisv9.ClusterClient) (string, error) {
 	infoResult := client.ClusterInfo(ctx)
 	if infoResult.Err() != nil {
 		return "", infoResult.Err()
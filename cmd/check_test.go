@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"redisctl/internal/redis"
+)
+
+// TestNormalizeClusterView tests that views differ only in what nodes should agree on
+func TestNormalizeClusterView(t *testing.T) {
+	fromA := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-8191
+bbbb 10.0.1.2:7001@17001 master - 0 1700000000000 2 connected 8192-16383`).Nodes
+	fromB := redis.ParseTopology(`bbbb 10.0.1.2:7001@17001 myself,master - 0 0 2 connected 8192-16383
+aaaa 10.0.1.1:7001@17001 master - 1700000000000 1700000000001 1 connected 0-8191`).Nodes
+
+	if normalizeClusterView(fromA) != normalizeClusterView(fromB) {
+		t.Errorf("views that differ only in myself, order and ping times should match:\n%s\n%s",
+			normalizeClusterView(fromA), normalizeClusterView(fromB))
+	}
+
+	// CLUSTER SHARDS health is part of the view: a node still loading on one side differs
+	loading := append([]redis.ClusterNode(nil), fromB...)
+	loading[1].Health = redis.HealthLoading
+	if normalizeClusterView(fromA) == normalizeClusterView(loading) {
+		t.Errorf("views that disagree on shard health should differ")
+	}
+
+	moved := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-8190
+bbbb 10.0.1.2:7001@17001 master - 0 0 3 connected 8191-16383`).Nodes
+	if normalizeClusterView(fromA) == normalizeClusterView(moved) {
+		t.Errorf("views that disagree on slot ownership should differ")
+	}
+}
@@ -6,10 +6,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"redisctl/internal/config"
+	"redisctl/internal/inventory"
+	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 )
 
@@ -24,10 +27,19 @@ func NewConfigCommand() *cobra.Command {
 			styles.DescStyle.Render("지원하는 환경 변수:") + "\n" +
 			styles.DescStyle.Render("• REDIS_USER - Redis 사용자명") + "\n" +
 			styles.DescStyle.Render("• REDIS_PASSWORD - Redis 비밀번호") + "\n" +
+			styles.DescStyle.Render("• REDIS_PASSWORD_FILE - 비밀번호 파일 경로") + "\n" +
+			styles.DescStyle.Render("• REDIS_CREDENTIAL_COMMAND - 사용자명/비밀번호를 출력하는 명령") + "\n" +
+			styles.DescStyle.Render("• REDIS_NODE_CREDENTIALS - 노드별 인증 정보 파일") + "\n" +
 			styles.DescStyle.Render("• REDIS_CONNECT_TIMEOUT - 연결 타임아웃 (예: 10s)") + "\n" +
 			styles.DescStyle.Render("• REDIS_COMMAND_TIMEOUT - 명령 타임아웃 (예: 60s)") + "\n" +
 			styles.DescStyle.Render("• REDIS_MAX_RETRIES - 최대 재시도 횟수") + "\n" +
+			styles.DescStyle.Render("• REDIS_MIN_RETRY_BACKOFF, REDIS_MAX_RETRY_BACKOFF - 재시도 백오프 (예: 100ms, 2s)") + "\n" +
 			styles.DescStyle.Render("• REDIS_POOL_SIZE - 연결 풀 크기") + "\n" +
+			styles.DescStyle.Render("• REDIS_MIGRATE_RETRIES, REDIS_MIGRATE_MIN_BACKOFF, REDIS_MIGRATE_MAX_BACKOFF - 키 마이그레이션 재시도") + "\n" +
+			styles.DescStyle.Render("• REDIS_SEEDS - 추가 시드 노드 (쉼표 구분)") + "\n" +
+			styles.DescStyle.Render("• REDIS_INVENTORY - 노드 라벨 인벤토리 파일") + "\n" +
+			styles.DescStyle.Render("• REDIS_TLS, REDIS_TLS_CACERT, REDIS_TLS_CERT, REDIS_TLS_KEY - TLS 설정") + "\n" +
+			styles.DescStyle.Render("• REDIS_TLS_INSECURE_SKIP_VERIFY, REDIS_TLS_SNI - TLS 검증 설정") + "\n" +
 			styles.DescStyle.Render("• REDIS_DEBUG - 디버그 모드 (true/1)"),
 		Example: `  # 현재 설정 표시
   redisctl config
@@ -62,12 +74,61 @@ func runShowConfig() error {
 		fmt.Printf("사용자명: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
 	}
 
-	if password != "" {
-		fmt.Printf("비밀번호: %s\n", styles.SuccessStyle.Render("***설정됨***"))
-	} else {
+	source := config.GetPasswordSource()
+	switch {
+	case password != "":
+		fmt.Printf("비밀번호: %s (%s)\n", styles.SuccessStyle.Render("***설정됨***"), styles.HighlightStyle.Render(string(source)))
+	case config.GetCredentialCommand() != "":
+		fmt.Printf("비밀번호: %s\n", styles.DescStyle.Render("<명령 실행 시 credential_command로 조회>"))
+	default:
 		fmt.Printf("비밀번호: %s\n", styles.ErrorStyle.Render("<설정되지 않음>"))
 	}
 
+	fmt.Println()
+	fmt.Println(styles.SubtitleStyle.Render("비밀번호 우선순위"))
+	for i, candidate := range config.PasswordPrecedence {
+		marker := "  "
+		if password != "" && candidate == source {
+			marker = styles.SuccessStyle.Render("→ ")
+		}
+		fmt.Printf("%s%d. %s - %s\n", marker, i+1, styles.HighlightStyle.Render(string(candidate)), passwordSourceDesc(candidate))
+	}
+
+	fmt.Println()
+	fmt.Println(styles.SubtitleStyle.Render("노드별 인증 정보"))
+	if file := config.GetNodeCredentialsFile(); file != "" {
+		overrides, fallbacks := 0, 0
+		for _, cred := range config.GetNodeCredentials() {
+			if cred.Node == config.FallbackNode {
+				fallbacks++
+			} else {
+				overrides++
+			}
+		}
+		fmt.Printf("파일: %s\n", styles.HighlightStyle.Render(file))
+		fmt.Printf("노드별 지정: %d개, 대체 비밀번호: %d개\n", overrides, fallbacks)
+		fmt.Println(styles.DescStyle.Render("(노드 ID > 주소 > 기본 비밀번호 > 대체 비밀번호 순서로 시도)"))
+	} else {
+		fmt.Printf("파일: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
+	}
+
+	fmt.Println()
+	fmt.Println(styles.SubtitleStyle.Render("노드 라벨 (인벤토리)"))
+	_, _, inventoryPath := config.GetLabelOptions()
+	if inventoryPath == "" {
+		inventoryPath = inventory.DefaultPath()
+	}
+	fmt.Printf("파일: %s\n", styles.HighlightStyle.Render(inventoryPath))
+	if inv, err := inventory.Load(inventoryPath); err != nil {
+		fmt.Printf("상태: %s\n", styles.RenderError(err.Error()))
+	} else {
+		zoneSet := make(map[string]bool)
+		for _, zone := range inv.Zones() {
+			zoneSet[zone] = true
+		}
+		fmt.Printf("라벨이 있는 노드: %d개, 존: %d개\n", len(inv.Nodes), len(zoneSet))
+	}
+
 	fmt.Println()
 	fmt.Println(styles.TitleStyle.Render("환경 변수 상태"))
 	envUser := os.Getenv("REDIS_USER")
@@ -85,13 +146,62 @@ func runShowConfig() error {
 		fmt.Printf("REDIS_PASSWORD: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
 	}
 
+	if envFile := os.Getenv("REDIS_PASSWORD_FILE"); envFile != "" {
+		fmt.Printf("REDIS_PASSWORD_FILE: %s\n", styles.HighlightStyle.Render(envFile))
+	} else {
+		fmt.Printf("REDIS_PASSWORD_FILE: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
+	}
+
+	if envCommand := os.Getenv("REDIS_CREDENTIAL_COMMAND"); envCommand != "" {
+		fmt.Printf("REDIS_CREDENTIAL_COMMAND: %s\n", styles.HighlightStyle.Render(envCommand))
+	} else {
+		fmt.Printf("REDIS_CREDENTIAL_COMMAND: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
+	}
+
 	fmt.Println()
 	fmt.Println(styles.TitleStyle.Render("연결 설정"))
+	fmt.Println(styles.DescStyle.Render("(모든 명령어의 연결에 공통으로 적용됩니다)"))
 	fmt.Printf("연결 타임아웃: %s\n", styles.HighlightStyle.Render(config.GetConnectTimeout().String()))
 	fmt.Printf("명령 타임아웃: %s\n", styles.HighlightStyle.Render(config.GetCommandTimeout().String()))
 	fmt.Printf("최대 재시도: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%d", config.GetMaxRetries())))
+	minBackoff, maxBackoff := config.GetRetryBackoff()
+	fmt.Printf("재시도 백오프: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%s ~ %s", minBackoff, maxBackoff)))
+	fmt.Printf("MIGRATE 타임아웃: %s\n", styles.HighlightStyle.Render(redis.MigrateTimeout().String()))
 	fmt.Printf("연결 풀 크기: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%d", config.GetPoolSize())))
 
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("마이그레이션 재시도"))
+	fmt.Println(styles.DescStyle.Render("(IOERR, TRYAGAIN, CLUSTERDOWN, LOADING, 네트워크 오류만 재시도합니다)"))
+	migrateRetries, migrateMinBackoff, migrateMaxBackoff := config.GetMigrateRetryPolicy()
+	fmt.Printf("재시도 횟수: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%d", migrateRetries)))
+	fmt.Printf("재시도 백오프: %s\n", styles.HighlightStyle.Render(fmt.Sprintf("%s ~ %s", migrateMinBackoff, migrateMaxBackoff)))
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("시드 노드"))
+	if seeds := config.GetSeeds(); len(seeds) > 0 {
+		fmt.Printf("추가 시드: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
+	} else {
+		fmt.Printf("추가 시드: %s\n", styles.DescStyle.Render("<설정되지 않음>"))
+	}
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("TLS 설정"))
+	tlsOpts := config.GetTLSOptions()
+	if tlsOpts.Enabled {
+		fmt.Printf("TLS: %s\n", styles.SuccessStyle.Render("활성화"))
+		fmt.Printf("CA 인증서: %s\n", displayPath(tlsOpts.CACert))
+		fmt.Printf("클라이언트 인증서: %s\n", displayPath(tlsOpts.Cert))
+		fmt.Printf("클라이언트 키: %s\n", displayPath(tlsOpts.Key))
+		if tlsOpts.ServerName != "" {
+			fmt.Printf("SNI: %s\n", styles.HighlightStyle.Render(tlsOpts.ServerName))
+		}
+		if tlsOpts.InsecureSkipVerify {
+			fmt.Printf("인증서 검증: %s\n", styles.WarningStyle.Render("생략 (insecure)"))
+		}
+	} else {
+		fmt.Printf("TLS: %s\n", styles.DescStyle.Render("비활성화"))
+	}
+
 	fmt.Println()
 	fmt.Println(styles.TitleStyle.Render("! 디버그 설정"))
 	if config.IsDebugEnabled() {
@@ -106,11 +216,55 @@ func runShowConfig() error {
 	fmt.Println()
 	fmt.Printf("  %s - Redis 사용자명\n", styles.HighlightStyle.Render("REDIS_USER"))
 	fmt.Printf("  %s - Redis 비밀번호\n", styles.HighlightStyle.Render("REDIS_PASSWORD"))
+	fmt.Printf("  %s - 비밀번호 파일 경로 (예: /run/secrets/redis)\n", styles.HighlightStyle.Render("REDIS_PASSWORD_FILE"))
+	fmt.Printf("  %s - 사용자명/비밀번호 조회 명령 (예: pass show redis)\n", styles.HighlightStyle.Render("REDIS_CREDENTIAL_COMMAND"))
+	fmt.Printf("  %s - 노드별 인증 정보 파일 (비밀번호 교체 중)\n", styles.HighlightStyle.Render("REDIS_NODE_CREDENTIALS"))
 	fmt.Printf("  %s - 연결 타임아웃 (예: 10s, 30s)\n", styles.HighlightStyle.Render("REDIS_CONNECT_TIMEOUT"))
 	fmt.Printf("  %s - 명령 타임아웃 (예: 60s, 120s)\n", styles.HighlightStyle.Render("REDIS_COMMAND_TIMEOUT"))
 	fmt.Printf("  %s - 최대 재시도 횟수 (예: 3, 5)\n", styles.HighlightStyle.Render("REDIS_MAX_RETRIES"))
+	fmt.Printf("  %s - 최소 재시도 백오프 (예: 100ms)\n", styles.HighlightStyle.Render("REDIS_MIN_RETRY_BACKOFF"))
+	fmt.Printf("  %s - 최대 재시도 백오프 (예: 2s)\n", styles.HighlightStyle.Render("REDIS_MAX_RETRY_BACKOFF"))
 	fmt.Printf("  %s - 연결 풀 크기 (예: 10, 20)\n", styles.HighlightStyle.Render("REDIS_POOL_SIZE"))
+	fmt.Printf("  %s - 키 마이그레이션 재시도 횟수 (예: 5)\n", styles.HighlightStyle.Render("REDIS_MIGRATE_RETRIES"))
+	fmt.Printf("  %s / %s - 마이그레이션 재시도 백오프 (예: 200ms, 5s)\n", styles.HighlightStyle.Render("REDIS_MIGRATE_MIN_BACKOFF"), styles.HighlightStyle.Render("REDIS_MIGRATE_MAX_BACKOFF"))
 	fmt.Printf("  %s - 디버그 모드 (true/1)\n", styles.HighlightStyle.Render("REDIS_DEBUG"))
+	fmt.Printf("  %s - 추가 시드 노드 (예: 10.0.0.1:7001,10.0.0.2:7001)\n", styles.HighlightStyle.Render("REDIS_SEEDS"))
+	fmt.Printf("  %s - 노드 라벨 인벤토리 파일 (기본값: %s)\n", styles.HighlightStyle.Render("REDIS_INVENTORY"), inventory.DefaultPath())
+	fmt.Printf("  %s - TLS 사용 (true/1, false/0이면 인증서 옵션이 있어도 비활성화)\n", styles.HighlightStyle.Render("REDIS_TLS"))
+	fmt.Printf("  %s - CA 인증서 파일\n", styles.HighlightStyle.Render("REDIS_TLS_CACERT"))
+	fmt.Printf("  %s / %s - mTLS 인증서/키 파일\n", styles.HighlightStyle.Render("REDIS_TLS_CERT"), styles.HighlightStyle.Render("REDIS_TLS_KEY"))
+	fmt.Printf("  %s - 인증서 검증 생략 (true/1)\n", styles.HighlightStyle.Render("REDIS_TLS_INSECURE_SKIP_VERIFY"))
+	fmt.Printf("  %s - TLS SNI 서버 이름\n", styles.HighlightStyle.Render("REDIS_TLS_SNI"))
 
 	return nil
 }
+
+// displayPath renders an optional file path setting
+func displayPath(path string) string {
+	if path == "" {
+		return styles.DescStyle.Render("<설정되지 않음>")
+	}
+	return styles.HighlightStyle.Render(path)
+}
+
+// passwordSourceDesc describes a password source for the precedence list
+func passwordSourceDesc(source config.PasswordSource) string {
+	switch source {
+	case config.PasswordSourceFlag:
+		return "명령줄 인수 (ps/히스토리에 노출, 비권장)"
+	case config.PasswordSourceStdin:
+		return "표준 입력"
+	case config.PasswordSourceFile:
+		return "비밀번호 파일"
+	case config.PasswordSourceEnvFile:
+		return "환경 변수로 지정한 비밀번호 파일"
+	case config.PasswordSourceEnv:
+		return "환경 변수"
+	case config.PasswordSourceCommand:
+		return "--credential-command / REDIS_CREDENTIAL_COMMAND 실행 결과"
+	case config.PasswordSourcePrompt:
+		return "터미널 입력 (화면에 표시되지 않음)"
+	default:
+		return ""
+	}
+}
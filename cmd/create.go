@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,56 +18,222 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// createOptions holds the create flags
+type createOptions struct {
+	replicas int
+	weights  []string // --weight host:port=N
+	dryRun   bool
+	strict   bool   // 사전 점검 경고도 중단
+	planOut  string // --plan-out: write the computed plan as JSON
+	planFile string // --plan: execute a previously written plan
+
+	resume            bool   // --resume: continue an unfinished create from its progress record
+	rollbackOnFailure bool   // --rollback-on-failure: CLUSTER RESET HARD every node on failure
+	progressFile      string // progress record, empty: createProgressPath()
+}
+
 // NewCreateCommand create 명령어
 func NewCreateCommand() *cobra.Command {
-	var replicas int
+	var opts createOptions
 
 	cmd := &cobra.Command{
-		Use:   "create [--replicas N] ip1:port1 ... ipN:portN",
+		Use:   "create [--replicas N] [--weight host:port=N] [--strict] [--dry-run] [--plan-out FILE] [--rollback-on-failure] [--progress-file FILE] ip1:port1 ... ipN:portN | create --plan FILE | create --resume [--progress-file FILE]",
 		Short: "< Redis 클러스터를 생성합니다",
 		Long: styles.TitleStyle.Render("[T] Redis 클러스터 생성") + "\n\n" +
 			styles.DescStyle.Render("지정된 노드들로부터 Redis 클러스터를 초기화합니다.") + "\n" +
-			styles.DescStyle.Render("최소 노드 요구사항을 충족해야 하며, 마스터 노드들 간에 슬롯을 균등하게 분배합니다."),
+			styles.DescStyle.Render("최소 노드 요구사항을 충족해야 하며, 마스터 노드들 간에 슬롯을 균등하게 분배합니다.") + "\n" +
+			styles.DescStyle.Render("--weight로 마스터별 가중치를 주면 가중치 비율대로 연속된 슬롯 범위를 할당합니다.") + "\n" +
+			styles.DescStyle.Render("--dry-run으로 노드에 연결하지 않고 레이아웃 계획만 확인하고, --plan-out으로 저장한 계획을 --plan으로 그대로 실행할 수 있습니다.") + "\n" +
+			styles.DescStyle.Render("실패하거나 중단되면 진행 상황이 기록되며, --resume으로 실제 노드 상태를 확인해 남은 단계부터 이어서 진행합니다.") + "\n" +
+			styles.DescStyle.Render("--rollback-on-failure를 주면 실패 시 모든 노드를 CLUSTER RESET HARD로 초기화합니다."),
 		Example: `  # 3개 노드로 클러스터 생성 (복제본 없음)
   redisctl create localhost:7001 localhost:7002 localhost:7003
 
   # 6개 노드로 클러스터 생성 (각 마스터당 복제본 1개)
-  redisctl create --replicas 1 localhost:7001 localhost:7002 localhost:7003 localhost:7004 localhost:7005 localhost:7006`,
-		Args: cobra.MinimumNArgs(3),
+  redisctl create --replicas 1 localhost:7001 localhost:7002 localhost:7003 localhost:7004 localhost:7005 localhost:7006
+
+  # 메모리가 2배인 노드에 슬롯을 2배 할당
+  redisctl create --weight localhost:7001=2 localhost:7001 localhost:7002 localhost:7003
+
+  # 레이아웃 계획을 검토용 파일로 저장 (노드 변경 없음)
+  redisctl create --replicas 1 --dry-run --plan-out plan.json localhost:7001 ... localhost:7006
+
+  # 승인된 계획을 그대로 실행
+  redisctl create --plan plan.json
+
+  # 실패/중단된 생성을 이어서 진행
+  redisctl create --resume
+
+  # 여러 클러스터를 동시에 만들 때는 클러스터별 진행 기록 사용
+  redisctl create --progress-file prod-a.json localhost:7001 localhost:7002 localhost:7003
+  redisctl create --resume --progress-file prod-a.json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.resume {
+				if len(args) > 0 || opts.planFile != "" || opts.dryRun || cmd.Flags().Changed("replicas") || cmd.Flags().Changed("weight") {
+					return fmt.Errorf("--resume은 기록된 계획을 사용하므로 노드, --plan, --replicas, --weight, --dry-run과 함께 사용할 수 없습니다")
+				}
+				return nil
+			}
+			if opts.planFile != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--plan을 사용할 때는 노드를 지정하지 않습니다 (계획 파일의 노드를 사용)")
+				}
+				if cmd.Flags().Changed("replicas") || cmd.Flags().Changed("weight") {
+					return fmt.Errorf("--plan은 --replicas, --weight와 함께 사용할 수 없습니다 (계획 파일을 수정하세요)")
+				}
+				return nil
+			}
+			return cobra.MinimumNArgs(3)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := config.ValidateAuth(); err != nil {
-				return err
+			// dry-run은 노드에 연결하지 않으므로 인증이 필요 없다
+			if !opts.dryRun {
+				if err := config.ValidateAuth(); err != nil {
+					return err
+				}
 			}
 
-			return runCreateCluster(args, replicas)
+			return runCreateCluster(cmd.Context(), args, opts)
 		},
 	}
 
-	cmd.Flags().IntVar(&replicas, "replicas", 0, "각 마스터당 복제본 수 (기본값: 0)")
+	cmd.Flags().IntVar(&opts.replicas, "replicas", 0, "각 마스터당 복제본 수 (기본값: 0)")
+	cmd.Flags().StringArrayVar(&opts.weights, "weight", nil, "마스터 슬롯 가중치 host:port=N (반복 지정, 기본값: 1)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "노드를 변경하지 않고 레이아웃 계획만 표시")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "사전 점검 경고(버전/설정 불일치 등)도 오류로 처리해 중단")
+	cmd.Flags().StringVar(&opts.planOut, "plan-out", "", "계산된 레이아웃 계획을 JSON 파일로 저장")
+	cmd.Flags().StringVar(&opts.planFile, "plan", "", "저장된 레이아웃 계획 파일을 그대로 실행")
+	cmd.Flags().BoolVar(&opts.resume, "resume", false, "실패/중단된 생성을 기록된 계획으로 이어서 진행")
+	cmd.Flags().BoolVar(&opts.rollbackOnFailure, "rollback-on-failure", false, "실패/중단 시 진행 기록 대신 모든 노드를 CLUSTER RESET HARD로 초기화")
+	cmd.Flags().StringVar(&opts.progressFile, "progress-file", "", "생성 진행 기록 파일 (기본값: <사용자 설정 디렉터리>/redisctl/create-progress.json)")
 
 	return cmd
 }
 
-func runCreateCluster(nodes []string, replicas int) error {
+func runCreateCluster(ctx context.Context, nodes []string, opts createOptions) error {
+	if opts.progressFile == "" {
+		opts.progressFile = createProgressPath()
+	}
+
+	// 저장된 계획이 있으면 노드와 레이아웃을 다시 계산하지 않고 그대로 사용
+	var plan *CreatePlan
+	var progress *createProgress
+	if opts.resume {
+		loaded, err := loadCreateProgress(opts.progressFile)
+		if err != nil {
+			return err
+		}
+		progress = loaded
+		plan = progress.Plan
+		nodes = plan.Nodes
+		opts.replicas = plan.Replicas
+	} else if opts.planFile != "" {
+		loaded, err := loadCreatePlan(opts.planFile)
+		if err != nil {
+			return err
+		}
+		plan = loaded
+		nodes = plan.Nodes
+		opts.replicas = plan.Replicas
+	}
+
 	fmt.Println(styles.InfoStyle.Render("클러스터 생성 시작..."))
-	fmt.Printf("노드 수: %d, 복제본: %d\n", len(nodes), replicas)
+	fmt.Printf("노드 수: %d, 복제본: %d\n", len(nodes), opts.replicas)
+	if progress != nil {
+		fmt.Printf("이어서 진행: %s (%s)\n", styles.HighlightStyle.Render(opts.progressFile), progress.Updated.Format("2006-01-02 15:04:05"))
+		fmt.Printf("기록된 진행 상황: %s\n", progress.summary())
+	} else if plan != nil {
+		fmt.Printf("계획 파일: %s\n", styles.HighlightStyle.Render(opts.planFile))
+	}
 
 	// 입력 검증 개선
-	if err := validateClusterInput(nodes, replicas); err != nil {
+	if err := validateClusterInput(nodes, opts.replicas); err != nil {
 		return err
 	}
 
-	// 최소 노드 수 검증
-	minNodes := calculateMinNodes(replicas)
-	if len(nodes) < minNodes {
+	// 최소 노드 수 검증 (계획 파일은 Validate에서 확인)
+	minNodes := calculateMinNodes(opts.replicas)
+	if plan == nil && len(nodes) < minNodes {
 		return fmt.Errorf("최소 %d개의 노드가 필요합니다 (현재: %d개)", minNodes, len(nodes))
 	}
 
+	// 존 라벨 (--label, --topology-file, 로컬 인벤토리)
+	inv, err := loadInventory()
+	if err != nil {
+		return err
+	}
+	zones := inv.Zones()
+
+	// 마스터와 복제본 계산
+	if plan == nil {
+		weights, err := parseWeights(opts.weights)
+		if err != nil {
+			return err
+		}
+		masters, replicaMap, err := calculateClusterLayout(nodes, opts.replicas, zones)
+		if err != nil {
+			return fmt.Errorf("클러스터 레이아웃 계산 실패: %w", err)
+		}
+		plan, err = newCreatePlan(nodes, opts.replicas, masters, replicaMap, weights)
+		if err != nil {
+			return fmt.Errorf("클러스터 레이아웃 계산 실패: %w", err)
+		}
+	}
+	masters, replicaMap := plan.Layout()
+
+	// 노드에 연결하기 전에 계획을 저장해 실행이 실패해도 검토할 수 있게 한다
+	if opts.planOut != "" {
+		if err := plan.Save(opts.planOut); err != nil {
+			return err
+		}
+		fmt.Printf("레이아웃 계획 저장: %s\n", styles.HighlightStyle.Render(opts.planOut))
+	}
+
+	if opts.dryRun {
+		fmt.Println(styles.InfoStyle.Render("레이아웃 계획 (dry-run: 노드에 연결하지 않음)"))
+		displayCreateLayout(plan, nodes, zones)
+		fmt.Println()
+		fmt.Println(styles.BoxStyle.Render(
+			styles.SubtitleStyle.Render("클러스터 내결함성 분석") + "\n" +
+				analyzeClusterResilience(masters, replicaMap, zones),
+		))
+		fmt.Println()
+		fmt.Println(styles.InfoStyle.Render("실제 생성을 수행하려면 --dry-run 플래그를 제거하세요"))
+		return nil
+	}
+
 	user, password := config.GetAuth()
-	cm := redis.NewClusterManager(user, password)
+	cm := redis.NewClusterManager(ctx, user, password)
 	defer cm.Close()
 
-	ctx := context.Background()
+	// 실패/중단 시 기본은 진행 기록을 남겨 --resume으로 이어가고,
+	// --rollback-on-failure일 때만 이미 변경된 노드를 모두 초기화한다
+	fail := func(err error) error {
+		if opts.rollbackOnFailure {
+			// 모든 노드를 초기화했으면 남은 기록으로 --resume하지 않도록 지운다
+			if rollbackClusterCreation(cm.WithContext(context.WithoutCancel(ctx)), nodes) && progress != nil {
+				progress.remove()
+			}
+			return err
+		}
+		if progress != nil {
+			resume := "redisctl create --resume"
+			if opts.progressFile != createProgressPath() {
+				resume += " --progress-file " + opts.progressFile
+			}
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("진행 상황: %s", progress.summary())))
+			fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("원인을 해결한 뒤 '%s'으로 이어서 진행하세요", resume)))
+			fmt.Println(styles.DescStyle.Render("  처음부터 다시 하려면 각 노드에서 CLUSTER RESET HARD 후 다시 생성하거나 --rollback-on-failure를 사용하세요"))
+		}
+		return err
+	}
+	abortIfInterrupted := func() error {
+		if ctx.Err() == nil {
+			return nil
+		}
+		fmt.Println()
+		return fail(ErrInterrupted)
+	}
 
 	// 모든 노드가 도달 가능하고 클러스터 모드 아님을 확인 (병렬 처리)
 	fmt.Println(styles.InfoStyle.Render("1단계: 노드 연결 확인 중..."))
@@ -98,9 +267,9 @@ func runCreateCluster(nodes []string, replicas int) error {
 				return
 			}
 
-			// 이미 클러스터에 참여 중인지 체크
+			// 이미 클러스터에 참여 중인지 체크 (이어서 진행할 때는 이전 실행에서 참여한 것이므로 허용)
 			info, err := cm.GetClusterInfo(nodeAddr)
-			if err == nil && info["cluster_state"] != "fail" {
+			if !opts.resume && err == nil && info["cluster_state"] != "fail" {
 				results <- nodeCheckResult{
 					node:    nodeAddr,
 					index:   index,
@@ -151,15 +320,15 @@ func runCreateCluster(nodes []string, replicas int) error {
 		}
 	}
 
-	// 마스터와 복제본 계산
-	masters, replicaMap, err := calculateClusterLayout(nodes, replicas)
-	if err != nil {
-		return fmt.Errorf("클러스터 레이아웃 계산 실패: %w", err)
+	// 이어서 진행할 때는 이전 실행에서 사전 점검을 통과했다
+	if !opts.resume {
+		if err := runPreflight(ctx, cm, nodes, "", opts.strict); err != nil {
+			return err
+		}
 	}
 
 	fmt.Println(styles.InfoStyle.Render("2단계: 클러스터 레이아웃 계획"))
-	fmt.Printf("  마스터 노드: %d개\n", len(masters))
-	fmt.Printf("  복제본: %d개\n", len(nodes)-len(masters))
+	displayCreateLayout(plan, nodes, zones)
 
 	// 모든 노드들 만나게 하기
 	fmt.Println(styles.InfoStyle.Render("3단계: 노드 간 핸드셰이크 수행 중..."))
@@ -167,64 +336,95 @@ func runCreateCluster(nodes []string, replicas int) error {
 	firstNode := nodes[0]
 	firstClient, _ := cm.Connect(firstNode)
 
+	// 첫 노드 MEET 전에 진행 기록을 시작한다
+	if progress == nil {
+		if _, err := os.Stat(opts.progressFile); err == nil {
+			fmt.Println(styles.RenderWarning(fmt.Sprintf("이전에 완료되지 않은 생성 기록을 새 기록으로 덮어씁니다 (%s)", opts.progressFile)))
+		}
+		progress = newCreateProgress(plan, opts.progressFile)
+		progress.save()
+	}
+
+	// 이미 첫 노드가 알고 있는 노드는 건너뛴다
+	known, err := cm.LoadTopology(firstNode)
+	if err != nil {
+		return fail(fmt.Errorf("클러스터 노드 조회 실패 (%s): %w", firstNode, err))
+	}
+
 	for i, node := range nodes[1:] {
+		if err := abortIfInterrupted(); err != nil {
+			return err
+		}
+
 		fmt.Printf("  [%d/%d] %s와 핸드셰이크...", i+1, len(nodes)-1, node)
 
+		if member, ok := known.NodeByAddress(node); ok && !member.IsHandshake() {
+			fmt.Printf(" %s\n", styles.DescStyle.Render("이미 연결됨"))
+			continue
+		}
+
 		host, port, err := parseNodeAddress(node)
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("주소 파싱 실패"))
-			return err
+			return fail(err)
 		}
 
 		// CLUSTER MEET 명령어
 		err = firstClient.ClusterMeet(ctx, host, port).Err()
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("핸드셰이크 실패"))
-			return fmt.Errorf("CLUSTER MEET 실패 (%s): %w", node, err)
+			return fail(fmt.Errorf("CLUSTER MEET 실패 (%s): %w", node, err))
 		}
 
 		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
 	}
+	progress.markMeet()
 
-	// 슬롯 -> 마스터 (성능 개선: 에러 시 롤백 추가)
+	// 슬롯 -> 마스터
 	fmt.Println(styles.InfoStyle.Render("4단계: 마스터 노드에 슬롯 할당 중..."))
 
-	slotsPerMaster := 16384 / len(masters)
-	remainder := 16384 % len(masters)
-
-	currentSlot := 0
 	assignedMasters := []string{} // 롤백을 위한 추적
 
-	for i, masterNode := range masters {
+	for _, shard := range plan.Masters {
+		if err := abortIfInterrupted(); err != nil {
+			return err
+		}
+
+		masterNode := shard.Address
 		client, _ := cm.Connect(masterNode)
 
-		slots := slotsPerMaster
-		if i < remainder {
-			slots++
+		ranges := make([]string, 0, len(shard.Slots))
+		for _, r := range shard.Slots {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", r.Start, r.End))
 		}
+		fmt.Printf("  %s: 슬롯 %s (%d개)...", masterNode, strings.Join(ranges, ", "), shard.SlotCount())
 
-		endSlot := currentSlot + slots - 1
-		fmt.Printf("  %s: 슬롯 %d-%d (%d개)...", masterNode, currentSlot, endSlot, slots)
-
-		// 슬롯 배열 준비 - 더 안전한 방식
-		slotArgs := make([]int, 0, slots)
-		for slot := currentSlot; slot <= endSlot; slot++ {
-			slotArgs = append(slotArgs, slot)
+		// 이전 실행에서 할당된 슬롯은 건너뛴다
+		slotArgs, err := missingPlanSlots(cm, masterNode, shard)
+		if err != nil {
+			fmt.Printf(" %s\n", styles.RenderError("슬롯 상태 확인 실패"))
+			return fail(err)
+		}
+		if len(slotArgs) == 0 {
+			fmt.Printf(" %s\n", styles.DescStyle.Render("이미 할당됨"))
+			progress.markSlots(masterNode)
+			continue
 		}
 
-		// CLUSTER ADDSLOTS 명령어 (에러 시 롤백)
-		err := client.ClusterAddSlots(ctx, slotArgs...).Err()
+		// CLUSTER ADDSLOTS 명령어
+		err = client.ClusterAddSlots(ctx, slotArgs...).Err()
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("슬롯 할당 실패"))
-			// 부분적으로 할당된 슬롯들을 먼저 롤백
-			rollbackPartialSlotAssignment(cm, assignedMasters)
-			rollbackClusterCreation(cm, nodes)
-			return fmt.Errorf("슬롯 할당 실패 (%s): %w", masterNode, err)
+			if opts.rollbackOnFailure {
+				// 부분적으로 할당된 슬롯들을 먼저 롤백
+				rollbackPartialSlotAssignment(cm, assignedMasters)
+			}
+			return fail(fmt.Errorf("슬롯 할당 실패 (%s): %w", masterNode, err))
 		}
 
 		assignedMasters = append(assignedMasters, masterNode)
+		progress.markSlots(masterNode)
 		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
-		currentSlot += slots
 	}
 
 	// 복제본 설정 (롤백 로직 추가)
@@ -233,7 +433,10 @@ func runCreateCluster(nodes []string, replicas int) error {
 
 		// 클러스터 상태 안정화 대기 (성능 개선: 동적 대기)
 		fmt.Print("  클러스터 안정화 대기 중...")
-		err := waitForClusterStable(cm, firstNode, 10*time.Second)
+		err := waitForClusterStable(ctx, cm, firstNode, 10*time.Second)
+		if errors.Is(err, ErrInterrupted) {
+			return abortIfInterrupted()
+		}
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderWarning("타임아웃"))
 			// 하드코딩 대기로 fallback
@@ -242,7 +445,13 @@ func runCreateCluster(nodes []string, replicas int) error {
 			fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
 		}
 
-		for replicaNode, masterNode := range replicaMap {
+		for _, replicaNode := range planReplicas(plan) {
+			if err := abortIfInterrupted(); err != nil {
+				return err
+			}
+
+			masterNode := replicaMap[replicaNode]
+
 			fmt.Printf("  %s -> %s 복제 설정...", replicaNode, masterNode)
 
 			// 마스터 노드 ID 가져오기
@@ -250,8 +459,14 @@ func runCreateCluster(nodes []string, replicas int) error {
 			masterInfo, err := masterClient.ClusterMyID(ctx).Result()
 			if err != nil {
 				fmt.Printf(" %s\n", styles.RenderError("마스터 ID 조회 실패"))
-				rollbackClusterCreation(cm, nodes) // 롤백 추가
-				return fmt.Errorf("마스터 ID 조회 실패 (%s): %w", masterNode, err)
+				return fail(fmt.Errorf("마스터 ID 조회 실패 (%s): %w", masterNode, err))
+			}
+
+			// 이전 실행에서 설정된 복제본은 건너뛴다
+			if replicates, err := nodeReplicates(cm, replicaNode, masterInfo); err == nil && replicates {
+				fmt.Printf(" %s\n", styles.DescStyle.Render("이미 설정됨"))
+				progress.markReplica(replicaNode)
+				continue
 			}
 
 			// 복제본 설정
@@ -259,10 +474,10 @@ func runCreateCluster(nodes []string, replicas int) error {
 			err = replicaClient.ClusterReplicate(ctx, masterInfo).Err()
 			if err != nil {
 				fmt.Printf(" %s\n", styles.RenderError("복제 설정 실패"))
-				rollbackClusterCreation(cm, nodes) // 롤백 추가
-				return fmt.Errorf("복제 설정 실패 (%s): %w", replicaNode, err)
+				return fail(fmt.Errorf("복제 설정 실패 (%s): %w", replicaNode, err))
 			}
 
+			progress.markReplica(replicaNode)
 			fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
 		}
 	}
@@ -272,7 +487,13 @@ func runCreateCluster(nodes []string, replicas int) error {
 
 	// 동적 안정화 대기
 	fmt.Print("  클러스터 최종 안정화 대기 중...")
-	err = waitForClusterStable(cm, firstNode, 15*time.Second)
+	err = waitForClusterStable(ctx, cm, firstNode, 15*time.Second)
+	if errors.Is(err, ErrInterrupted) {
+		// 구성은 모두 끝났으므로 롤백하지 않고 검증만 생략
+		fmt.Printf(" %s\n", styles.RenderWarning("중단됨"))
+		fmt.Println(styles.WarningStyle.Render("  클러스터 구성은 완료되었습니다. 'redisctl check'로 상태를 확인하세요."))
+		return err
+	}
 	if err != nil {
 		fmt.Printf(" %s\n", styles.RenderWarning("타임아웃 - 하드코딩 대기로 fallback"))
 		time.Sleep(3 * time.Second)
@@ -285,18 +506,19 @@ func runCreateCluster(nodes []string, replicas int) error {
 	info, err := cm.GetClusterInfo(firstNode)
 	if err != nil {
 		fmt.Printf(" %s\n", styles.RenderError("정보 조회 실패"))
-		return fmt.Errorf("클러스터 정보 조회 실패: %w", err)
+		return fail(fmt.Errorf("클러스터 정보 조회 실패: %w", err))
 	}
 
 	if info["cluster_state"] != "ok" {
 		fmt.Printf(" %s\n", styles.RenderError("비정상 상태"))
-		return fmt.Errorf("클러스터 상태가 비정상입니다: %s", info["cluster_state"])
+		return fail(fmt.Errorf("클러스터 상태가 비정상입니다: %s", info["cluster_state"]))
 	}
 	fmt.Printf(" %s\n", styles.RenderSuccess("OK"))
+	progress.remove()
 
 	// 슬롯 커버리지 검증
 	fmt.Print("  슬롯 커버리지 검증 중...")
-	slotsCovered, err := verifySlotCoverage(cm, firstNode)
+	slotsCovered, err := verifySlotCoverage(ctx, cm, firstNode)
 	if err != nil {
 		fmt.Printf(" %s\n", styles.RenderWarning("검증 실패"))
 		// 경고만 출력하고 계속 진행
@@ -307,6 +529,11 @@ func runCreateCluster(nodes []string, replicas int) error {
 	}
 
 	// Success message
+	slotShares := ""
+	for _, shard := range plan.Masters {
+		slotShares += fmt.Sprintf("\n  - %s: 슬롯 %d개 (%s)", shard.Address, shard.SlotCount(), formatSlotShare(shard.SlotCount()))
+	}
+
 	fmt.Println()
 	fmt.Println(styles.RenderSuccess("Redis 클러스터가 성공적으로 생성되었습니다!"))
 	fmt.Println()
@@ -315,11 +542,12 @@ func runCreateCluster(nodes []string, replicas int) error {
 			fmt.Sprintf("• 총 노드: %d개\n", len(nodes)) +
 			fmt.Sprintf("• 마스터: %d개\n", len(masters)) +
 			fmt.Sprintf("• 복제본: %d개\n", len(nodes)-len(masters)) +
-			fmt.Sprintf("• 상태: %s", info["cluster_state"]),
+			fmt.Sprintf("• 상태: %s\n", info["cluster_state"]) +
+			"• 마스터별 슬롯 비율:" + slotShares,
 	))
 
 	// 선택사항: 클러스터 복원력 정보 표시
-	resilienceInfo := analyzeClusterResilience(len(masters), len(nodes)-len(masters), replicas)
+	resilienceInfo := analyzeClusterResilience(masters, replicaMap, zones)
 	fmt.Println()
 	fmt.Println(styles.BoxStyle.Render(
 		styles.SubtitleStyle.Render("클러스터 내결함성 분석") + "\n" +
@@ -330,9 +558,28 @@ func runCreateCluster(nodes []string, replicas int) error {
 }
 
 // analyzeClusterResilience 하드웨어 장애로부터 클러스터 복구 능력 분석
-func analyzeClusterResilience(masterCount, replicaCount, replicasPerMaster int) string {
+// 노드 수가 아니라 실제 배치를 기준으로 호스트/존 단위 장애 허용 수를 계산한다.
+func analyzeClusterResilience(masters []string, replicaMap map[string]string, zones map[string]string) string {
 	var analysis []string
 
+	masterCount := len(masters)
+	replicaCount := len(replicaMap)
+
+	// 마스터별 복제본 수 (균등 여부 확인)
+	perMaster := make(map[string]int)
+	for _, master := range replicaMap {
+		perMaster[master]++
+	}
+	replicasPerMaster := -1
+	for _, master := range masters {
+		if replicasPerMaster == -1 {
+			replicasPerMaster = perMaster[master]
+		} else if perMaster[master] != replicasPerMaster {
+			replicasPerMaster = 0
+			break
+		}
+	}
+
 	// 기본 복원력 분석
 	if replicaCount == 0 {
 		analysis = append(analysis, "! 복제본이 없어 마스터 노드 장애 시 데이터 손실 위험이 있습니다")
@@ -353,21 +600,41 @@ func analyzeClusterResilience(masterCount, replicaCount, replicasPerMaster int)
 	// 클러스터 전체 복원력
 	if masterCount >= 3 {
 		analysis = append(analysis, "최소 마스터 수(3개) 요구사항을 만족합니다")
+	}
 
-		// 클러스터 생존 가능성 계산
-		if replicaCount > 0 {
-			// 최악의 경우, 마스터들을 무작위로 잃는다면
-			minSurvivableFaults := 1
-			if replicasPerMaster > 0 {
-				minSurvivableFaults = replicasPerMaster
-			}
-			analysis = append(analysis, fmt.Sprintf("권장사항: 최소 %d개 노드 동시 장애까지 서비스 연속성 보장", minSurvivableFaults))
+	// 호스트 단위 장애 허용 수
+	hosts, tolerated, weakHosts := faultTolerance(masters, replicaMap, nodeHost)
+	switch {
+	case len(hosts) == 1:
+		analysis = append(analysis, fmt.Sprintf("! 모든 노드가 한 호스트(%s)에 있어 호스트 장애 시 클러스터 전체가 중단됩니다", hosts[0]))
+	case tolerated == 0:
+		analysis = append(analysis, fmt.Sprintf("! 호스트 1대 장애로도 서비스가 중단될 수 있습니다 (취약 호스트: %s)", strings.Join(weakHosts, ", ")))
+	default:
+		analysis = append(analysis, fmt.Sprintf("호스트 내결함성: %d개 호스트 중 최대 %d대 동시 장애까지 서비스 유지", len(hosts), tolerated))
+	}
+
+	// 존 단위 장애 허용 수 (모든 노드에 zone 라벨이 있을 때만)
+	allNodes := append([]string(nil), masters...)
+	for replica := range replicaMap {
+		allNodes = append(allNodes, replica)
+	}
+	if zonesCoverAll(zones, allNodes) {
+		zoneNames, zoneTolerated, weakZones := faultTolerance(masters, replicaMap, func(n string) string { return nodeZone(zones, n) })
+		switch {
+		case len(zoneNames) == 1:
+			analysis = append(analysis, fmt.Sprintf("! 모든 노드가 한 존(%s)에 있어 존 장애 시 클러스터 전체가 중단됩니다", zoneNames[0]))
+		case zoneTolerated == 0:
+			analysis = append(analysis, fmt.Sprintf("! 존 1개 장애로도 서비스가 중단될 수 있습니다 (취약 존: %s)", strings.Join(weakZones, ", ")))
+		default:
+			analysis = append(analysis, fmt.Sprintf("존 내결함성: %d개 존 중 최대 %d개 동시 장애까지 서비스 유지", len(zoneNames), zoneTolerated))
 		}
 	}
 
 	// 추가 권장사항
 	if replicaCount == 0 {
 		analysis = append(analysis, "권장사항: 고가용성을 위해 --replicas 1 이상 설정을 고려하세요")
+	} else if tolerated == 0 {
+		analysis = append(analysis, "권장사항: 마스터와 복제본을 서로 다른 호스트에 두도록 노드를 추가하세요")
 	}
 
 	totalNodes := masterCount + replicaCount
@@ -402,10 +669,12 @@ func validateClusterInput(nodes []string, replicas int) error {
 	// 중복 노드 검증
 	nodeSet := make(map[string]bool)
 	for _, node := range nodes {
-		if nodeSet[node] {
+		// localhost:7001 과 127.0.0.1:7001, [::1]:7001 과 ::1:7001 은 같은 노드
+		normalized := normalizeClusterAddress(node)
+		if nodeSet[normalized] {
 			return fmt.Errorf("중복된 노드가 있습니다: %s", node)
 		}
-		nodeSet[node] = true
+		nodeSet[normalized] = true
 		// 노드 주소 형식 검증
 		host, portStr, err := parseNodeAddress(node)
 		if err != nil {
@@ -443,7 +712,10 @@ func validateClusterInput(nodes []string, replicas int) error {
 }
 
 // 개선된 클러스터 레이아웃 계산
-func calculateClusterLayout(nodes []string, replicas int) ([]string, map[string]string, error) {
+// redis-cli와 같이 노드를 존/호스트별로 묶어 번갈아 배치한 뒤 앞쪽을 마스터로 사용하므로
+// 마스터가 여러 존과 호스트에 분산되고, 복제본은 가능하면 마스터와 다른 존/호스트에 배치한다.
+// zones는 정규화된 host:port -> zone 라벨이며 nil이면 호스트만 고려한다.
+func calculateClusterLayout(nodes []string, replicas int, zones map[string]string) ([]string, map[string]string, error) {
 	totalNodes := len(nodes)
 
 	// 마스터 수 계산 - 개선된 로직
@@ -465,18 +737,32 @@ func calculateClusterLayout(nodes []string, replicas int) ([]string, map[string]
 			replicas, mastersCount, replicaNodes, mastersCount-replicaNodes)
 	}
 
-	masters := nodes[:mastersCount]
-	replicaNodesList := nodes[mastersCount:]
+	interleaved := interleaveNodes(nodes, zones)
+	masters := interleaved[:mastersCount]
+	remaining := append([]string(nil), interleaved[mastersCount:]...)
 
-	// 복제본 매핑 생성 - 균등 분배
+	// 복제본 매핑 생성 - 마스터를 돌아가며 균등 분배, 마스터와 다른 존/호스트 우선
 	replicaMap := make(map[string]string)
-	masterIndex := 0
+	usedZones := make(map[string]map[string]bool) // master -> 샤드가 있는 존
+	usedHosts := make(map[string]map[string]bool) // master -> 샤드가 있는 호스트
+	for _, master := range masters {
+		usedZones[master] = map[string]bool{nodeZone(zones, master): true}
+		usedHosts[master] = map[string]bool{nodeHost(master): true}
+	}
+
+	for masterIndex := 0; len(remaining) > 0; masterIndex = (masterIndex + 1) % len(masters) {
+		master := masters[masterIndex]
+		pick := pickReplicaCandidate(remaining, master, usedZones[master], usedHosts[master], zones)
 
-	for _, replicaNode := range replicaNodesList {
-		replicaMap[replicaNode] = masters[masterIndex]
-		masterIndex = (masterIndex + 1) % len(masters)
+		replica := remaining[pick]
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+		replicaMap[replica] = master
+		usedZones[master][nodeZone(zones, replica)] = true
+		usedHosts[master][nodeHost(replica)] = true
 	}
 
+	optimizeAntiAffinity(replicaMap, zones)
+
 	return masters, replicaMap, nil
 }
 
@@ -510,13 +796,12 @@ func isValidHostname(hostname string) bool {
 }
 
 // 슬롯 커버리지 검증 함수
-func verifySlotCoverage(cm *redis.ClusterManager, node string) (int, error) {
+func verifySlotCoverage(ctx context.Context, cm *redis.ClusterManager, node string) (int, error) {
 	client, err := cm.Connect(node)
 	if err != nil {
 		return 0, err
 	}
 
-	ctx := context.Background()
 	// CLUSTER SLOTS 명령어로 할당된 슬롯 확인
 	result, err := client.ClusterSlots(ctx).Result()
 	if err != nil {
@@ -533,6 +818,43 @@ func verifySlotCoverage(cm *redis.ClusterManager, node string) (int, error) {
 	return coveredSlots, nil
 }
 
+// missingPlanSlots returns the planned slots of a master that it does not own yet.
+// A planned slot owned by another node means the cluster diverged from the plan.
+func missingPlanSlots(cm *redis.ClusterManager, master string, shard CreatePlanMaster) ([]int, error) {
+	topology, err := cm.LoadTopology(master)
+	if err != nil {
+		return nil, fmt.Errorf("클러스터 노드 조회 실패 (%s): %w", master, err)
+	}
+	myself, ok := topology.Myself()
+	if !ok {
+		return nil, fmt.Errorf("노드 %s의 자기 정보를 찾을 수 없습니다", master)
+	}
+
+	var missing []int
+	for _, r := range shard.Slots {
+		for slot := r.Start; slot <= r.End; slot++ {
+			owner, ok := topology.SlotOwner(slot)
+			switch {
+			case !ok:
+				missing = append(missing, slot)
+			case owner.ID != myself.ID:
+				return nil, fmt.Errorf("슬롯 %d가 계획과 달리 %s에 할당되어 있습니다", slot, owner.HostPort())
+			}
+		}
+	}
+	return missing, nil
+}
+
+// nodeReplicates reports whether node already replicates the master with masterID
+func nodeReplicates(cm *redis.ClusterManager, node, masterID string) (bool, error) {
+	topology, err := cm.LoadTopology(node)
+	if err != nil {
+		return false, err
+	}
+	myself, ok := topology.Myself()
+	return ok && myself.Master == masterID, nil
+}
+
 // 부분적으로 할당된 슬롯들 롤백
 func rollbackPartialSlotAssignment(cm *redis.ClusterManager, assignedMasters []string) {
 	if len(assignedMasters) == 0 {
@@ -558,9 +880,10 @@ func rollbackPartialSlotAssignment(cm *redis.ClusterManager, assignedMasters []s
 }
 
 // 롤백 함수
-func rollbackClusterCreation(cm *redis.ClusterManager, nodes []string) {
+func rollbackClusterCreation(cm *redis.ClusterManager, nodes []string) bool {
 	fmt.Println(styles.WarningStyle.Render("클러스터 생성 실패 - 롤백 중..."))
 
+	reset := true
 	for i, node := range nodes {
 		fmt.Printf("  [%d/%d] %s 초기화 중...", i+1, len(nodes), node)
 
@@ -568,6 +891,7 @@ func rollbackClusterCreation(cm *redis.ClusterManager, nodes []string) {
 		nodeClient, err := cm.Connect(node)
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderWarning("연결 실패"))
+			reset = false
 			continue
 		}
 
@@ -576,8 +900,10 @@ func rollbackClusterCreation(cm *redis.ClusterManager, nodes []string) {
 
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderWarning("실패"))
+			reset = false
 		} else {
 			fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
 		}
 	}
+	return reset
 }
@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+)
+
+// createPlanVersion is the format version written to plan files
+const createPlanVersion = 1
+
+// CreatePlan is the layout create applies: which nodes become masters, the slots each master
+// serves and its replicas. It can be printed (--dry-run), exported (--plan-out) and executed
+// later exactly as reviewed (--plan).
+type CreatePlan struct {
+	Version  int                `json:"version"`
+	Replicas int                `json:"replicas"`
+	Nodes    []string           `json:"nodes"`
+	Masters  []CreatePlanMaster `json:"masters"`
+}
+
+// CreatePlanMaster is one shard of the plan.
+// Weight is the relative share of slots (omitted = 1); it is only used to compute Slots
+// when a plan file leaves the slots of every master empty.
+type CreatePlanMaster struct {
+	Address  string          `json:"address"`
+	Weight   float64         `json:"weight,omitempty"`
+	Slots    []PlanSlotRange `json:"slots"`
+	Replicas []string        `json:"replicas,omitempty"`
+}
+
+// PlanSlotRange is an inclusive slot range in a plan file
+type PlanSlotRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Count returns the number of slots in the range
+func (r PlanSlotRange) Count() int {
+	return r.End - r.Start + 1
+}
+
+// newCreatePlan builds the plan for a computed layout. Slots are split in master order in proportion
+// to weights (normalized address -> weight, missing = 1); replicas keep the order in which the nodes were given.
+func newCreatePlan(nodes []string, replicas int, masters []string, replicaMap map[string]string, weights map[string]float64) (*CreatePlan, error) {
+	plan := &CreatePlan{
+		Version:  createPlanVersion,
+		Replicas: replicas,
+		Nodes:    append([]string(nil), nodes...),
+	}
+
+	isMaster := make(map[string]bool, len(masters))
+	for _, master := range masters {
+		isMaster[normalizeClusterAddress(master)] = true
+	}
+	known := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		key := normalizeClusterAddress(node)
+		if _, ok := weights[key]; ok && !isMaster[key] {
+			return nil, fmt.Errorf("가중치를 지정한 노드 %s가 복제본으로 배치되었습니다 (--dry-run으로 배치를 확인하고 노드 순서를 조정하세요)", node)
+		}
+		known[key] = true
+	}
+	for node := range weights {
+		if !known[node] {
+			return nil, fmt.Errorf("가중치를 지정한 노드 %s가 노드 목록에 없습니다", node)
+		}
+	}
+
+	for _, master := range masters {
+		shard := CreatePlanMaster{Address: master}
+		if w, ok := weights[normalizeClusterAddress(master)]; ok {
+			shard.Weight = w
+		}
+		for _, node := range nodes {
+			if replicaMap[node] == master {
+				shard.Replicas = append(shard.Replicas, node)
+			}
+		}
+		plan.Masters = append(plan.Masters, shard)
+	}
+
+	if err := plan.assignSlots(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// assignSlots computes the slot ranges of every master from the weights
+func (p *CreatePlan) assignSlots() error {
+	weights := make([]float64, len(p.Masters))
+	for i, shard := range p.Masters {
+		weights[i] = shard.EffectiveWeight()
+	}
+
+	ranges, err := distributeSlots(weights)
+	if err != nil {
+		return err
+	}
+	for i := range p.Masters {
+		p.Masters[i].Slots = []PlanSlotRange{ranges[i]}
+	}
+	return nil
+}
+
+// distributeSlots splits the hash slots into contiguous ranges, one per master, in proportion to weights.
+// Leftover slots after rounding down go to the largest fractions, earlier masters first,
+// so equal weights give the first masters one extra slot when the slots do not divide evenly.
+func distributeSlots(weights []float64) ([]PlanSlotRange, error) {
+	total := 0.0
+	for _, w := range weights {
+		if w <= 0 {
+			return nil, fmt.Errorf("가중치는 0보다 커야 합니다: %g", w)
+		}
+		total += w
+	}
+
+	counts := make([]int, len(weights))
+	fractions := make([]float64, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		quota := float64(redis.ClusterSlots) * w / total
+		counts[i] = int(quota)
+		fractions[i] = quota - float64(counts[i])
+		assigned += counts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return fractions[order[a]] > fractions[order[b]] })
+	for i := 0; assigned < redis.ClusterSlots; i++ {
+		counts[order[i%len(order)]]++
+		assigned++
+	}
+
+	ranges := make([]PlanSlotRange, 0, len(weights))
+	start := 0
+	for i, count := range counts {
+		if count == 0 {
+			return nil, fmt.Errorf("가중치 비율이 너무 커서 %d번째 마스터에 슬롯이 배정되지 않습니다", i+1)
+		}
+		ranges = append(ranges, PlanSlotRange{Start: start, End: start + count - 1})
+		start += count
+	}
+	return ranges, nil
+}
+
+// parseWeights parses --weight values (host:port=N) into normalized address -> weight
+func parseWeights(values []string) (map[string]float64, error) {
+	weights := make(map[string]float64, len(values))
+	for _, value := range values {
+		addr, w, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("잘못된 가중치 %q (형식: host:port=N)", value)
+		}
+		if _, _, err := parseNodeAddress(addr); err != nil {
+			return nil, fmt.Errorf("잘못된 가중치 %q: %w", value, err)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("잘못된 가중치 %q: 0보다 큰 숫자여야 합니다", value)
+		}
+		weights[normalizeClusterAddress(addr)] = weight
+	}
+	return weights, nil
+}
+
+// EffectiveWeight returns the weight of the shard, 1 when not set
+func (m CreatePlanMaster) EffectiveWeight() float64 {
+	if m.Weight == 0 {
+		return 1
+	}
+	return m.Weight
+}
+
+// Layout returns the masters and the replica -> master mapping of the plan
+func (p *CreatePlan) Layout() ([]string, map[string]string) {
+	masters := make([]string, 0, len(p.Masters))
+	replicaMap := make(map[string]string)
+	for _, shard := range p.Masters {
+		masters = append(masters, shard.Address)
+		for _, replica := range shard.Replicas {
+			replicaMap[replica] = shard.Address
+		}
+	}
+	return masters, replicaMap
+}
+
+// SlotCount returns the number of slots a shard serves
+func (m CreatePlanMaster) SlotCount() int {
+	count := 0
+	for _, r := range m.Slots {
+		count += r.Count()
+	}
+	return count
+}
+
+// Validate checks that the plan uses every node exactly once, has at least 3 masters
+// and assigns every slot to exactly one master
+func (p *CreatePlan) Validate() error {
+	if p.Version != createPlanVersion {
+		return fmt.Errorf("지원하지 않는 계획 버전입니다: %d (지원: %d)", p.Version, createPlanVersion)
+	}
+	if len(p.Masters) < 3 {
+		return fmt.Errorf("클러스터를 위해 최소 3개의 마스터가 필요합니다 (계획: %d개)", len(p.Masters))
+	}
+	for _, shard := range p.Masters {
+		if shard.Weight < 0 {
+			return fmt.Errorf("마스터 %s의 가중치는 0보다 커야 합니다: %g", shard.Address, shard.Weight)
+		}
+	}
+	if err := validateClusterInput(p.Nodes, p.Replicas); err != nil {
+		return err
+	}
+
+	unused := make(map[string]string, len(p.Nodes))
+	for _, node := range p.Nodes {
+		unused[normalizeClusterAddress(node)] = node
+	}
+	use := func(node string) error {
+		key := normalizeClusterAddress(node)
+		if _, ok := unused[key]; !ok {
+			return fmt.Errorf("노드 %s가 nodes 목록에 없거나 계획에서 두 번 이상 사용되었습니다", node)
+		}
+		delete(unused, key)
+		return nil
+	}
+
+	var owner [redis.ClusterSlots]string
+	for _, shard := range p.Masters {
+		if err := use(shard.Address); err != nil {
+			return err
+		}
+		for _, replica := range shard.Replicas {
+			if err := use(replica); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range shard.Slots {
+			if r.Start < 0 || r.End >= redis.ClusterSlots || r.Start > r.End {
+				return fmt.Errorf("마스터 %s의 잘못된 슬롯 범위: %d-%d", shard.Address, r.Start, r.End)
+			}
+			for slot := r.Start; slot <= r.End; slot++ {
+				if owner[slot] != "" {
+					return fmt.Errorf("슬롯 %d가 %s와 %s에 중복 할당되었습니다", slot, owner[slot], shard.Address)
+				}
+				owner[slot] = shard.Address
+			}
+		}
+	}
+
+	for _, node := range p.Nodes {
+		if _, ok := unused[normalizeClusterAddress(node)]; ok {
+			return fmt.Errorf("노드 %s가 마스터나 복제본으로 배치되지 않았습니다", node)
+		}
+	}
+	for slot, master := range owner {
+		if master == "" {
+			return fmt.Errorf("슬롯 %d가 할당되지 않았습니다 (모든 %d개 슬롯을 할당해야 합니다)", slot, redis.ClusterSlots)
+		}
+	}
+	return nil
+}
+
+// loadCreatePlan reads and validates a plan written by --plan-out
+func loadCreatePlan(path string) (*CreatePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("계획 파일 읽기 실패 (%s): %w", path, err)
+	}
+
+	var plan CreatePlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("계획 파일 해석 실패 (%s): %w", path, err)
+	}
+
+	// 모든 마스터의 slots를 비워 두면 weight 비율로 계산한다
+	if plan.slotsOmitted() && len(plan.Masters) > 0 {
+		if err := plan.assignSlots(); err != nil {
+			return nil, fmt.Errorf("잘못된 계획 파일 (%s): %w", path, err)
+		}
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, fmt.Errorf("잘못된 계획 파일 (%s): %w", path, err)
+	}
+	return &plan, nil
+}
+
+// slotsOmitted reports whether no master of the plan lists slots
+func (p *CreatePlan) slotsOmitted() bool {
+	for _, shard := range p.Masters {
+		if len(shard.Slots) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Save writes the plan as indented JSON
+func (p *CreatePlan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("계획 파일 저장 실패 (%s): %w", path, err)
+	}
+	return nil
+}
+
+// displayCreatePlan prints every shard with its slot ranges and replicas
+func displayCreatePlan(plan *CreatePlan, zones map[string]string) {
+	withZone := func(node string) string {
+		if zone := nodeZone(zones, node); zone != "" {
+			return fmt.Sprintf("%s (zone=%s)", node, zone)
+		}
+		return node
+	}
+
+	for i, shard := range plan.Masters {
+		ranges := make([]string, 0, len(shard.Slots))
+		for _, r := range shard.Slots {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", r.Start, r.End))
+		}
+		weight := ""
+		if shard.Weight != 0 {
+			weight = fmt.Sprintf(", 가중치 %g", shard.Weight)
+		}
+		fmt.Printf("  마스터 %d: %s  슬롯 %s (%d개, %s%s)\n",
+			i+1, styles.HighlightStyle.Render(withZone(shard.Address)), strings.Join(ranges, ", "), shard.SlotCount(),
+			formatSlotShare(shard.SlotCount()), weight)
+		for _, replica := range shard.Replicas {
+			fmt.Printf("    └ 복제본: %s\n", withZone(replica))
+		}
+	}
+}
+
+// planReplicas returns the replicas of the plan in shard order
+func planReplicas(plan *CreatePlan) []string {
+	var replicas []string
+	for _, shard := range plan.Masters {
+		replicas = append(replicas, shard.Replicas...)
+	}
+	return replicas
+}
+
+// displayCreateLayout prints the layout summary, anti-affinity warnings and every shard
+func displayCreateLayout(plan *CreatePlan, nodes []string, zones map[string]string) {
+	masters, replicaMap := plan.Layout()
+
+	fmt.Printf("  마스터 노드: %d개\n", len(masters))
+	fmt.Printf("  복제본: %d개\n", len(replicaMap))
+	hosts, _ := groupNodesByHost(nodes)
+	fmt.Printf("  호스트: %d개\n", len(hosts))
+	if zonesCoverAll(zones, nodes) {
+		zoneNames, _ := groupNodesBy(nodes, func(n string) string { return nodeZone(zones, n) })
+		fmt.Printf("  존: %d개 (%s)\n", len(zoneNames), strings.Join(zoneNames, ", "))
+	} else if hasAnyZone(zones, nodes) {
+		fmt.Printf("  %s\n", styles.RenderWarning("일부 노드에 zone 라벨이 없어 존 분산이 불완전할 수 있습니다"))
+	}
+	for _, warning := range layoutAntiAffinityWarnings(masters, replicaMap, zones) {
+		fmt.Printf("  %s\n", styles.RenderWarning(warning))
+	}
+	displayCreatePlan(plan, zones)
+}
+
+// formatSlotShare formats a slot count as a percentage of all slots
+func formatSlotShare(slots int) string {
+	return fmt.Sprintf("%.1f%%", float64(slots)*100/redis.ClusterSlots)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"redisctl/internal/styles"
+)
+
+// createProgress records how far create got, so that create --resume continues with the same plan.
+// Each step is also checked against the live cluster on resume; the record keeps the layout
+// (which node is which master, slot ranges, replica assignment) that cannot be recovered from the nodes.
+type createProgress struct {
+	Plan     *CreatePlan `json:"plan"`
+	Meet     bool        `json:"meet"`               // 모든 노드 MEET 완료
+	Slots    []string    `json:"slots,omitempty"`    // 슬롯 할당을 마친 마스터
+	Replicas []string    `json:"replicas,omitempty"` // 복제 설정을 마친 복제본
+	Updated  time.Time   `json:"updated"`
+
+	path   string
+	warned bool
+}
+
+// createProgressPath returns where the create progress is recorded: <user config dir>/redisctl/create-progress.json
+func createProgressPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "redisctl-create-progress.json"
+	}
+	return filepath.Join(dir, "redisctl", "create-progress.json")
+}
+
+// newCreateProgress starts a progress record for plan at path
+func newCreateProgress(plan *CreatePlan, path string) *createProgress {
+	return &createProgress{Plan: plan, path: path}
+}
+
+// loadCreateProgress reads the progress left by an unfinished create
+func loadCreateProgress(path string) (*createProgress, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("이어서 진행할 클러스터 생성 기록이 없습니다 (%s)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("생성 진행 기록 읽기 실패 (%s): %w", path, err)
+	}
+
+	progress := &createProgress{path: path}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, fmt.Errorf("생성 진행 기록 해석 실패 (%s): %w", path, err)
+	}
+	if progress.Plan == nil {
+		return nil, fmt.Errorf("생성 진행 기록에 계획이 없습니다 (%s)", path)
+	}
+	if err := progress.Plan.Validate(); err != nil {
+		return nil, fmt.Errorf("생성 진행 기록의 계획이 잘못되었습니다 (%s): %w", path, err)
+	}
+	return progress, nil
+}
+
+// save writes the record. A failure only costs the ability to resume, so it is reported once as a warning.
+func (p *createProgress) save() {
+	p.Updated = time.Now()
+
+	err := os.MkdirAll(filepath.Dir(p.path), 0o755)
+	if err == nil {
+		var data []byte
+		data, err = json.MarshalIndent(p, "", "  ")
+		if err == nil {
+			err = os.WriteFile(p.path, append(data, '\n'), 0o644)
+		}
+	}
+	if err != nil && !p.warned {
+		p.warned = true
+		fmt.Println(styles.RenderWarning(fmt.Sprintf("생성 진행 기록 저장 실패 (--resume 불가): %v", err)))
+	}
+}
+
+// markMeet records that every node has been introduced to the first node
+func (p *createProgress) markMeet() {
+	p.Meet = true
+	p.save()
+}
+
+// markSlots records that master owns all of its planned slots
+func (p *createProgress) markSlots(master string) {
+	if !stringSliceContains(p.Slots, master) {
+		p.Slots = append(p.Slots, master)
+	}
+	p.save()
+}
+
+// markReplica records that replica replicates its planned master
+func (p *createProgress) markReplica(replica string) {
+	if !stringSliceContains(p.Replicas, replica) {
+		p.Replicas = append(p.Replicas, replica)
+	}
+	p.save()
+}
+
+// remove deletes the record once the cluster has been created
+func (p *createProgress) remove() {
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		fmt.Println(styles.RenderWarning(fmt.Sprintf("생성 진행 기록 삭제 실패: %v", err)))
+	}
+}
+
+// summary describes the recorded progress
+func (p *createProgress) summary() string {
+	meet := "미완료"
+	if p.Meet {
+		meet = "완료"
+	}
+	return fmt.Sprintf("핸드셰이크 %s, 슬롯 할당 %d/%d 마스터, 복제 설정 %d/%d 복제본",
+		meet, len(p.Slots), len(p.Plan.Masters), len(p.Replicas), len(planReplicas(p.Plan)))
+}
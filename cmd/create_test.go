@@ -0,0 +1,481 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"redisctl/internal/redis"
+)
+
+// TestCalculateClusterLayoutAntiAffinity tests that masters are spread over hosts
+// and that replicas land on a different host than their master
+func TestCalculateClusterLayoutAntiAffinity(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodes         []string
+		replicas      int
+		expectedHosts int // distinct hosts among masters
+		conflicts     int // replicas left on their master's host
+	}{
+		{
+			name:          "three hosts, ports grouped by host",
+			nodes:         []string{"10.0.0.1:7001", "10.0.0.1:7002", "10.0.0.2:7001", "10.0.0.2:7002", "10.0.0.3:7001", "10.0.0.3:7002"},
+			replicas:      1,
+			expectedHosts: 3,
+			conflicts:     0,
+		},
+		{
+			name:          "three hosts, hosts interleaved",
+			nodes:         []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001", "10.0.0.1:7002", "10.0.0.2:7002", "10.0.0.3:7002"},
+			replicas:      1,
+			expectedHosts: 3,
+			conflicts:     0,
+		},
+		{
+			name:          "two hosts with two replicas",
+			nodes:         []string{"10.0.0.1:7001", "10.0.0.1:7002", "10.0.0.1:7003", "10.0.0.1:7004", "10.0.0.1:7005", "10.0.0.2:7001", "10.0.0.2:7002", "10.0.0.2:7003", "10.0.0.2:7004"},
+			replicas:      2,
+			expectedHosts: 2,
+			conflicts:     1, // 마스터 2개가 있는 호스트에 필요한 복제본 4개 중 다른 호스트 노드는 3개뿐
+		},
+		{
+			name:          "single host",
+			nodes:         []string{"localhost:7001", "127.0.0.1:7002", "localhost:7003", "localhost:7004", "localhost:7005", "localhost:7006"},
+			replicas:      1,
+			expectedHosts: 1,
+			conflicts:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masters, replicaMap, err := calculateClusterLayout(tt.nodes, tt.replicas, nil)
+			if err != nil {
+				t.Fatalf("calculateClusterLayout() error = %v", err)
+			}
+			if len(masters)+len(replicaMap) != len(tt.nodes) {
+				t.Fatalf("layout uses %d nodes, want %d", len(masters)+len(replicaMap), len(tt.nodes))
+			}
+
+			masterHosts := make(map[string]bool)
+			for _, m := range masters {
+				masterHosts[nodeHost(m)] = true
+			}
+			if len(masterHosts) != tt.expectedHosts {
+				t.Errorf("masters on %d hosts, want %d (%v)", len(masterHosts), tt.expectedHosts, masters)
+			}
+
+			perMaster := make(map[string]int)
+			conflicts := 0
+			for replica, master := range replicaMap {
+				perMaster[master]++
+				if nodeHost(replica) == nodeHost(master) {
+					conflicts++
+				}
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("%d replicas on their master's host, want %d (%v)", conflicts, tt.conflicts, replicaMap)
+			}
+			for _, m := range masters {
+				if perMaster[m] != tt.replicas {
+					t.Errorf("master %s has %d replicas, want %d", m, perMaster[m], tt.replicas)
+				}
+			}
+		})
+	}
+}
+
+// TestHostFaultTolerance tests the host-level fault tolerance of a layout
+func TestHostFaultTolerance(t *testing.T) {
+	tests := []struct {
+		name       string
+		masters    []string
+		replicaMap map[string]string
+		tolerated  int
+		weakHosts  int
+	}{
+		{
+			name:       "replicas on other hosts",
+			masters:    []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"},
+			replicaMap: map[string]string{"10.0.0.2:7002": "10.0.0.1:7001", "10.0.0.3:7002": "10.0.0.2:7001", "10.0.0.1:7002": "10.0.0.3:7001"},
+			tolerated:  1,
+		},
+		{
+			name:       "replica next to its master",
+			masters:    []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"},
+			replicaMap: map[string]string{"10.0.0.1:7002": "10.0.0.1:7001", "10.0.0.3:7002": "10.0.0.2:7001", "10.0.0.2:7002": "10.0.0.3:7001"},
+			tolerated:  0,
+			weakHosts:  1,
+		},
+		{
+			name:       "two masters on one host",
+			masters:    []string{"10.0.0.1:7001", "10.0.0.1:7002", "10.0.0.2:7001"},
+			replicaMap: map[string]string{"10.0.0.2:7002": "10.0.0.1:7001", "10.0.0.2:7003": "10.0.0.1:7002", "10.0.0.1:7003": "10.0.0.2:7001"},
+			tolerated:  0,
+			weakHosts:  1,
+		},
+		{
+			name:      "no replicas",
+			masters:   []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"},
+			weakHosts: 3,
+		},
+		{
+			name:    "two replicas on five hosts",
+			masters: []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001", "10.0.0.4:7001", "10.0.0.5:7001"},
+			replicaMap: map[string]string{
+				"10.0.0.2:7002": "10.0.0.1:7001", "10.0.0.3:7003": "10.0.0.1:7001",
+				"10.0.0.3:7002": "10.0.0.2:7001", "10.0.0.4:7003": "10.0.0.2:7001",
+				"10.0.0.4:7002": "10.0.0.3:7001", "10.0.0.5:7003": "10.0.0.3:7001",
+				"10.0.0.5:7002": "10.0.0.4:7001", "10.0.0.1:7003": "10.0.0.4:7001",
+				"10.0.0.1:7002": "10.0.0.5:7001", "10.0.0.2:7003": "10.0.0.5:7001",
+			},
+			tolerated: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tolerated, weakHosts := faultTolerance(tt.masters, tt.replicaMap, nodeHost)
+			if tolerated != tt.tolerated || len(weakHosts) != tt.weakHosts {
+				t.Errorf("faultTolerance() = %d tolerated, weak hosts %v; want %d tolerated, %d weak hosts",
+					tolerated, weakHosts, tt.tolerated, tt.weakHosts)
+			}
+		})
+	}
+}
+
+// TestCalculateClusterLayoutZones tests that masters and replicas are spread across zones
+func TestCalculateClusterLayoutZones(t *testing.T) {
+	// 존 a에 호스트 2대, 존 b/c에 1대씩 - 입력 순서는 존 a에 몰려 있음
+	nodes := []string{
+		"10.0.1.5:7000", "10.0.1.5:7001", "10.0.1.6:7000",
+		"10.0.2.5:7000", "10.0.2.5:7001", "10.0.3.5:7000",
+	}
+	zones := map[string]string{
+		"10.0.1.5:7000": "a", "10.0.1.5:7001": "a", "10.0.1.6:7000": "a",
+		"10.0.2.5:7000": "b", "10.0.2.5:7001": "b",
+		"10.0.3.5:7000": "c",
+	}
+
+	masters, replicaMap, err := calculateClusterLayout(nodes, 1, zones)
+	if err != nil {
+		t.Fatalf("calculateClusterLayout() error = %v", err)
+	}
+
+	masterZones := make(map[string]bool)
+	for _, m := range masters {
+		masterZones[nodeZone(zones, m)] = true
+	}
+	if len(masterZones) != 3 {
+		t.Errorf("masters in %d zones, want 3 (%v)", len(masterZones), masters)
+	}
+
+	for replica, master := range replicaMap {
+		if nodeZone(zones, replica) == nodeZone(zones, master) {
+			t.Errorf("replica %s is in the same zone as master %s", replica, master)
+		}
+	}
+	if warnings := layoutAntiAffinityWarnings(masters, replicaMap, zones); len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	zoneOf := func(n string) string { return nodeZone(zones, n) }
+	if _, tolerated, weak := faultTolerance(masters, replicaMap, zoneOf); tolerated != 1 {
+		t.Errorf("zone fault tolerance = %d (weak %v), want 1", tolerated, weak)
+	}
+}
+
+// TestZoneOutageRisks tests that check flags shards living entirely in one zone
+func TestZoneOutageRisks(t *testing.T) {
+	topology := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-5460
+bbbb 10.0.2.1:7001@17001 master - 0 0 2 connected 5461-10922
+cccc 10.0.3.1:7001@17001 master - 0 0 3 connected 10923-16383
+dddd 10.0.1.2:7001@17001 slave aaaa 0 0 1 connected
+eeee 10.0.3.2:7001@17001 slave bbbb 0 0 2 connected
+ffff 10.0.1.3:7001@17001 slave cccc 0 0 3 connected`)
+
+	zones := map[string]string{
+		"10.0.1.1:7001": "a", "10.0.1.2:7001": "a", "10.0.1.3:7001": "a",
+		"10.0.2.1:7001": "b",
+		"10.0.3.1:7001": "c", "10.0.3.2:7001": "c",
+	}
+
+	risks := zoneOutageRisks(topology, zones)
+	if len(risks) != 1 || !strings.Contains(risks[0], "10.0.1.1:7001") {
+		t.Errorf("zoneOutageRisks() = %q, want one risk for the shard of 10.0.1.1:7001", risks)
+	}
+
+	if risks := zoneOutageRisks(topology, nil); len(risks) != 0 {
+		t.Errorf("zoneOutageRisks() without labels = %q, want none", risks)
+	}
+}
+
+// TestDistributeSlots tests that slots are split into contiguous ranges covering every slot
+func TestDistributeSlots(t *testing.T) {
+	for _, masters := range []int{3, 5, 7, 10} {
+		weights := make([]float64, masters)
+		for i := range weights {
+			weights[i] = 1
+		}
+		ranges, err := distributeSlots(weights)
+		if err != nil {
+			t.Fatalf("distributeSlots(%d) error: %v", masters, err)
+		}
+		if len(ranges) != masters {
+			t.Fatalf("distributeSlots(%d) returned %d ranges", masters, len(ranges))
+		}
+
+		next := 0
+		for i, r := range ranges {
+			if r.Start != next {
+				t.Errorf("distributeSlots(%d)[%d] starts at %d, want %d", masters, i, r.Start, next)
+			}
+			if diff := r.Count() - ranges[0].Count(); diff > 0 || diff < -1 {
+				t.Errorf("distributeSlots(%d)[%d] has %d slots, first has %d", masters, i, r.Count(), ranges[0].Count())
+			}
+			next = r.End + 1
+		}
+		if next != redis.ClusterSlots {
+			t.Errorf("distributeSlots(%d) covers %d slots, want %d", masters, next, redis.ClusterSlots)
+		}
+	}
+}
+
+// TestCreatePlanRoundTrip tests that a saved plan loads back to the same layout
+func TestCreatePlanRoundTrip(t *testing.T) {
+	nodes := []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001", "10.0.0.1:7002", "10.0.0.2:7002", "10.0.0.3:7002"}
+	masters, replicaMap, err := calculateClusterLayout(nodes, 1, nil)
+	if err != nil {
+		t.Fatalf("calculateClusterLayout: %v", err)
+	}
+
+	plan, err := newCreatePlan(nodes, 1, masters, replicaMap, map[string]float64{masters[0]: 2})
+	if err != nil {
+		t.Fatalf("newCreatePlan: %v", err)
+	}
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("Validate() of a computed plan: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := plan.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := loadCreatePlan(path)
+	if err != nil {
+		t.Fatalf("loadCreatePlan: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, plan) {
+		t.Errorf("loaded plan = %+v, want %+v", loaded, plan)
+	}
+
+	gotMasters, gotReplicas := loaded.Layout()
+	if !reflect.DeepEqual(gotMasters, masters) || !reflect.DeepEqual(gotReplicas, replicaMap) {
+		t.Errorf("Layout() = %v, %v, want %v, %v", gotMasters, gotReplicas, masters, replicaMap)
+	}
+}
+
+// TestCreatePlanValidate tests that edited plans with missing nodes or slots are rejected
+func TestCreatePlanValidate(t *testing.T) {
+	valid := func() *CreatePlan {
+		return &CreatePlan{
+			Version: createPlanVersion,
+			Nodes:   []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"},
+			Masters: []CreatePlanMaster{
+				{Address: "10.0.0.1:7001", Slots: []PlanSlotRange{{0, 5460}}},
+				{Address: "10.0.0.2:7001", Slots: []PlanSlotRange{{5461, 10922}}},
+				{Address: "10.0.0.3:7001", Slots: []PlanSlotRange{{10923, 16383}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(p *CreatePlan)
+		wantErr bool
+	}{
+		{"valid", func(p *CreatePlan) {}, false},
+		{"split ranges", func(p *CreatePlan) {
+			p.Masters[0].Slots = []PlanSlotRange{{0, 99}, {100, 5460}}
+		}, false},
+		{"unknown version", func(p *CreatePlan) { p.Version = 99 }, true},
+		{"missing slots", func(p *CreatePlan) { p.Masters[2].Slots = []PlanSlotRange{{10923, 16382}} }, true},
+		{"overlapping slots", func(p *CreatePlan) { p.Masters[1].Slots = []PlanSlotRange{{5460, 10922}} }, true},
+		{"slot out of range", func(p *CreatePlan) { p.Masters[2].Slots = []PlanSlotRange{{10923, 16384}} }, true},
+		{"unplaced node", func(p *CreatePlan) { p.Nodes = append(p.Nodes, "10.0.0.4:7001") }, true},
+		{"unknown replica", func(p *CreatePlan) { p.Masters[0].Replicas = []string{"10.0.0.9:7001"} }, true},
+		{"node used twice", func(p *CreatePlan) { p.Masters[0].Replicas = []string{"10.0.0.2:7001"} }, true},
+		{"too few masters", func(p *CreatePlan) {
+			p.Masters = p.Masters[:2]
+			p.Masters[1].Slots = []PlanSlotRange{{5461, 16383}}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := valid()
+			tt.edit(plan)
+			if err := plan.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestDistributeSlotsWeighted tests that slots follow the weights in contiguous ranges
+func TestDistributeSlotsWeighted(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  []float64
+		expected []int
+		wantErr  bool
+	}{
+		{"equal", []float64{1, 1, 1}, []int{5462, 5461, 5461}, false},
+		{"double first", []float64{2, 1, 1}, []int{8192, 4096, 4096}, false},
+		{"double last", []float64{1, 1, 2}, []int{4096, 4096, 8192}, false},
+		{"fractional", []float64{1.5, 1, 1}, []int{7022, 4681, 4681}, false},
+		{"zero weight", []float64{0, 1, 1}, nil, true},
+		{"negative weight", []float64{-1, 1, 1}, nil, true},
+		{"share too small", []float64{1e-6, 1, 1}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := distributeSlots(tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("distributeSlots(%v) error = %v, wantErr %t", tt.weights, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var counts []int
+			next := 0
+			for _, r := range ranges {
+				if r.Start != next {
+					t.Errorf("range %d-%d is not contiguous (expected start %d)", r.Start, r.End, next)
+				}
+				counts = append(counts, r.Count())
+				next = r.End + 1
+			}
+			if !reflect.DeepEqual(counts, tt.expected) {
+				t.Errorf("distributeSlots(%v) = %v, want %v", tt.weights, counts, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseWeights tests parsing of --weight values
+func TestParseWeights(t *testing.T) {
+	weights, err := parseWeights([]string{"localhost:7001=2", "10.0.0.2:7001=0.5"})
+	if err != nil {
+		t.Fatalf("parseWeights: %v", err)
+	}
+	expected := map[string]float64{"127.0.0.1:7001": 2, "10.0.0.2:7001": 0.5}
+	if !reflect.DeepEqual(weights, expected) {
+		t.Errorf("parseWeights() = %v, want %v", weights, expected)
+	}
+
+	for _, value := range []string{"localhost:7001", "localhost:7001=0", "localhost:7001=-1", "localhost:7001=x", "localhost=2"} {
+		if _, err := parseWeights([]string{value}); err == nil {
+			t.Errorf("parseWeights(%q) succeeded, want error", value)
+		}
+	}
+}
+
+// TestCreatePlanWeightsFromFile tests that a plan file without slots is allocated from its weights
+func TestCreatePlanWeightsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	data := `{
+  "version": 1,
+  "nodes": ["10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001"],
+  "masters": [
+    {"address": "10.0.0.1:7001", "weight": 2},
+    {"address": "10.0.0.2:7001"},
+    {"address": "10.0.0.3:7001"}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := loadCreatePlan(path)
+	if err != nil {
+		t.Fatalf("loadCreatePlan: %v", err)
+	}
+	var counts []int
+	for _, shard := range plan.Masters {
+		counts = append(counts, shard.SlotCount())
+	}
+	if !reflect.DeepEqual(counts, []int{8192, 4096, 4096}) {
+		t.Errorf("slot counts = %v, want [8192 4096 4096]", counts)
+	}
+}
+
+// TestNewCreatePlanWeightOnReplica tests that a weight given to a node placed as replica is rejected
+func TestNewCreatePlanWeightOnReplica(t *testing.T) {
+	nodes := []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001", "10.0.0.1:7002", "10.0.0.2:7002", "10.0.0.3:7002"}
+	masters, replicaMap, err := calculateClusterLayout(nodes, 1, nil)
+	if err != nil {
+		t.Fatalf("calculateClusterLayout: %v", err)
+	}
+
+	var replica string
+	for r := range replicaMap {
+		replica = r
+		break
+	}
+	if _, err := newCreatePlan(nodes, 1, masters, replicaMap, map[string]float64{replica: 2}); err == nil {
+		t.Errorf("newCreatePlan() with a weight on replica %s succeeded, want error", replica)
+	}
+	if _, err := newCreatePlan(nodes, 1, masters, replicaMap, map[string]float64{"10.0.0.9:7001": 2}); err == nil {
+		t.Error("newCreatePlan() with a weight on an unknown node succeeded, want error")
+	}
+}
+
+// TestCreateProgress tests that the create progress survives a save/load round trip and is removed on success
+func TestCreateProgress(t *testing.T) {
+	nodes := []string{"10.0.0.1:7001", "10.0.0.2:7001", "10.0.0.3:7001", "10.0.0.1:7002", "10.0.0.2:7002", "10.0.0.3:7002"}
+	masters, replicaMap, err := calculateClusterLayout(nodes, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := newCreatePlan(nodes, 1, masters, replicaMap, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "redisctl", "create-progress.json")
+	if _, err := loadCreateProgress(path); err == nil {
+		t.Fatal("loadCreateProgress() without a record succeeded")
+	}
+
+	progress := newCreateProgress(plan, path)
+	progress.save()
+	progress.markMeet()
+	progress.markSlots(masters[0])
+	progress.markSlots(masters[0])
+	progress.markReplica(planReplicas(plan)[0])
+
+	loaded, err := loadCreateProgress(path)
+	if err != nil {
+		t.Fatalf("loadCreateProgress: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Plan, plan) {
+		t.Errorf("loaded plan = %+v, want %+v", loaded.Plan, plan)
+	}
+	if !loaded.Meet || !reflect.DeepEqual(loaded.Slots, []string{masters[0]}) || len(loaded.Replicas) != 1 {
+		t.Errorf("loaded progress = meet %t, slots %v, replicas %v", loaded.Meet, loaded.Slots, loaded.Replicas)
+	}
+	if want := "핸드셰이크 완료, 슬롯 할당 1/3 마스터, 복제 설정 1/3 복제본"; loaded.summary() != want {
+		t.Errorf("summary() = %q, want %q", loaded.summary(), want)
+	}
+
+	loaded.remove()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("record still exists after remove(): %v", err)
+	}
+}
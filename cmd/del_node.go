@@ -5,22 +5,23 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 
 	"redisctl/internal/config"
+	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 )
 
 // NewDelNodeCommand del-node 명령어
 func NewDelNodeCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "del-node <cluster-node-ip:port> <node-id>",
+		Use:   "del-node [<cluster-node-ip:port[,ip:port...]>] <node-id>",
 		Short: "> Redis 클러스터에서 노드를 제거합니다",
 		Long: styles.TitleStyle.Render("[-] Redis 클러스터 노드 제거") + "\n\n" +
 			styles.DescStyle.Render("Redis 클러스터에서 지정된 노드를 안전하게 제거합니다.") + "\n\n" +
@@ -33,46 +34,43 @@ func NewDelNodeCommand() *cobra.Command {
   redisctl del-node localhost:7001 a1b2c3d4e5f6...
 
   # 마스터 노드 제거 (슬롯 자동 재분배)
-  redisctl del-node localhost:7002 f6e5d4c3b2a1...`,
-		Args: cobra.ExactArgs(2),
+  redisctl del-node localhost:7002 f6e5d4c3b2a1...
+
+  # 시드 노드를 --seed로만 지정
+  redisctl del-node --seed localhost:7001 --seed localhost:7002 f6e5d4c3b2a1...`,
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
-			return runDelNode(args[0], args[1])
+			clusterAddr, rest := splitClusterArgs(args, 2)
+			return runDelNode(cmd.Context(), clusterAddr, rest[0])
 		},
 	}
 
 	return cmd
 }
 
-type NodeInfo struct {
-	ID        string
-	Addr      string
-	IsMaster  bool
-	IsReplica bool
-	MasterID  string
-	Slots     []int
-	Replicas  []string
-}
+func runDelNode(ctx context.Context, clusterAddr, nodeIDToRemove string) error {
+	seeds, err := clusterSeeds(clusterAddr)
+	if err != nil {
+		return err
+	}
 
-func runDelNode(clusterAddr, nodeIDToRemove string) error {
 	fmt.Println(styles.InfoStyle.Render("Redis 클러스터 노드 제거"))
-	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(clusterAddr))
+	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
 	fmt.Printf("제거할 노드 ID: %s\n", styles.HighlightStyle.Render(nodeIDToRemove))
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
 	fmt.Println()
 
 	// 클러스터 연결
-	user, password := config.GetAuth()
-	client := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:    []string{clusterAddr},
-		Username: user,
-		Password: password,
-	})
+	client := redis.NewClusterClient(selection.Addresses()...)
 	defer client.Close()
 
-	ctx := context.Background()
-
 	// Validate cluster connectivity
 	if err := validateDelNodeConnectivity(ctx, client); err != nil {
 		return fmt.Errorf("클러스터 연결 검증 실패: %w", err)
@@ -92,7 +90,7 @@ func runDelNode(clusterAddr, nodeIDToRemove string) error {
 	}
 
 	// 슬롯이 있는 마스터인지 체크
-	if nodeInfo.IsMaster && len(nodeInfo.Slots) > 0 {
+	if nodeInfo.IsMaster() && len(nodeInfo.Slots) > 0 {
 		// 재분배를 위해 충분한 마스터가 있는지 검증
 		masters, err := getOtherMasters(ctx, client, nodeIDToRemove)
 		if err != nil {
@@ -112,7 +110,7 @@ func runDelNode(clusterAddr, nodeIDToRemove string) error {
 		if err := reshardBeforeRemoval(ctx, client, nodeInfo); err != nil {
 			return fmt.Errorf("슬롯 재분배 실패: %w", err)
 		}
-	} else if nodeInfo.IsMaster {
+	} else if nodeInfo.IsMaster() {
 		// 슬롯 없는 마스터도 최소 마스터 수 검증 필요
 		masters, err := getOtherMasters(ctx, client, nodeIDToRemove)
 		if err != nil {
@@ -126,6 +124,11 @@ func runDelNode(clusterAddr, nodeIDToRemove string) error {
 		fmt.Println(styles.InfoStyle.Render("ℹ슬롯이 없는 마스터 노드입니다. 바로 제거합니다."))
 	}
 
+	// 슬롯 재분배 직후 중단 요청이 들어왔다면 노드는 클러스터에 남겨둔다
+	if ctx.Err() != nil {
+		return ErrInterrupted
+	}
+
 	// 클러스터에서 노드 제거
 	if err := removeNodeFromCluster(ctx, client, nodeIDToRemove); err != nil {
 		return fmt.Errorf("노드 제거 실패: %w", err)
@@ -141,7 +144,7 @@ func runDelNode(clusterAddr, nodeIDToRemove string) error {
 	return nil
 }
 
-func validateDelNodeConnectivity(ctx context.Context, client *redis.ClusterClient) error {
+func validateDelNodeConnectivity(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("1. 클러스터 연결 확인..."))
 
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -153,87 +156,41 @@ func validateDelNodeConnectivity(ctx context.Context, client *redis.ClusterClien
 	return nil
 }
 
-func getDelNodeInfo(ctx context.Context, client *redis.ClusterClient, nodeID string) (*NodeInfo, error) {
+func getDelNodeInfo(ctx context.Context, client *redisv9.ClusterClient, nodeID string) (*redis.ClusterNode, error) {
 	fmt.Print(styles.InfoStyle.Render("2. 노드 정보 조회..."))
 
-	// 클러스터 노드 정보 가져오기
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
+	// 클러스터 토폴로지 가져오기 (CLUSTER SHARDS 우선)
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
 		fmt.Println(styles.ErrorStyle.Render(" 실패"))
-		return nil, result.Err()
+		return nil, err
 	}
 
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) < 8 {
-			continue
-		}
-
-		currentNodeID := parts[0]
-		if currentNodeID == nodeID {
-			nodeInfo := &NodeInfo{
-				ID:   currentNodeID,
-				Addr: parts[1],
-			}
-
-			// Parse flags using unified utility
-			nodeFlags := parseNodeFlagsSlice(strings.Split(parts[2], ","))
-			nodeInfo.IsMaster = nodeFlags.IsMaster
-			nodeInfo.IsReplica = nodeFlags.IsReplica
-
-			if nodeInfo.IsReplica && len(parts) > 3 && parts[3] != "-" {
-				nodeInfo.MasterID = parts[3]
-			}
-
-			// 슬롯 파싱
-			if len(parts) > 8 {
-				for i := 8; i < len(parts); i++ {
-					slotRange := parts[i]
-					if strings.Contains(slotRange, "-") {
-						rangeParts := strings.Split(slotRange, "-")
-						if len(rangeParts) == 2 {
-							start, err1 := strconv.Atoi(rangeParts[0])
-							end, err2 := strconv.Atoi(rangeParts[1])
-							if err1 == nil && err2 == nil {
-								for slot := start; slot <= end; slot++ {
-									nodeInfo.Slots = append(nodeInfo.Slots, slot)
-								}
-							}
-						}
-					} else {
-						if slot, err := strconv.Atoi(slotRange); err == nil {
-							nodeInfo.Slots = append(nodeInfo.Slots, slot)
-						}
-					}
-				}
-			}
-
-			fmt.Println(styles.SuccessStyle.Render(" 완료"))
-			fmt.Printf("  노드 타입: %s\n", nodeTypeString(nodeInfo))
-			if len(nodeInfo.Slots) > 0 {
-				fmt.Printf("  할당된 슬롯: %d개\n", len(nodeInfo.Slots))
-			}
-			return nodeInfo, nil
-		}
+	node, ok := topology.NodeByID(nodeID)
+	if !ok {
+		fmt.Println(styles.ErrorStyle.Render(" 실패"))
+		return nil, fmt.Errorf("노드 ID '%s'를 찾을 수 없습니다", nodeID)
 	}
 
-	fmt.Println(styles.ErrorStyle.Render(" 실패"))
-	return nil, fmt.Errorf("노드 ID '%s'를 찾을 수 없습니다", nodeID)
+	fmt.Println(styles.SuccessStyle.Render(" 완료"))
+	fmt.Printf("  노드 타입: %s\n", nodeTypeString(node))
+	if len(node.Slots) > 0 {
+		fmt.Printf("  할당된 슬롯: %d개\n", node.SlotCount())
+	}
+	if node.Health != "" && node.Health != redis.HealthOnline {
+		fmt.Printf("  노드 상태: %s\n", styles.WarningStyle.Render(node.Health))
+	}
+	return &node, nil
 }
 
-func nodeTypeString(nodeInfo *NodeInfo) string {
-	if nodeInfo.IsMaster {
+func nodeTypeString(node redis.ClusterNode) string {
+	if node.IsMaster() {
 		return styles.HighlightStyle.Render("마스터")
 	}
 	return styles.HighlightStyle.Render("레플리카")
 }
 
-func reshardBeforeRemoval(ctx context.Context, client *redis.ClusterClient, nodeInfo *NodeInfo) error {
+func reshardBeforeRemoval(ctx context.Context, client *redisv9.ClusterClient, nodeInfo *redis.ClusterNode) error {
 	fmt.Print(styles.InfoStyle.Render("3. 슬롯 재분배 중..."))
 
 	// 다른 마스터 노드들 가져오기
@@ -249,8 +206,9 @@ func reshardBeforeRemoval(ctx context.Context, client *redis.ClusterClient, node
 	}
 
 	// 다른 마스터들에게 슬롯을 고르게 분배 (롤백 로직 추가)
-	slotsPerMaster := len(nodeInfo.Slots) / len(masters)
-	remainder := len(nodeInfo.Slots) % len(masters)
+	nodeSlots := nodeInfo.SlotList()
+	slotsPerMaster := len(nodeSlots) / len(masters)
+	remainder := len(nodeSlots) % len(masters)
 
 	slotIndex := 0
 	var migratedSlots []int // 롤백을 위한 추적
@@ -264,16 +222,22 @@ func reshardBeforeRemoval(ctx context.Context, client *redis.ClusterClient, node
 		if slotsToMove > 0 {
 			startSlot := slotIndex
 			endSlot := slotIndex + slotsToMove - 1
-			slotsToMigrate := nodeInfo.Slots[startSlot : endSlot+1]
+			slotsToMigrate := nodeSlots[startSlot : endSlot+1]
 
-			if err := moveSlots(ctx, client, nodeInfo.ID, masterID, slotsToMigrate); err != nil {
+			moved, err := moveSlots(ctx, client, nodeInfo.ID, masterID, slotsToMigrate)
+			migratedSlots = append(migratedSlots, moved...)
+			if errors.Is(err, ErrInterrupted) {
+				// 노드에 슬롯이 남아 있으므로 제거 단계로 넘어가지 않는다
+				displayInterruptSummary(ctx, interruptTopology(ctx, client), migratedSlots, len(nodeSlots))
+				return err
+			}
+			if err != nil {
 				fmt.Println(styles.ErrorStyle.Render(" 실패"))
 				// 롤백 수행
 				rollbackSlotMigration(ctx, client, nodeInfo.ID, migratedSlots)
 				return fmt.Errorf("슬롯 이동 실패 (대상: %s): %w", masterID, err)
 			}
 
-			migratedSlots = append(migratedSlots, slotsToMigrate...)
 			slotIndex += slotsToMove
 		}
 	}
@@ -282,86 +246,90 @@ func reshardBeforeRemoval(ctx context.Context, client *redis.ClusterClient, node
 	return nil
 }
 
-func getOtherMasters(ctx context.Context, client *redis.ClusterClient, excludeNodeID string) ([]string, error) {
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
-		return nil, result.Err()
+func getOtherMasters(ctx context.Context, client *redisv9.ClusterClient, excludeNodeID string) ([]string, error) {
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
+		return nil, err
 	}
 
 	var masters []string
-	lines := strings.Split(result.Val(), "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) < 3 {
-			continue
-		}
-
-		nodeID := parts[0]
-
-		if nodeID != excludeNodeID {
-			nodeFlags := parseNodeFlagsSlice(strings.Split(parts[2], ","))
-			if nodeFlags.IsMaster {
-				masters = append(masters, nodeID)
-			}
+	for _, node := range topology.Masters() {
+		if node.ID != excludeNodeID {
+			masters = append(masters, node.ID)
 		}
 	}
 
 	return masters, nil
 }
 
-func moveSlots(ctx context.Context, client *redis.ClusterClient, sourceNodeID, targetNodeID string, slots []int) error {
+// moveSlots moves slots one by one and returns the slots that were fully moved.
+// On interrupt the slot in flight is finished first and ErrInterrupted is returned.
+func moveSlots(ctx context.Context, client *redisv9.ClusterClient, sourceNodeID, targetNodeID string, slots []int) ([]int, error) {
 	// 소스와 타겟 노드 주소 가져오기
-	sourceAddr, err := getNodeAddress(ctx, client, sourceNodeID)
+	topology, err := redis.LoadClusterTopology(ctx, client)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	targetAddr, err := getNodeAddress(ctx, client, targetNodeID)
-	if err != nil {
-		return err
+	sourceNode, ok := topology.NodeByID(sourceNodeID)
+	if !ok {
+		return nil, fmt.Errorf("노드 %s의 주소를 찾을 수 없습니다", sourceNodeID)
 	}
+	targetNode, ok := topology.NodeByID(targetNodeID)
+	if !ok {
+		return nil, fmt.Errorf("노드 %s의 주소를 찾을 수 없습니다", targetNodeID)
+	}
+	sourceAddr := sourceNode.Endpoint()
+	targetAddr := targetNode.Endpoint()
 
-	user, password := config.GetAuth()
+	// MIGRATE AUTH2에는 대상 노드가 실제로 받아들이는 인증 정보를 사용
+	targetCred := redis.CredentialFor(targetAddr)
 
 	// 소스 노드에 연결
-	sourceClient := redis.NewClient(&redis.Options{
-		Addr:     sourceAddr,
-		Username: user,
-		Password: password,
-	})
+	sourceClient := redis.NewNodeClient(sourceAddr)
 	defer sourceClient.Close()
 
 	// 타겟 노드에 연결
-	targetClient := redis.NewClient(&redis.Options{
-		Addr:     targetAddr,
-		Username: user,
-		Password: password,
-	})
+	targetClient := redis.NewNodeClient(targetAddr)
 	defer targetClient.Close()
 
+	if err := verifyMigrateTransport(ctx, sourceClient); err != nil {
+		return nil, err
+	}
+
 	// MIGRATE 명령을 위한 타겟 주소 파싱
-	targetParts := strings.Split(targetAddr, ":")
-	if len(targetParts) != 2 {
-		return fmt.Errorf("잘못된 대상 노드 주소: %s", targetAddr)
+	targetHost, targetPort, err := parseNodeAddress(targetAddr)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 대상 노드 주소: %s", targetAddr)
 	}
-	targetHost := targetParts[0]
-	targetPort := targetParts[1]
 
 	// 진행률 표시를 위한 초기화
 	totalSlots := len(slots)
 	fmt.Printf("    %d개 슬롯 마이그레이션 시작...\n", totalSlots)
 
 	// MIGRATE 명령어는 소스 노드에서 실행되어 타겟으로 직접 전송
-	// MIGRATE targetHost targetPort key 0 60000 [AUTH password]
+	// MIGRATE targetHost targetPort key 0 <timeout> [AUTH password]
 
 	// 순차 처리하되 배치 크기와 대기 시간 최적화
 	// TODO: goroutine으로 처리해버리면 클러스터 뷰 불일치? ASK 리다렉 폭증?
+	var moved []int
+
+	// 실패한 슬롯은 열린 채로 두지 않는다: 되돌리거나 마무리한 뒤 양쪽 노드에서 확인
+	failSlot := func(slot int, err error) ([]int, error) {
+		owner, closeErr := closeFailedSlot(context.WithoutCancel(ctx), sourceClient, targetClient, slot, sourceNodeID, targetNodeID)
+		if closeErr == nil && owner == targetNodeID {
+			moved = append(moved, slot)
+		}
+		return moved, failedSlotError(slot, err, closeErr)
+	}
+
 	for i, slot := range slots {
+		// 중단 요청은 슬롯 사이에서만 처리
+		if ctx.Err() != nil {
+			return moved, ErrInterrupted
+		}
+		slotCtx := context.WithoutCancel(ctx)
+
 		// 진행률 표시 (매 10%마다)
 		if i > 0 && (i*10/totalSlots) > ((i-1)*10/totalSlots) {
 			progress := (i * 100) / totalSlots
@@ -369,36 +337,38 @@ func moveSlots(ctx context.Context, client *redis.ClusterClient, sourceNodeID, t
 		}
 
 		// 타겟에서 슬롯 가져오기 설정
-		if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "IMPORTING", sourceNodeID).Err(); err != nil {
-			return fmt.Errorf("슬롯 %d 가져오기 설정 실패: %w", slot, err)
+		if err := targetClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "IMPORTING", sourceNodeID).Err(); err != nil {
+			return failSlot(slot, fmt.Errorf("슬롯 %d 가져오기 설정 실패: %w", slot, err))
 		}
 
 		// 소스에서 슬롯 이주 설정
-		if err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "MIGRATING", targetNodeID).Err(); err != nil {
-			return fmt.Errorf("슬롯 %d 이주 설정 실패: %w", slot, err)
+		if err := sourceClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "MIGRATING", targetNodeID).Err(); err != nil {
+			return failSlot(slot, fmt.Errorf("슬롯 %d 이주 설정 실패: %w", slot, err))
 		}
 
 		// 키 마이그레이션 - 배치 크기 증가로 효율성 향상
 		batchSize := 500 // 프로덕션 환경에서 안전한 크기
-		if err := migrateSlotsKeysWithBatching(ctx, sourceClient, slot, targetHost, targetPort, user, password, batchSize); err != nil {
-			return fmt.Errorf("슬롯 %d 키 마이그레이션 실패: %w", slot, err)
+		if err := migrateSlotsKeysWithBatching(slotCtx, sourceClient, slot, targetHost, targetPort, targetCred.User, targetCred.Password, batchSize); err != nil {
+			return failSlot(slot, fmt.Errorf("슬롯 %d 키 마이그레이션 실패: %w", slot, err))
 		}
 
 		// 양쪽 노드에서 슬롯 안정화
-		if err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
-			return fmt.Errorf("슬롯 %d 소스 노드 안정화 실패: %w", slot, err)
+		if err := sourceClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
+			return failSlot(slot, fmt.Errorf("슬롯 %d 소스 노드 안정화 실패: %w", slot, err))
 		}
 
-		if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
-			return fmt.Errorf("슬롯 %d 대상 노드 안정화 실패: %w", slot, err)
+		if err := targetClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
+			return failSlot(slot, fmt.Errorf("슬롯 %d 대상 노드 안정화 실패: %w", slot, err))
 		}
 
 		// 일관성을 위해 클러스터의 모든 노드에 새로운 슬롯 소유권 업데이트
-		err = updateAllNodesSlotOwnershipForDelNode(ctx, client, slot, targetNodeID)
+		err = updateAllNodesSlotOwnershipForDelNode(slotCtx, client, slot, targetNodeID)
 		if err != nil {
-			return fmt.Errorf("슬롯 %d 클러스터 전체 업데이트 실패: %w", slot, err)
+			return failSlot(slot, fmt.Errorf("슬롯 %d 클러스터 전체 업데이트 실패: %w", slot, err))
 		}
 
+		moved = append(moved, slot)
+
 		// CPU 부하 분산을 위한 미세 대기
 		// 매 슬롯마다 소량 대기로 시스템 부하 분산
 		if i > 0 && i%50 == 0 { // 매 50슬롯마다 더 긴 대기
@@ -409,14 +379,22 @@ func moveSlots(ctx context.Context, client *redis.ClusterClient, sourceNodeID, t
 	}
 
 	fmt.Printf("    마이그레이션 완료: %d개 슬롯\n", totalSlots)
-	return nil
+	return moved, nil
 }
 
 // 키 마이그레이션 함수 - 배치 처리 최적화
-func migrateSlotsKeysWithBatching(ctx context.Context, sourceClient *redis.Client, slot int, targetHost, targetPort, user, password string, batchSize int) error {
+func migrateSlotsKeysWithBatching(ctx context.Context, sourceClient *redisv9.Client, slot int, targetHost, targetPort, user, password string, batchSize int) error {
+	// 일시적 오류(IOERR, TRYAGAIN, 네트워크 등)는 재시도 정책에 따라 재시도
+	policy := redis.DefaultRetryPolicy()
+
 	for {
 		// 슬롯의 키들 가져오기 (배치 크기 증가)
-		keys, err := sourceClient.ClusterGetKeysInSlot(ctx, slot, batchSize).Result()
+		var keys []string
+		err := policy.Do(ctx, func() error {
+			var err error
+			keys, err = sourceClient.ClusterGetKeysInSlot(ctx, slot, batchSize).Result()
+			return err
+		})
 		if err != nil {
 			return fmt.Errorf("키 조회 실패: %w", err)
 		}
@@ -430,28 +408,11 @@ func migrateSlotsKeysWithBatching(ctx context.Context, sourceClient *redis.Clien
 		for _, key := range keys {
 			migrateCmd := buildMigrateCommand(targetHost, targetPort, key, user, password)
 
-			// 재시도 로직 추가
-			maxRetries := 3
-			var migrateErr error
-
-			for retry := 0; retry < maxRetries; retry++ {
-				migrateErr = sourceClient.Do(ctx, migrateCmd...).Err()
-				if migrateErr == nil {
-					break // 성공
-				}
-
-				// 네트워크 오류나 일시적 오류인 경우 재시도
-				if strings.Contains(migrateErr.Error(), "timeout") ||
-					strings.Contains(migrateErr.Error(), "connection") {
-					time.Sleep(time.Duration(retry+1) * 100 * time.Millisecond)
-					continue
-				}
-
-				break // 재시도 불가능한 오류
-			}
-
-			if migrateErr != nil {
-				return fmt.Errorf("키 '%s' 마이그레이션 실패 (재시도 %d회): %w", key, maxRetries, migrateErr)
+			err := migrateWithRetry(ctx, policy, migrateCmd, false, func(args ...any) error {
+				return sourceClient.Do(ctx, args...).Err()
+			})
+			if err != nil {
+				return fmt.Errorf("키 '%s' 마이그레이션 실패: %w", key, err)
 			}
 		}
 
@@ -462,33 +423,7 @@ func migrateSlotsKeysWithBatching(ctx context.Context, sourceClient *redis.Clien
 	return nil
 }
 
-func getNodeAddress(ctx context.Context, client *redis.ClusterClient, nodeID string) (string, error) {
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
-		return "", result.Err()
-	}
-
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) >= 2 && parts[0] == nodeID {
-			addr := parts[1]
-			// @cluster_port가 있으면 제거
-			if strings.Contains(addr, "@") {
-				addr = strings.Split(addr, "@")[0]
-			}
-			return addr, nil
-		}
-	}
-
-	return "", fmt.Errorf("노드 %s의 주소를 찾을 수 없습니다", nodeID)
-}
-
-func removeNodeFromCluster(ctx context.Context, client *redis.ClusterClient, nodeID string) error {
+func removeNodeFromCluster(ctx context.Context, client *redisv9.ClusterClient, nodeID string) error {
 	fmt.Print(styles.InfoStyle.Render("4. 클러스터에서 노드 제거..."))
 
 	// 일관성을 위해 모든 노드에 CLUSTER FORGET 전송
@@ -513,47 +448,26 @@ func removeNodeFromCluster(ctx context.Context, client *redis.ClusterClient, nod
 }
 
 // forgetNodeFromAllNodes 일관성을 위해 클러스터의 모든 노드에 CLUSTER FORGET 전송
-func forgetNodeFromAllNodes(ctx context.Context, client *redis.ClusterClient, nodeIDToRemove string) error {
+func forgetNodeFromAllNodes(ctx context.Context, client *redisv9.ClusterClient, nodeIDToRemove string) error {
 	// 모든 클러스터 노드 가져오기
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
-		return fmt.Errorf("클러스터 노드 목록 조회 실패: %w", result.Err())
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
+		return fmt.Errorf("클러스터 노드 목록 조회 실패: %w", err)
 	}
 
 	var errors []string
 	successCount := 0
 
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			continue
-		}
-
-		nodeID := parts[0]
-		nodeAddr := parts[1]
-
+	for _, node := range topology.Nodes {
 		// 제거되는 노드는 건너뛰기 (자기 자신을 forget할 수 없음)
-		if nodeID == nodeIDToRemove {
+		if node.ID == nodeIDToRemove {
 			continue
 		}
 
-		// 주소 정규화
-		if strings.Contains(nodeAddr, "@") {
-			nodeAddr = strings.Split(nodeAddr, "@")[0]
-		}
+		nodeAddr := node.Endpoint()
 
 		// 개별 노드에 연결해서 CLUSTER FORGET 전송
-		user, password := config.GetAuth()
-		nodeClient := redis.NewClient(&redis.Options{
-			Addr:     nodeAddr,
-			Username: user,
-			Password: password,
-		})
+		nodeClient := redis.NewNodeClient(nodeAddr)
 
 		err := nodeClient.ClusterForget(ctx, nodeIDToRemove).Err()
 		nodeClient.Close()
@@ -582,46 +496,27 @@ func forgetNodeFromAllNodes(ctx context.Context, client *redis.ClusterClient, no
 	return nil
 }
 
-func validateRemoval(ctx context.Context, client *redis.ClusterClient, nodeID string) error {
+func validateRemoval(ctx context.Context, client *redisv9.ClusterClient, nodeID string) error {
 	fmt.Print(styles.InfoStyle.Render("5. 노드 제거 확인..."))
 
 	// 노드가 여전히 클러스터에 있는지 체크
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
 		fmt.Println(styles.ErrorStyle.Render(" 실패"))
-		return result.Err()
+		return err
 	}
 
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) > 0 && parts[0] == nodeID {
-			fmt.Println(styles.ErrorStyle.Render(" 실패"))
-			return fmt.Errorf("노드가 여전히 클러스터에 존재합니다")
-		}
+	if _, ok := topology.NodeByID(nodeID); ok {
+		fmt.Println(styles.ErrorStyle.Render(" 실패"))
+		return fmt.Errorf("노드가 여전히 클러스터에 존재합니다")
 	}
 
 	fmt.Println(styles.SuccessStyle.Render(" 완료"))
 	return nil
 }
 
-func validateNodeReachability(ctx context.Context, nodeInfo *NodeInfo) error {
-	// 클러스터 포트 없이 주소 추출
-	addr := nodeInfo.Addr
-	if strings.Contains(addr, "@") {
-		addr = strings.Split(addr, "@")[0]
-	}
-
-	user, password := config.GetAuth()
-	nodeClient := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Username: user,
-		Password: password,
-	})
+func validateNodeReachability(ctx context.Context, nodeInfo *redis.ClusterNode) error {
+	nodeClient := redis.NewNodeClient(nodeInfo.Endpoint())
 	defer nodeClient.Close()
 
 	// 노드 핑 시도
@@ -629,7 +524,7 @@ func validateNodeReachability(ctx context.Context, nodeInfo *NodeInfo) error {
 }
 
 // 동적 클러스터 안정화 대기 (ClusterClient용)
-func waitForClusterStableSimple(ctx context.Context, client *redis.ClusterClient, maxWait time.Duration) error {
+func waitForClusterStableSimple(ctx context.Context, client *redisv9.ClusterClient, maxWait time.Duration) error {
 	start := time.Now()
 	for time.Since(start) < maxWait {
 		result := client.ClusterInfo(ctx)
@@ -639,29 +534,88 @@ func waitForClusterStableSimple(ctx context.Context, client *redis.ClusterClient
 				return nil
 			}
 		}
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
 	}
 	return fmt.Errorf("클러스터가 %v 내에 안정화되지 않았습니다", maxWait)
 }
 
 // 슬롯 재분배 롤백 함수
-func rollbackSlotMigration(ctx context.Context, client *redis.ClusterClient, sourceNodeID string, migratedSlots []int) {
+func rollbackSlotMigration(ctx context.Context, client *redisv9.ClusterClient, sourceNodeID string, migratedSlots []int) {
+	fmt.Println(styles.WarningStyle.Render(" 슬롯 재분배 실패 - 롤백 중..."))
+	ctx = context.WithoutCancel(ctx)
+
+	// moveSlots가 실패한 슬롯을 정리하지만, 정리 자체가 실패했을 수 있으므로
+	// 이 노드에서 나가던 슬롯 중 아직 열린 슬롯을 다시 닫는다
+	fmt.Print("  열린 슬롯 확인 중...")
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	var open []redis.OpenSlot
+	if err == nil {
+		open, err = redis.CollectOpenSlots(ctx, topology)
+	}
+	if err != nil {
+		fmt.Printf(" %s\n", styles.RenderWarning(fmt.Sprintf("실패 (%v)", err)))
+		fmt.Println("  'redisctl check'로 열린 슬롯을 확인하고 'redisctl fix <노드>'로 복구하세요")
+	} else {
+		var pending []redis.SlotMigration
+		for _, m := range redis.GroupOpenSlots(open) {
+			if m.SourceID == sourceNodeID && m.TargetID != "" {
+				pending = append(pending, m)
+			}
+		}
+		if len(pending) == 0 {
+			fmt.Printf(" %s\n", styles.RenderSuccess("열린 슬롯 없음"))
+		} else {
+			fmt.Printf(" %s\n", styles.RenderWarning(fmt.Sprintf("%d개", len(pending))))
+		}
+		for _, m := range pending {
+			fmt.Printf("  슬롯 %d 정리 중...", m.Slot)
+			owner, err := closeOpenSlot(ctx, topology, m)
+			switch {
+			case err != nil:
+				fmt.Printf(" %s\n", styles.RenderError(fmt.Sprintf("실패 (%v)", err)))
+				fmt.Println("  'redisctl fix <노드>'로 열린 슬롯을 복구하세요")
+			case owner == m.TargetID:
+				fmt.Printf(" %s\n", styles.RenderSuccess("대상 노드로 이동 마무리"))
+				migratedSlots = append(migratedSlots, m.Slot)
+			default:
+				fmt.Printf(" %s\n", styles.RenderSuccess("소스 노드로 되돌림"))
+			}
+		}
+	}
+
 	if len(migratedSlots) == 0 {
 		return
 	}
 
-	fmt.Println(styles.WarningStyle.Render(" 슬롯 재분배 실패 - 롤백 중..."))
-
-	// 이미 이동된 슬롯들을 원래 소스 노드로 되돌리기
-	// 일단 경고만 출력
-	// TODO: 수동으로 맞길지 자동으로 할지
-	fmt.Printf("  경고: %d개 슬롯이 부분적으로 이동되었습니다\n", len(migratedSlots))
+	// 이미 이동된 슬롯은 되돌리지 않는다 (다시 실행하면 남은 슬롯만 이동)
+	fmt.Printf("  경고: %d개 슬롯이 부분적으로 이동되었습니다 (%s)\n", len(migratedSlots), formatSlotRanges(migratedSlots))
 	fmt.Println("  수동으로 클러스터 상태를 확인하고 필요시 슬롯을 재조정하세요")
 }
 
+// closeOpenSlot connects to both ends of an open slot and closes it with closeFailedSlot
+func closeOpenSlot(ctx context.Context, topology *redis.Topology, m redis.SlotMigration) (string, error) {
+	source, ok := topology.NodeByID(m.SourceID)
+	if !ok {
+		return "", fmt.Errorf("노드 %s를 찾을 수 없습니다", m.SourceID)
+	}
+	target, ok := topology.NodeByID(m.TargetID)
+	if !ok {
+		return "", fmt.Errorf("노드 %s를 찾을 수 없습니다", m.TargetID)
+	}
+
+	sourceClient := redis.NewNodeClient(source.Endpoint())
+	defer sourceClient.Close()
+	targetClient := redis.NewNodeClient(target.Endpoint())
+	defer targetClient.Close()
+
+	return closeFailedSlot(ctx, sourceClient, targetClient, m.Slot, m.SourceID, m.TargetID)
+}
+
 // MIGRATE 명령어 통합 구성 함수
 func buildMigrateCommand(targetHost, targetPort, key, user, password string) []interface{} {
-	baseCmd := []interface{}{"MIGRATE", targetHost, targetPort, key, 0, 60000}
+	baseCmd := []interface{}{"MIGRATE", targetHost, targetPort, key, 0, redis.MigrateTimeout().Milliseconds()}
 
 	if user != "" {
 		return append(baseCmd, "AUTH2", user, password)
@@ -671,41 +625,21 @@ func buildMigrateCommand(targetHost, targetPort, key, user, password string) []i
 
 	return baseCmd
 }
-func updateAllNodesSlotOwnershipForDelNode(ctx context.Context, client *redis.ClusterClient, slot int, targetNodeID string) error {
+func updateAllNodesSlotOwnershipForDelNode(ctx context.Context, client *redisv9.ClusterClient, slot int, targetNodeID string) error {
 	// 모든 클러스터 노드 가져오기
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
-		return fmt.Errorf("클러스터 노드 목록 조회 실패: %w", result.Err())
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
+		return fmt.Errorf("클러스터 노드 목록 조회 실패: %w", err)
 	}
 
 	var errors []string
 	successCount := 0
 
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			continue
-		}
-
-		nodeAddr := parts[1]
-
-		// 주소 정규화
-		if strings.Contains(nodeAddr, "@") {
-			nodeAddr = strings.Split(nodeAddr, "@")[0]
-		}
+	for _, node := range topology.Nodes {
+		nodeAddr := node.Endpoint()
 
 		// 개별 노드에 연결해서 CLUSTER SETSLOT 전송
-		user, password := config.GetAuth()
-		nodeClient := redis.NewClient(&redis.Options{
-			Addr:     nodeAddr,
-			Username: user,
-			Password: password,
-		})
+		nodeClient := redis.NewNodeClient(nodeAddr)
 
 		err := nodeClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err()
 		nodeClient.Close()
@@ -0,0 +1,552 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"redisctl/internal/config"
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+
+	redisv9 "github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+)
+
+// NewFixCommand 'fix' 명령어
+func NewFixCommand() *cobra.Command {
+	var dryRun bool
+	var assignTo string
+	var pipeline int
+
+	cmd := &cobra.Command{
+		Use:   "fix [--dry-run] [--assign-to <master-id>] <cluster-node-ip:port[,ip:port...]>",
+		Short: "! 열린 슬롯과 할당되지 않은 슬롯을 복구합니다",
+		Long: styles.TitleStyle.Render("[F] 클러스터 슬롯 복구") + "\n\n" +
+			styles.DescStyle.Render("중단된 리샤딩으로 MIGRATING/IMPORTING 상태에 남은 슬롯과") + "\n" +
+			styles.DescStyle.Render("어느 마스터에도 할당되지 않은 슬롯을 복구합니다.") + "\n\n" +
+			styles.DescStyle.Render("복구 방식 (키가 실제로 있는 위치 기준):") + "\n" +
+			styles.DescStyle.Render("• 키가 대상 노드에만 있음: 마이그레이션 완료 (대상에 슬롯 할당)") + "\n" +
+			styles.DescStyle.Render("• 키가 양쪽에 나뉘어 있음: 남은 키를 대상으로 옮기고 완료") + "\n" +
+			styles.DescStyle.Render("• 키가 소스에만 있거나 없음: 마이그레이션 되돌리기 (STABLE)") + "\n" +
+			styles.DescStyle.Render("• 할당되지 않은 슬롯: 키를 가진 마스터, 없으면 --assign-to 또는 슬롯이 가장 적은 마스터에 할당"),
+		Example: `  # 복구 계획만 확인
+  redisctl fix --dry-run localhost:7001
+
+  # 열린 슬롯/미할당 슬롯 복구
+  redisctl fix localhost:7001
+
+  # 키가 없는 미할당 슬롯을 특정 마스터에 할당
+  redisctl fix --assign-to <master-node-id> localhost:7001`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.ValidateAuth(); err != nil {
+				return err
+			}
+
+			clusterNode, _ := splitClusterArgs(args, 1)
+			return runFix(cmd.Context(), clusterNode, assignTo, dryRun, pipeline)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "실제 변경 없이 복구 계획만 표시")
+	cmd.Flags().StringVar(&assignTo, "assign-to", "", "키가 없는 미할당 슬롯을 할당할 마스터 노드 ID")
+	cmd.Flags().IntVar(&pipeline, "pipeline", 10, "MIGRATE당 키 수 (기본값: 10)")
+
+	return cmd
+}
+
+// Fix action kinds
+const (
+	fixFinish = "finish" // complete the migration on the target
+	fixRevert = "revert" // close the slot on both ends, owner unchanged
+	fixAssign = "assign" // assign an uncovered slot
+	fixManual = "manual" // cannot be decided automatically
+)
+
+// fixAction is one step of the repair plan
+type fixAction struct {
+	Slot       int
+	Kind       string
+	SourceID   string
+	TargetID   string
+	SourceKeys int64
+	TargetKeys int64
+	Reason     string
+}
+
+func runFix(ctx context.Context, clusterNode, assignTo string, dryRun bool, pipelineSize int) error {
+	seeds, err := clusterSeeds(clusterNode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.InfoStyle.Render("클러스터 슬롯 복구 시작..."))
+	fmt.Printf("클러스터 노드: %s\n", strings.Join(seeds, ", "))
+	if dryRun {
+		fmt.Println(styles.WarningStyle.Render("드라이런 모드: 실제 변경 없이 계획만 표시"))
+	}
+
+	if pipelineSize <= 0 {
+		pipelineSize = 10
+	}
+
+	user, password := config.GetAuth()
+	cm := redis.NewClusterManager(ctx, user, password)
+	defer cm.Close()
+
+	// Step 1: Load topology
+	fmt.Println(styles.InfoStyle.Render("1단계: 클러스터 토폴로지 조회 중..."))
+
+	// 복구 대상 클러스터는 비정상 상태일 수 있으므로 일관되지 않은 시드도 허용
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
+	clusterNode = selection.Address
+
+	fmt.Printf("  %s 연결 중...", clusterNode)
+
+	topology, err := cm.LoadTopology(clusterNode)
+	if err != nil {
+		fmt.Printf(" %s\n", styles.RenderError("실패"))
+		return fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+	fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
+
+	if assignTo != "" {
+		if node, ok := topology.NodeByID(assignTo); !ok || !node.IsMaster() {
+			return fmt.Errorf("--assign-to %s는 존재하지 않거나 마스터 노드가 아닙니다", assignTo)
+		}
+	}
+
+	// Step 2: Build the plan
+	fmt.Println(styles.InfoStyle.Render("2단계: 열린 슬롯 및 미할당 슬롯 분석 중..."))
+
+	openSlots, err := redis.CollectOpenSlots(ctx, topology)
+	if err != nil {
+		fmt.Printf("  %s\n", styles.RenderWarning(fmt.Sprintf("일부 노드에서 열린 슬롯을 확인하지 못했습니다: %v", err)))
+	}
+
+	migrations := redis.GroupOpenSlots(openSlots)
+	keys, err := countFixSlotKeys(ctx, cm, topology, migrations)
+	if err != nil {
+		fmt.Printf("  %s\n", styles.RenderWarning(fmt.Sprintf("일부 노드의 키 수를 확인하지 못했습니다 (해당 슬롯은 수동 확인): %v", err)))
+	}
+	plan, err := buildFixPlan(topology, migrations, keys, assignTo)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		fmt.Println(styles.RenderSuccess("복구할 슬롯이 없습니다. 모든 슬롯이 정상입니다."))
+		return nil
+	}
+
+	displayFixPlan(topology, plan)
+
+	if dryRun {
+		fmt.Println()
+		fmt.Println(styles.InfoStyle.Render("실제 복구를 수행하려면 --dry-run 플래그를 제거하세요"))
+		return nil
+	}
+
+	// Step 3: Execute
+	fmt.Println(styles.InfoStyle.Render("3단계: 복구 실행 중..."))
+
+	var fixed, skipped, failed []int
+	interrupted := false
+	for i, action := range plan {
+		// 중단 요청은 슬롯 사이에서만 처리 (진행 중인 슬롯은 끝까지 복구)
+		if ctx.Err() != nil {
+			interrupted = true
+			break
+		}
+
+		fmt.Printf("  [%d/%d] 슬롯 %d %s...", i+1, len(plan), action.Slot, fixActionLabel(action.Kind))
+
+		if action.Kind == fixManual {
+			fmt.Printf(" %s\n", styles.RenderWarning("건너뜀 (수동 확인 필요)"))
+			skipped = append(skipped, action.Slot)
+			continue
+		}
+
+		slotCtx := context.WithoutCancel(ctx)
+		if err := executeFixAction(slotCtx, cm.WithContext(slotCtx), clusterNode, topology, action, pipelineSize); err != nil {
+			fmt.Printf(" %s\n", styles.RenderError("실패"))
+			fmt.Printf("    %v\n", err)
+			failed = append(failed, action.Slot)
+			continue
+		}
+
+		fmt.Printf(" %s\n", styles.RenderSuccess("완료"))
+		fixed = append(fixed, action.Slot)
+	}
+
+	fmt.Println()
+	summary := styles.SubtitleStyle.Render("복구 요약") + "\n" +
+		fmt.Sprintf("• 복구된 슬롯: %d개 (%s)\n", len(fixed), formatSlotRanges(fixed)) +
+		fmt.Sprintf("• 건너뛴 슬롯: %d개 (%s)\n", len(skipped), formatSlotRanges(skipped)) +
+		fmt.Sprintf("• 실패한 슬롯: %d개 (%s)\n", len(failed), formatSlotRanges(failed))
+	if interrupted {
+		remaining := len(plan) - len(fixed) - len(skipped) - len(failed)
+		summary += fmt.Sprintf("• 중단으로 남은 슬롯: %d개 (다시 'fix'를 실행하세요)\n", remaining)
+	}
+	fmt.Println(styles.BoxStyle.Render(summary))
+
+	if interrupted {
+		return ErrInterrupted
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d개 슬롯 복구 실패", len(failed))
+	}
+
+	fmt.Println(styles.RenderSuccess("슬롯 복구가 완료되었습니다! 'check' 명령으로 상태를 확인하세요."))
+	return nil
+}
+
+// slotKeyCounts holds CLUSTER COUNTKEYSINSLOT results by node ID and slot.
+// Nodes that could not be asked are missing.
+type slotKeyCounts map[string]map[int]int64
+
+// get returns the key count, or -1 when the node could not be asked
+func (c slotKeyCounts) get(nodeID string, slot int) int64 {
+	counts, ok := c[nodeID]
+	if !ok {
+		return -1
+	}
+	return counts[slot]
+}
+
+// uncoveredSlots returns the slots no master owns in topology
+func uncoveredSlots(topology *redis.Topology) []int {
+	var slots []int
+	for slot := 0; slot < redis.ClusterSlots; slot++ {
+		if _, ok := topology.SlotOwner(slot); !ok {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// countFixSlotKeys counts the keys buildFixPlan needs: both ends of every open slot,
+// and every uncovered slot on every healthy master. Each node is asked once, with
+// one pipelined CLUSTER COUNTKEYSINSLOT per slot. A node with any failed count is
+// left out of the result (unknown) and reported in the returned error.
+func countFixSlotKeys(ctx context.Context, cm *redis.ClusterManager, topology *redis.Topology, migrations []redis.SlotMigration) (slotKeyCounts, error) {
+	slotsByNode := make(map[string][]int)
+	for _, m := range migrations {
+		slotsByNode[m.SourceID] = append(slotsByNode[m.SourceID], m.Slot)
+		slotsByNode[m.TargetID] = append(slotsByNode[m.TargetID], m.Slot)
+	}
+	if uncovered := uncoveredSlots(topology); len(uncovered) > 0 {
+		for _, master := range topology.Masters() {
+			if !master.IsFail() {
+				slotsByNode[master.ID] = append(slotsByNode[master.ID], uncovered...)
+			}
+		}
+	}
+
+	counts := make(slotKeyCounts, len(slotsByNode))
+	var errs []error
+	for nodeID, slots := range slotsByNode {
+		node, ok := topology.NodeByID(nodeID)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: 토폴로지에 없는 노드", nodeID))
+			continue
+		}
+		client, err := cm.Connect(node.Endpoint())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", node.HostPort(), err))
+			continue
+		}
+
+		pipe := client.Pipeline()
+		cmds := make(map[int]*redisv9.IntCmd, len(slots))
+		for _, slot := range slots {
+			if _, dup := cmds[slot]; !dup {
+				cmds[slot] = pipe.ClusterCountKeysInSlot(ctx, slot)
+			}
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: CLUSTER COUNTKEYSINSLOT 실패: %w", node.HostPort(), err))
+			continue
+		}
+
+		nodeCounts := make(map[int]int64, len(cmds))
+		for slot, cmd := range cmds {
+			nodeCounts[slot] = cmd.Val()
+		}
+		counts[nodeID] = nodeCounts
+	}
+	return counts, errors.Join(errs...)
+}
+
+// buildFixPlan decides per slot whether to finish, revert or assign, based on where keys live
+func buildFixPlan(topology *redis.Topology, migrations []redis.SlotMigration, keys slotKeyCounts, assignTo string) ([]fixAction, error) {
+	var plan []fixAction
+
+	// Open slots
+	for _, m := range migrations {
+		action := fixAction{
+			Slot:       m.Slot,
+			SourceID:   m.SourceID,
+			TargetID:   m.TargetID,
+			SourceKeys: keys.get(m.SourceID, m.Slot),
+			TargetKeys: keys.get(m.TargetID, m.Slot),
+		}
+
+		switch {
+		case action.TargetKeys < 0 && action.SourceKeys < 0:
+			action.Kind = fixManual
+			action.Reason = "소스와 대상 노드 모두 연결할 수 없음"
+		case action.TargetKeys < 0:
+			action.Kind = fixManual
+			action.Reason = "대상 노드에 연결할 수 없음"
+		case action.SourceKeys < 0:
+			// 소스가 장애(fail)로 판정된 경우에만 대상으로 소유권을 넘긴다.
+			// 단순히 연결할 수 없는 소스에는 키가 남아 있을 수 있다.
+			if source, ok := topology.NodeByID(m.SourceID); ok && source.IsFail() {
+				action.Kind = fixFinish
+				action.Reason = fmt.Sprintf("소스 노드 장애(fail) - 대상으로 마무리 (대상 %d개)", action.TargetKeys)
+			} else {
+				action.Kind = fixManual
+				action.Reason = "소스 노드에 연결할 수 없음"
+			}
+		case action.TargetKeys == 0:
+			// 대상에 키가 없으면 소스에 그대로 두고 닫는다
+			action.Kind = fixRevert
+			action.Reason = fmt.Sprintf("키가 소스에만 있음 (소스 %d개)", action.SourceKeys)
+		case action.SourceKeys == 0:
+			action.Kind = fixFinish
+			action.Reason = fmt.Sprintf("키가 대상에만 있음 (대상 %d개)", action.TargetKeys)
+		default:
+			action.Kind = fixFinish
+			action.Reason = fmt.Sprintf("키가 양쪽에 나뉘어 있음 (소스 %d개, 대상 %d개) - 남은 키 이동", action.SourceKeys, action.TargetKeys)
+		}
+
+		plan = append(plan, action)
+	}
+
+	// Uncovered slots
+	masters := topology.Masters()
+	if len(masters) == 0 {
+		return nil, fmt.Errorf("클러스터에 마스터 노드가 없습니다")
+	}
+
+	// Slots assigned during planning count towards the fallback balance
+	slotCounts := make(map[string]int, len(masters))
+	for _, master := range masters {
+		slotCounts[master.ID] = master.SlotCount()
+	}
+
+	for _, slot := range uncoveredSlots(topology) {
+		var holders, unknown []string
+		for _, master := range masters {
+			if master.IsFail() {
+				continue
+			}
+			switch count := keys.get(master.ID, slot); {
+			case count < 0:
+				unknown = append(unknown, master.HostPort())
+			case count > 0:
+				holders = append(holders, master.ID)
+			}
+		}
+
+		action := fixAction{Slot: slot, Kind: fixAssign}
+		switch {
+		case len(unknown) > 0:
+			// 확인하지 못한 마스터가 키를 가지고 있을 수 있으므로 할당하지 않는다
+			action.Kind = fixManual
+			action.Reason = fmt.Sprintf("마스터 %s 키 수 확인 불가", strings.Join(unknown, ", "))
+		case len(holders) == 1:
+			action.TargetID = holders[0]
+			action.Reason = "키를 가진 마스터에 할당"
+		case len(holders) > 1:
+			action.Kind = fixManual
+			action.Reason = fmt.Sprintf("%d개 마스터에 키가 있음", len(holders))
+		case assignTo != "":
+			action.TargetID = assignTo
+			action.Reason = "키 없음 - 지정된 마스터에 할당"
+		default:
+			action.TargetID = leastLoadedMaster(masters, slotCounts)
+			action.Reason = "키 없음 - 슬롯이 가장 적은 마스터에 할당"
+		}
+
+		if action.TargetID != "" {
+			slotCounts[action.TargetID]++
+		}
+		plan = append(plan, action)
+	}
+
+	return plan, nil
+}
+
+func leastLoadedMaster(masters []redis.ClusterNode, slotCounts map[string]int) string {
+	candidates := make([]redis.ClusterNode, 0, len(masters))
+	for _, master := range masters {
+		if !master.IsFail() {
+			candidates = append(candidates, master)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return slotCounts[candidates[i].ID] < slotCounts[candidates[j].ID]
+	})
+	return candidates[0].ID
+}
+
+func fixActionLabel(kind string) string {
+	switch kind {
+	case fixFinish:
+		return "마이그레이션 완료"
+	case fixRevert:
+		return "마이그레이션 되돌리기"
+	case fixAssign:
+		return "슬롯 할당"
+	default:
+		return "수동 확인"
+	}
+}
+
+func displayFixPlan(topology *redis.Topology, plan []fixAction) {
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("복구 계획"))
+
+	nodeAddr := func(id string) string {
+		if id == "" {
+			return "-"
+		}
+		if node, ok := topology.NodeByID(id); ok {
+			return node.Endpoint()
+		}
+		if len(id) > 8 {
+			return id[:8] + "..."
+		}
+		return id
+	}
+
+	counts := make(map[string]int)
+	for _, action := range plan {
+		counts[action.Kind]++
+
+		var route string
+		if action.Kind == fixAssign {
+			route = "→ " + nodeAddr(action.TargetID)
+		} else {
+			route = nodeAddr(action.SourceID) + " → " + nodeAddr(action.TargetID)
+		}
+
+		style := styles.SuccessStyle
+		switch action.Kind {
+		case fixRevert:
+			style = styles.WarningStyle
+		case fixManual:
+			style = styles.ErrorStyle
+		}
+
+		// 미할당 슬롯이 많을 수 있으므로 할당 작업은 요약만 표시
+		if action.Kind == fixAssign && counts[fixAssign] > 20 {
+			continue
+		}
+
+		fmt.Printf("  슬롯 %-5d %s %s | %s\n",
+			action.Slot,
+			style.Render(fixActionLabel(action.Kind)),
+			styles.HighlightStyle.Render(route),
+			styles.DescStyle.Render(action.Reason))
+	}
+
+	if counts[fixAssign] > 20 {
+		fmt.Printf("  ... 외 %d개 슬롯 할당\n", counts[fixAssign]-20)
+	}
+
+	var parts []string
+	for _, kind := range []string{fixFinish, fixRevert, fixAssign, fixManual} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d개", fixActionLabel(kind), counts[kind]))
+		}
+	}
+	fmt.Printf("\n총 %d개 슬롯: %s\n", len(plan), strings.Join(parts, ", "))
+}
+
+// executeFixAction applies one plan step, reusing the reshard MIGRATE/SETSLOT helpers
+func executeFixAction(ctx context.Context, cm *redis.ClusterManager, clusterNode string, topology *redis.Topology, action fixAction, pipelineSize int) error {
+	target, ok := topology.NodeByID(action.TargetID)
+	if !ok {
+		return fmt.Errorf("대상 노드 %s를 찾을 수 없습니다", action.TargetID)
+	}
+
+	targetClient, err := cm.Connect(target.Endpoint())
+	if err != nil {
+		return fmt.Errorf("대상 노드 연결 실패: %w", err)
+	}
+
+	switch action.Kind {
+	case fixAssign:
+		if err := targetClient.Do(ctx, "CLUSTER", "ADDSLOTS", action.Slot).Err(); err != nil {
+			return fmt.Errorf("ADDSLOTS 실패: %w", err)
+		}
+		// 새 설정이 다른 노드의 오래된 설정보다 우선하도록 에포크 증가
+		if err := targetClient.Do(ctx, "CLUSTER", "BUMPEPOCH").Err(); err != nil {
+			return fmt.Errorf("BUMPEPOCH 실패: %w", err)
+		}
+		return nil
+
+	case fixRevert:
+		if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", action.Slot, "STABLE").Err(); err != nil {
+			return fmt.Errorf("대상 노드 STABLE 설정 실패: %w", err)
+		}
+		if source, ok := topology.NodeByID(action.SourceID); ok {
+			sourceClient, err := cm.Connect(source.Endpoint())
+			if err != nil {
+				return fmt.Errorf("소스 노드 연결 실패: %w", err)
+			}
+			if err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", action.Slot, "STABLE").Err(); err != nil {
+				return fmt.Errorf("소스 노드 STABLE 설정 실패: %w", err)
+			}
+		}
+		return nil
+
+	case fixFinish:
+		if action.SourceKeys > 0 {
+			source, ok := topology.NodeByID(action.SourceID)
+			if !ok {
+				return fmt.Errorf("소스 노드 %s를 찾을 수 없습니다", action.SourceID)
+			}
+			sourceClient, err := cm.Connect(source.Endpoint())
+			if err != nil {
+				return fmt.Errorf("소스 노드 연결 실패: %w", err)
+			}
+			if err := verifyMigrateTransport(ctx, sourceClient); err != nil {
+				return err
+			}
+
+			targetHost, targetPort, err := parseNodeAddress(target.Endpoint())
+			if err != nil {
+				return fmt.Errorf("대상 노드 주소 파싱 실패: %w", err)
+			}
+
+			// MIGRATING/IMPORTING 재설정 → 남은 키 이동 → 양쪽 SETSLOT NODE
+			if err := migrateSlot(ctx, sourceClient, targetClient, action.Slot, targetHost, targetPort, pipelineSize, action.SourceID, action.TargetID); err != nil {
+				return err
+			}
+		} else {
+			// 키가 모두 대상에 있으므로 소유권만 넘긴다 (대상 먼저)
+			if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", action.Slot, "NODE", action.TargetID).Err(); err != nil {
+				return fmt.Errorf("대상 노드 슬롯 할당 실패: %w", err)
+			}
+		}
+
+		return updateAllNodesSlotOwnership(ctx, cm, clusterNode, action.Slot, action.TargetID)
+	}
+
+	return fmt.Errorf("자동 복구할 수 없는 슬롯입니다: %s", action.Reason)
+}
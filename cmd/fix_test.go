@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"testing"
+
+	"redisctl/internal/redis"
+)
+
+// fixTestTopology leaves slot 16383 uncovered; dddd is a failed master without slots
+const fixTestTopology = `aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-5460
+bbbb 10.0.1.2:7001@17001 master - 0 0 2 connected 5461-10922
+cccc 10.0.1.3:7001@17001 master - 0 0 3 connected 10923-16382
+dddd 10.0.1.4:7001@17001 master,fail - 0 0 4 disconnected`
+
+// TestBuildFixPlanOpenSlots tests the finish/revert decision for open slots
+func TestBuildFixPlanOpenSlots(t *testing.T) {
+	// Cover every slot so that only the open slot is planned
+	topology := redis.ParseTopology(fixTestTopology + "\neeee 10.0.1.5:7001@17001 master - 0 0 5 connected 16383")
+
+	tests := []struct {
+		name     string
+		source   string // defaults to aaaa
+		keys     slotKeyCounts
+		wantKind string
+	}{
+		{"keys only on source", "", slotKeyCounts{"aaaa": {100: 5}, "bbbb": {100: 0}}, fixRevert},
+		{"empty slot", "", slotKeyCounts{"aaaa": {100: 0}, "bbbb": {100: 0}}, fixRevert},
+		{"source unreachable, target empty", "", slotKeyCounts{"bbbb": {100: 0}}, fixManual},
+		{"keys only on target", "", slotKeyCounts{"aaaa": {100: 0}, "bbbb": {100: 3}}, fixFinish},
+		{"source unreachable, target has keys", "", slotKeyCounts{"bbbb": {100: 3}}, fixManual},
+		{"failed source, target has keys", "dddd", slotKeyCounts{"bbbb": {100: 3}}, fixFinish},
+		{"failed source, target empty", "dddd", slotKeyCounts{"bbbb": {100: 0}}, fixFinish},
+		{"keys on both", "", slotKeyCounts{"aaaa": {100: 2}, "bbbb": {100: 3}}, fixFinish},
+		{"target unreachable", "", slotKeyCounts{"aaaa": {100: 5}}, fixManual},
+		{"both unreachable", "", slotKeyCounts{}, fixManual},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migration := redis.SlotMigration{Slot: 100, SourceID: "aaaa", TargetID: "bbbb", Migrating: true, Importing: true}
+			if tt.source != "" {
+				migration.SourceID = tt.source
+			}
+			plan, err := buildFixPlan(topology, []redis.SlotMigration{migration}, tt.keys, "")
+			if err != nil {
+				t.Fatalf("buildFixPlan() error = %v", err)
+			}
+			if len(plan) != 1 {
+				t.Fatalf("buildFixPlan() returned %d actions, want 1: %+v", len(plan), plan)
+			}
+			if plan[0].Slot != 100 || plan[0].Kind != tt.wantKind {
+				t.Errorf("buildFixPlan() = slot %d %s, want slot 100 %s (%s)", plan[0].Slot, plan[0].Kind, tt.wantKind, plan[0].Reason)
+			}
+		})
+	}
+}
+
+// TestBuildFixPlanUncoveredSlots tests who an uncovered slot is assigned to
+func TestBuildFixPlanUncoveredSlots(t *testing.T) {
+	topology := redis.ParseTopology(fixTestTopology)
+
+	tests := []struct {
+		name       string
+		keys       slotKeyCounts
+		assignTo   string
+		wantKind   string
+		wantTarget string
+	}{
+		{"single holder", slotKeyCounts{"aaaa": {16383: 0}, "bbbb": {16383: 7}, "cccc": {16383: 0}}, "", fixAssign, "bbbb"},
+		{"single holder wins over assign-to", slotKeyCounts{"aaaa": {16383: 7}, "bbbb": {16383: 0}, "cccc": {16383: 0}}, "cccc", fixAssign, "aaaa"},
+		{"several holders", slotKeyCounts{"aaaa": {16383: 1}, "bbbb": {16383: 2}, "cccc": {16383: 0}}, "", fixManual, ""},
+		{"failed master keys ignored", slotKeyCounts{"aaaa": {16383: 0}, "bbbb": {16383: 1}, "cccc": {16383: 0}, "dddd": {16383: 4}}, "", fixAssign, "bbbb"},
+		{"no keys with assign-to", slotKeyCounts{"aaaa": {16383: 0}, "bbbb": {16383: 0}, "cccc": {16383: 0}}, "aaaa", fixAssign, "aaaa"},
+		{"no keys goes to least loaded healthy master", slotKeyCounts{"aaaa": {16383: 0}, "bbbb": {16383: 0}, "cccc": {16383: 0}}, "", fixAssign, "cccc"},
+		{"one master count missing", slotKeyCounts{"aaaa": {16383: 0}, "cccc": {16383: 0}}, "aaaa", fixManual, ""},
+		{"missing count blocks single holder", slotKeyCounts{"aaaa": {16383: 3}, "bbbb": {16383: 0}}, "", fixManual, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := buildFixPlan(topology, nil, tt.keys, tt.assignTo)
+			if err != nil {
+				t.Fatalf("buildFixPlan() error = %v", err)
+			}
+			if len(plan) != 1 {
+				t.Fatalf("buildFixPlan() returned %d actions, want 1: %+v", len(plan), plan)
+			}
+			action := plan[0]
+			if action.Slot != 16383 || action.Kind != tt.wantKind || action.TargetID != tt.wantTarget {
+				t.Errorf("buildFixPlan() = slot %d %s -> %q, want slot 16383 %s -> %q (%s)",
+					action.Slot, action.Kind, action.TargetID, tt.wantKind, tt.wantTarget, action.Reason)
+			}
+		})
+	}
+}
+
+// TestBuildFixPlanBalancesAssignments tests that slots assigned during planning count towards the balance
+func TestBuildFixPlanBalancesAssignments(t *testing.T) {
+	topology := redis.ParseTopology(`aaaa 10.0.1.1:7001@17001 myself,master - 0 0 1 connected 0-16379
+bbbb 10.0.1.2:7001@17001 master - 0 0 2 connected
+cccc 10.0.1.3:7001@17001 master - 0 0 3 connected`)
+
+	empty := map[int]int64{16380: 0, 16381: 0, 16382: 0, 16383: 0}
+	plan, err := buildFixPlan(topology, nil, slotKeyCounts{"aaaa": empty, "bbbb": empty, "cccc": empty}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assigned := make(map[string]int)
+	for _, action := range plan {
+		assigned[action.TargetID]++
+	}
+	if len(plan) != 4 || assigned["bbbb"] != 2 || assigned["cccc"] != 2 {
+		t.Errorf("buildFixPlan() assigned %v over %d slots, want bbbb 2, cccc 2", assigned, len(plan))
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	redisv9 "github.com/redis/go-redis/v9"
+
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+)
+
+// ErrInterrupted is returned when a command stops because of Ctrl-C / SIGTERM
+var ErrInterrupted = errors.New("사용자 요청으로 작업이 중단되었습니다")
+
+// NewSignalContext returns the root context for all commands.
+// The first SIGINT/SIGTERM cancels it: slot loops finish the slot in flight and stop.
+// A second signal exits immediately.
+func NewSignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigCh:
+		case <-ctx.Done():
+			return
+		}
+
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, styles.RenderWarning("중단 요청을 받았습니다. 진행 중인 작업을 정리한 후 종료합니다 (강제 종료: Ctrl-C 한 번 더)"))
+		cancel()
+
+		<-sigCh
+		fmt.Fprintln(os.Stderr, styles.RenderError("강제 종료합니다. 'redisctl check'로 열린 슬롯을 확인하세요"))
+		os.Exit(130)
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigCh)
+		cancel()
+	}
+}
+
+// displayInterruptSummary shows which slots were moved before the interrupt.
+// Open slots are re-checked on every master of topology before the summary
+// claims that none were left behind; a nil topology means the check could not run.
+func displayInterruptSummary(ctx context.Context, topology *redis.Topology, moved []int, total int) {
+	fmt.Println()
+	summary := styles.SubtitleStyle.Render("중단 요약") + "\n" +
+		fmt.Sprintf("• 이동 완료된 슬롯: %d/%d개 (%s)\n", len(moved), total, formatSlotRanges(moved)) +
+		fmt.Sprintf("• 남은 슬롯: %d개\n", total-len(moved)) +
+		interruptOpenSlotLine(ctx, topology) +
+		"• 필요하면 명령을 다시 실행하여 남은 슬롯을 이동하세요"
+	fmt.Println(styles.BoxStyle.Render(summary))
+}
+
+// interruptOpenSlotLine reports the open slot check for the interrupt summary.
+// ctx is already cancelled here, so the check runs detached with its own timeout.
+func interruptOpenSlotLine(ctx context.Context, topology *redis.Topology) string {
+	if topology == nil {
+		return "• 열린 슬롯 여부를 확인하지 못했습니다. 'redisctl check'로 확인하세요\n"
+	}
+
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	open, err := redis.CollectOpenSlots(checkCtx, topology)
+	if err != nil {
+		return fmt.Sprintf("• 열린 슬롯 확인 실패: %v. 'redisctl check'로 확인하세요\n", err)
+	}
+	if len(open) > 0 {
+		var slots []int
+		for _, m := range redis.GroupOpenSlots(open) {
+			slots = append(slots, m.Slot)
+		}
+		return fmt.Sprintf("• 열린 슬롯이 남아 있습니다 (%s). 'redisctl fix <노드>'로 복구하세요\n", formatSlotRanges(slots))
+	}
+	return "• 모든 마스터에서 열린 슬롯이 남지 않았음을 확인했습니다\n"
+}
+
+// interruptTopology reloads the topology for the interrupt summary, or returns nil if it cannot
+func interruptTopology(ctx context.Context, client *redisv9.ClusterClient) *redis.Topology {
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	topology, err := redis.LoadClusterTopology(checkCtx, client)
+	if err != nil {
+		return nil
+	}
+	return topology
+}
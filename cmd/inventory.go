@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"redisctl/internal/config"
+	"redisctl/internal/inventory"
+	"redisctl/internal/styles"
+)
+
+// loadInventory loads the local inventory and records the --label / --topology-file values in it,
+// so that labels given once are reused by later commands
+func loadInventory() (*inventory.Inventory, error) {
+	labels, topologyFile, path := config.GetLabelOptions()
+	if path == "" {
+		path = inventory.DefaultPath()
+	}
+
+	inv, err := inventory.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]inventory.Labels)
+	if topologyFile != "" {
+		nodes, err := inventory.LoadTopologyFile(topologyFile)
+		if err != nil {
+			return nil, err
+		}
+		for addr, nodeLabels := range nodes {
+			updates[addr] = nodeLabels
+		}
+	}
+	for _, label := range labels {
+		addr, key, value, err := inventory.ParseLabel(label)
+		if err != nil {
+			return nil, err
+		}
+		if updates[addr] == nil {
+			updates[addr] = make(inventory.Labels)
+		}
+		updates[addr][key] = value
+	}
+
+	if len(updates) == 0 {
+		return inv, nil
+	}
+
+	inv.Merge(updates)
+	if err := inv.Save(); err != nil {
+		// 라벨은 이번 실행에는 적용되므로 저장 실패는 경고만
+		fmt.Println(styles.RenderWarning(fmt.Sprintf("라벨을 인벤토리에 저장하지 못했습니다: %v", err)))
+	} else {
+		fmt.Printf("노드 %d개의 라벨을 인벤토리에 저장했습니다: %s\n", len(updates), styles.HighlightStyle.Render(inv.Path()))
+	}
+	return inv, nil
+}
@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"redisctl/internal/redis"
+)
+
+// 노드 배치 시 장애 도메인(존, 호스트) 계산.
+// 존은 --label/토폴로지 파일/인벤토리의 zone 라벨이며, 라벨이 없으면 호스트만 고려한다.
+
+// nodeHost returns the normalized host of a node address (localhost and 127.0.0.1 are the same host)
+func nodeHost(node string) string {
+	addr, err := redis.ParseAddress(node)
+	if err != nil {
+		return node
+	}
+	return addr.Host
+}
+
+// nodeZone returns the zone label of a node, empty when unknown
+func nodeZone(zones map[string]string, node string) string {
+	addr, err := redis.ParseAddress(node)
+	if err != nil {
+		return zones[node]
+	}
+	return zones[addr.String()]
+}
+
+// groupNodesBy groups nodes by key, keeping the order in which keys first appear
+func groupNodesBy(nodes []string, key func(string) string) ([]string, map[string][]string) {
+	var keys []string
+	groups := make(map[string][]string)
+	for _, node := range nodes {
+		k := key(node)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], node)
+	}
+	return keys, groups
+}
+
+// groupNodesByHost groups nodes by host, keeping the order in which hosts first appear
+func groupNodesByHost(nodes []string) ([]string, map[string][]string) {
+	return groupNodesBy(nodes, nodeHost)
+}
+
+// roundRobin takes one node from each group in turn
+func roundRobin(keys []string, groups map[string][]string) []string {
+	var result []string
+	for {
+		taken := false
+		for _, k := range keys {
+			if len(groups[k]) > 0 {
+				result = append(result, groups[k][0])
+				groups[k] = groups[k][1:]
+				taken = true
+			}
+		}
+		if !taken {
+			return result
+		}
+	}
+}
+
+// interleaveNodes orders nodes so that consecutive nodes are in different zones,
+// and within a zone on different hosts
+func interleaveNodes(nodes []string, zones map[string]string) []string {
+	zoneKeys, byZone := groupNodesBy(nodes, func(n string) string { return nodeZone(zones, n) })
+	for _, zone := range zoneKeys {
+		hosts, byHost := groupNodesByHost(byZone[zone])
+		byZone[zone] = roundRobin(hosts, byHost)
+	}
+	return roundRobin(zoneKeys, byZone)
+}
+
+// placementConflict scores how badly a replica shares failure domains with its master:
+// 2 for the same host, 1 for the same (labelled) zone, 0 otherwise
+func placementConflict(zones map[string]string, replica, master string) int {
+	if nodeHost(replica) == nodeHost(master) {
+		return 2
+	}
+	if zone := nodeZone(zones, master); zone != "" && nodeZone(zones, replica) == zone {
+		return 1
+	}
+	return 0
+}
+
+// pickReplicaCandidate chooses the index of the next replica for a master, preferring
+// a zone and host without the master or its other replicas, then a zone other than the master's,
+// then an unused host, then any host other than the master's.
+// The first remaining node is used only when anti-affinity cannot be satisfied.
+func pickReplicaCandidate(remaining []string, master string, usedZones, usedHosts map[string]bool, zones map[string]string) int {
+	masterZone := nodeZone(zones, master)
+	preferences := []func(string) bool{
+		func(n string) bool { return !usedZones[nodeZone(zones, n)] && !usedHosts[nodeHost(n)] },
+		func(n string) bool {
+			return masterZone != "" && nodeZone(zones, n) != masterZone && !usedHosts[nodeHost(n)]
+		},
+		func(n string) bool { return !usedHosts[nodeHost(n)] },
+		func(n string) bool { return nodeHost(n) != nodeHost(master) },
+	}
+
+	for _, preferred := range preferences {
+		for i, node := range remaining {
+			if preferred(node) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// optimizeAntiAffinity swaps masters between a conflicting replica and a replica of another master
+// when the swap lowers the combined conflict score of both
+func optimizeAntiAffinity(replicaMap map[string]string, zones map[string]string) {
+	replicas := make([]string, 0, len(replicaMap))
+	for replica := range replicaMap {
+		replicas = append(replicas, replica)
+	}
+	sort.Strings(replicas)
+
+	for _, replica := range replicas {
+		for _, other := range replicas {
+			master, otherMaster := replicaMap[replica], replicaMap[other]
+			if placementConflict(zones, replica, master) == 0 {
+				break
+			}
+			if otherMaster == master {
+				continue
+			}
+
+			before := placementConflict(zones, replica, master) + placementConflict(zones, other, otherMaster)
+			after := placementConflict(zones, replica, otherMaster) + placementConflict(zones, other, master)
+			if after < before {
+				replicaMap[replica], replicaMap[other] = otherMaster, master
+			}
+		}
+	}
+}
+
+// layoutAntiAffinityWarnings lists replicas that had to share a host or zone with their master,
+// and hosts or zones holding half of the masters or more
+func layoutAntiAffinityWarnings(masters []string, replicaMap map[string]string, zones map[string]string) []string {
+	var warnings []string
+
+	for _, master := range masters {
+		var conflicting []string
+		for replica, m := range replicaMap {
+			if m == master && placementConflict(zones, replica, master) > 0 {
+				conflicting = append(conflicting, replica)
+			}
+		}
+		sort.Strings(conflicting)
+		for _, replica := range conflicting {
+			if placementConflict(zones, replica, master) == 2 {
+				warnings = append(warnings, fmt.Sprintf("복제본 %s가 마스터 %s와 같은 호스트(%s)에 있습니다", replica, master, nodeHost(master)))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("복제본 %s가 마스터 %s와 같은 존(%s)에 있습니다", replica, master, nodeZone(zones, master)))
+			}
+		}
+	}
+
+	domains := []struct {
+		name string
+		key  func(string) string
+	}{
+		{"호스트", nodeHost},
+		{"존", func(n string) string { return nodeZone(zones, n) }},
+	}
+	for _, domain := range domains {
+		keys, groups := groupNodesBy(masters, domain.key)
+		for _, k := range keys {
+			if k == "" || len(keys) < 2 || len(groups[k]) < 2 || len(groups[k])*2 < len(masters) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("%s %s에 마스터 %d개가 몰려 있어 장애 시 과반 마스터를 잃습니다", domain.name, k, len(groups[k])))
+		}
+	}
+
+	return warnings
+}
+
+// faultTolerance returns the failure domains in the layout (hosts, zones, ...), how many of them may fail
+// at once while the cluster keeps serving every slot, and the domains whose failure alone breaks it.
+// The cluster survives when every shard keeps a node and a majority of masters stays up to vote for failover.
+func faultTolerance(masters []string, replicaMap map[string]string, domainOf func(string) string) ([]string, int, []string) {
+	shards := make(map[string][]string, len(masters))
+	var nodes []string
+	for _, master := range masters {
+		shards[master] = []string{domainOf(master)}
+		nodes = append(nodes, master)
+	}
+	for replica, master := range replicaMap {
+		shards[master] = append(shards[master], domainOf(replica))
+		nodes = append(nodes, replica)
+	}
+	domains, _ := groupNodesBy(nodes, domainOf)
+
+	survives := func(failed map[string]bool) bool {
+		mastersDown := 0
+		for _, master := range masters {
+			if failed[domainOf(master)] {
+				mastersDown++
+			}
+			alive := false
+			for _, domain := range shards[master] {
+				if !failed[domain] {
+					alive = true
+					break
+				}
+			}
+			if !alive {
+				return false
+			}
+		}
+		return mastersDown == 0 || (len(masters)-mastersDown)*2 > len(masters)
+	}
+
+	var weak []string
+	for _, domain := range domains {
+		if !survives(map[string]bool{domain: true}) {
+			weak = append(weak, domain)
+		}
+	}
+	if len(weak) > 0 {
+		return domains, 0, weak
+	}
+
+	// 한 샤드가 차지한 도메인 수가 상한이므로 조합 수는 작게 유지된다
+	limit := len(domains)
+	for _, shardDomains := range shards {
+		distinct, _ := groupNodesBy(shardDomains, func(d string) string { return d })
+		limit = min(limit, len(distinct))
+	}
+
+	tolerated := 1
+	for k := 2; k < limit; k++ {
+		if !allCombinationsSurvive(domains, k, survives) {
+			break
+		}
+		tolerated = k
+	}
+	return domains, tolerated, nil
+}
+
+// allCombinationsSurvive reports whether survives holds for every set of k failed domains
+func allCombinationsSurvive(domains []string, k int, survives func(map[string]bool) bool) bool {
+	failed := make(map[string]bool, k)
+
+	var walk func(start, left int) bool
+	walk = func(start, left int) bool {
+		if left == 0 {
+			return survives(failed)
+		}
+		for i := start; i <= len(domains)-left; i++ {
+			failed[domains[i]] = true
+			ok := walk(i+1, left-1)
+			delete(failed, domains[i])
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return walk(0, k)
+}
+
+// zonesCoverAll reports whether every node has a zone label
+func zonesCoverAll(zones map[string]string, nodes []string) bool {
+	if len(zones) == 0 {
+		return false
+	}
+	for _, node := range nodes {
+		if nodeZone(zones, node) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAnyZone reports whether at least one node has a zone label
+func hasAnyZone(zones map[string]string, nodes []string) bool {
+	for _, node := range nodes {
+		if nodeZone(zones, node) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneOutageRisks lists zones whose outage would take down a whole shard
+// (master and every healthy replica in the zone) or half of the masters or more
+func zoneOutageRisks(topology *redis.Topology, zones map[string]string) []string {
+	var risks []string
+	mastersPerZone := make(map[string]int)
+	masters := 0
+
+	for _, master := range topology.Masters() {
+		if master.IsFail() || master.SlotCount() == 0 {
+			continue
+		}
+		masters++
+
+		zone := nodeZone(zones, master.HostPort())
+		if zone == "" {
+			continue
+		}
+		mastersPerZone[zone]++
+
+		sameZone := true
+		for _, replica := range topology.ReplicasOf(master.ID) {
+			if !replica.IsFail() && nodeZone(zones, replica.HostPort()) != zone {
+				sameZone = false
+				break
+			}
+		}
+		if sameZone {
+			risks = append(risks, fmt.Sprintf("존 %s 장애 시 샤드 %s(슬롯 %d개) 전체 손실 - 복제본을 다른 존에 두세요",
+				zone, master.HostPort(), master.SlotCount()))
+		}
+	}
+
+	zoneNames := make([]string, 0, len(mastersPerZone))
+	for zone := range mastersPerZone {
+		zoneNames = append(zoneNames, zone)
+	}
+	sort.Strings(zoneNames)
+	for _, zone := range zoneNames {
+		if masters > 1 && mastersPerZone[zone]*2 >= masters {
+			risks = append(risks, fmt.Sprintf("존 %s에 마스터 %d/%d개 - 존 장애 시 과반 마스터를 잃어 자동 페일오버 불가",
+				zone, mastersPerZone[zone], masters))
+		}
+	}
+
+	return risks
+}
+
+// replicaMasterChoice is the master chosen for a new replica and why
+type replicaMasterChoice struct {
+	Master   redis.ClusterNode
+	Replicas int    // healthy replicas before the new one
+	Reason   string // shown in the add-node summary
+}
+
+// chooseReplicaMaster picks the master for a new replica: the fewest healthy replicas,
+// ties broken by more slots, skipping masters on the new node's host.
+// added counts replicas already assigned in the same run by master ID.
+func chooseReplicaMaster(topology *redis.Topology, newNode string, added map[string]int) (replicaMasterChoice, error) {
+	var candidates []replicaMasterChoice
+	sameHost := 0
+	for _, master := range topology.Masters() {
+		if !master.IsHealthy() {
+			continue
+		}
+		if nodeHost(master.HostPort()) == nodeHost(newNode) {
+			sameHost++
+			continue
+		}
+		healthy := added[master.ID]
+		for _, replica := range topology.ReplicasOf(master.ID) {
+			if replica.IsHealthy() && !replica.IsHandshake() && !replica.IsNoAddr() {
+				healthy++
+			}
+		}
+		candidates = append(candidates, replicaMasterChoice{Master: master, Replicas: healthy})
+	}
+	if len(candidates) == 0 {
+		return replicaMasterChoice{}, fmt.Errorf("%s와 다른 호스트에 있는 정상 마스터가 없습니다 (--master-id로 직접 지정하세요)", newNode)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Replicas != b.Replicas {
+			return a.Replicas < b.Replicas
+		}
+		if a.Master.SlotCount() != b.Master.SlotCount() {
+			return a.Master.SlotCount() > b.Master.SlotCount()
+		}
+		return a.Master.HostPort() < b.Master.HostPort()
+	})
+
+	choice := candidates[0]
+	tied := 0
+	for _, c := range candidates[1:] {
+		if c.Replicas == choice.Replicas {
+			tied++
+		}
+	}
+	choice.Reason = fmt.Sprintf("정상 복제본이 가장 적음 (%d개)", choice.Replicas)
+	if tied > 0 {
+		choice.Reason += fmt.Sprintf(", 같은 복제본 수의 마스터 %d개 중 슬롯이 가장 많음 (%d개)", tied+1, choice.Master.SlotCount())
+	}
+	if sameHost > 0 {
+		choice.Reason += fmt.Sprintf(", 같은 호스트의 마스터 %d개 제외", sameHost)
+	}
+	return choice, nil
+}
@@ -4,13 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 
 	"redisctl/internal/config"
+	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 )
 
@@ -19,7 +21,7 @@ func NewPopulateCommand() *cobra.Command {
 	var numKeys int
 
 	cmd := &cobra.Command{
-		Use:   "populate-test-data [--num-keys N] <cluster-node-ip:port>",
+		Use:   "populate-test-data [--num-keys N] <cluster-node-ip:port[,ip:port...]>",
 		Short: "p 클러스터에 테스트 데이터를 삽입합니다",
 		Long: styles.TitleStyle.Render("[TEST] Redis 클러스터 테스트 데이터 생성") + "\n\n" +
 			styles.DescStyle.Render("Redis 클러스터에 성능 테스트용 더미 데이터를 생성합니다.") + "\n\n" +
@@ -36,12 +38,13 @@ func NewPopulateCommand() *cobra.Command {
 
   # 최대 10,000,000개 키 생성 (대규모 테스트)
   redisctl --password mypass populate-test-data --num-keys 10000000 localhost:9001`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
-			return runPopulateTestData(args[0], numKeys)
+			clusterAddr, _ := splitClusterArgs(args, 1)
+			return runPopulateTestData(cmd.Context(), clusterAddr, numKeys)
 		},
 	}
 
@@ -60,7 +63,7 @@ type PopulateStats struct {
 	WorkerCount   int
 }
 
-func runPopulateTestData(clusterAddr string, numKeys int) error {
+func runPopulateTestData(ctx context.Context, clusterAddr string, numKeys int) error {
 	// Validate input
 	if numKeys <= 0 {
 		return fmt.Errorf("키 수는 1 이상이어야 합니다")
@@ -69,22 +72,25 @@ func runPopulateTestData(clusterAddr string, numKeys int) error {
 		return fmt.Errorf("최대 키 수는 10,000,000개입니다")
 	}
 
+	seeds, err := clusterSeeds(clusterAddr)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(styles.InfoStyle.Render("Redis 클러스터 테스트 데이터 생성"))
-	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(clusterAddr))
+	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
 	fmt.Printf("생성할 키 수: %s\n", styles.HighlightStyle.Render(formatNumber(int64(numKeys))))
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
 	fmt.Println()
 
 	// Connect to cluster
-	user, password := config.GetAuth()
-	client := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:    []string{clusterAddr},
-		Username: user,
-		Password: password,
-	})
+	client := redis.NewClusterClient(selection.Addresses()...)
 	defer client.Close()
 
-	ctx := context.Background()
-
 	// Validate cluster connectivity
 	if err := validatePopulateConnectivity(ctx, client); err != nil {
 		return fmt.Errorf("클러스터 연결 실패: %w", err)
@@ -115,12 +121,19 @@ func runPopulateTestData(clusterAddr string, numKeys int) error {
 	// Display final statistics
 	displayPopulateResults(stats)
 
+	if ctx.Err() != nil {
+		fmt.Println()
+		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("중단 요청으로 %s/%s개 키만 생성되었습니다",
+			formatNumber(int64(stats.ProcessedKeys)), formatNumber(int64(stats.TotalKeys)))))
+		return ErrInterrupted
+	}
+
 	fmt.Println()
 	fmt.Println(styles.SuccessStyle.Render("테스트 데이터 생성이 완료되었습니다!"))
 	return nil
 }
 
-func validatePopulateConnectivity(ctx context.Context, client *redis.ClusterClient) error {
+func validatePopulateConnectivity(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("1. 클러스터 연결 확인..."))
 
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -132,7 +145,7 @@ func validatePopulateConnectivity(ctx context.Context, client *redis.ClusterClie
 	return nil
 }
 
-func validateClusterForPopulate(ctx context.Context, client *redis.ClusterClient) error {
+func validateClusterForPopulate(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("2. 클러스터 상태 확인..."))
 
 	// Check cluster info
@@ -177,7 +190,7 @@ func calculateOptimalWorkerCount(numKeys int) int {
 	}
 }
 
-func populateDataWithProgress(ctx context.Context, client *redis.ClusterClient, stats *PopulateStats) error {
+func populateDataWithProgress(ctx context.Context, client *redisv9.ClusterClient, stats *PopulateStats) error {
 	fmt.Println(styles.InfoStyle.Render("3. 테스트 데이터 생성 중..."))
 
 	// Calculate optimal batch size based on total keys
@@ -189,16 +202,22 @@ func populateDataWithProgress(ctx context.Context, client *redis.ClusterClient,
 	var wg sync.WaitGroup
 
 	// Start workers
+	// 이미 받은 배치는 중단 요청과 관계없이 끝까지 기록
+	workerCtx := context.WithoutCancel(ctx)
 	for i := 0; i < stats.WorkerCount; i++ {
 		wg.Add(1)
-		go populateWorkerWithBatchSize(ctx, client, workChan, resultChan, &wg, batchSize)
+		go populateWorkerWithBatchSize(workerCtx, client, workChan, resultChan, &wg, batchSize)
 	}
 
-	// Send work items
+	// Send work items (중단 요청 시 새 작업 전송 중지)
 	go func() {
 		defer close(workChan)
 		for i := 1; i <= stats.TotalKeys; i++ {
-			workChan <- i
+			select {
+			case workChan <- i:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
@@ -266,7 +285,7 @@ func calculateOptimalBatchSize(totalKeys int) int {
 	}
 }
 
-func populateWorkerWithBatchSize(ctx context.Context, client *redis.ClusterClient, workChan <-chan int, resultChan chan<- PopulateResult, wg *sync.WaitGroup, batchSize int) {
+func populateWorkerWithBatchSize(ctx context.Context, client *redisv9.ClusterClient, workChan <-chan int, resultChan chan<- PopulateResult, wg *sync.WaitGroup, batchSize int) {
 	defer wg.Done()
 
 	// Pre-allocate slices to avoid repeated allocations
@@ -302,7 +321,7 @@ type PopulateResult struct {
 	Error    error
 }
 
-func executeBatch(ctx context.Context, client *redis.ClusterClient, batch []int, keys []string, values []string, resultChan chan<- PopulateResult) {
+func executeBatch(ctx context.Context, client *redisv9.ClusterClient, batch []int, keys []string, values []string, resultChan chan<- PopulateResult) {
 	// Create pipeline for batch execution
 	pipeline := client.Pipeline()
 
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+)
+
+// 노드를 클러스터에 넣기 전 INFO/CONFIG GET으로 설정을 점검한다.
+// 클러스터 구성 자체가 불가능한 문제는 항상 중단하고, 운영상 위험한 불일치는 --strict일 때만 중단한다.
+
+// preflightConfigKeys are settings that should be identical on every node of a cluster
+var preflightConfigKeys = []string{"cluster-node-timeout", "maxmemory-policy", "cluster-require-full-coverage"}
+
+// nodePreflight is what the preflight stage learned about one node
+type nodePreflight struct {
+	Address        string
+	Version        string // redis_version
+	ClusterEnabled bool
+	Keys           int64
+	Config         map[string]string // preflightConfigKeys, nil when CONFIG GET is not allowed
+	RequirePass    string
+	MasterAuth     string
+	Err            error
+}
+
+// preflightFinding is one problem found by the preflight stage
+type preflightFinding struct {
+	Blocking bool // 항상 중단 (false면 --strict일 때만 중단)
+	Message  string
+}
+
+// inspectNode reads INFO, DBSIZE and CONFIG GET from a node
+func inspectNode(ctx context.Context, cm *redis.ClusterManager, address string) nodePreflight {
+	result := nodePreflight{Address: address}
+
+	client, err := cm.Connect(address)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	server, err := cm.GetInfo(address, "server")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Version = server["redis_version"]
+
+	cluster, err := cm.GetInfo(address, "cluster")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.ClusterEnabled = cluster["cluster_enabled"] == "1"
+
+	result.Keys, err = client.DBSize(ctx).Result()
+	if err != nil {
+		result.Err = fmt.Errorf("DBSIZE 명령 실패: %w", err)
+		return result
+	}
+
+	// 관리형 서비스 등에서 CONFIG가 막혀 있으면 설정 비교만 생략
+	settings := make(map[string]string)
+	for _, key := range append(append([]string(nil), preflightConfigKeys...), "requirepass", "masterauth") {
+		values, err := client.ConfigGet(ctx, key).Result()
+		if err != nil {
+			return result
+		}
+		settings[key] = values[key]
+	}
+	result.RequirePass = settings["requirepass"]
+	result.MasterAuth = settings["masterauth"]
+	delete(settings, "requirepass")
+	delete(settings, "masterauth")
+	result.Config = settings
+
+	return result
+}
+
+// collectPreflight inspects the nodes in parallel, keeping their order
+func collectPreflight(ctx context.Context, cm *redis.ClusterManager, nodes []string) []nodePreflight {
+	results := make([]nodePreflight, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(index int, address string) {
+			defer wg.Done()
+			results[index] = inspectNode(ctx, cm, address)
+		}(i, node)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// evaluatePreflight checks candidate nodes for emptiness, cluster mode, version compatibility,
+// requirepass/masterauth consistency and config parity. reference is an existing cluster node
+// the candidates must match (nil for create).
+func evaluatePreflight(candidates []nodePreflight, reference *nodePreflight) []preflightFinding {
+	var findings []preflightFinding
+	blocking := func(format string, args ...any) {
+		findings = append(findings, preflightFinding{Blocking: true, Message: fmt.Sprintf(format, args...)})
+	}
+	warning := func(format string, args ...any) {
+		findings = append(findings, preflightFinding{Message: fmt.Sprintf(format, args...)})
+	}
+
+	var compared []nodePreflight
+	if reference != nil && reference.Err == nil {
+		compared = append(compared, *reference)
+	}
+
+	for _, node := range candidates {
+		if node.Err != nil {
+			blocking("%s: 정보 조회 실패 (%v)", node.Address, node.Err)
+			continue
+		}
+		if !node.ClusterEnabled {
+			blocking("%s: cluster-enabled no - redis.conf에 cluster-enabled yes를 설정하고 재시작하세요", node.Address)
+		}
+		if node.Keys > 0 {
+			blocking("%s: 키 %d개가 있습니다 - 빈 노드만 추가할 수 있습니다 (FLUSHALL 후 다시 시도)", node.Address, node.Keys)
+		}
+		if major, _, ok := parseRedisVersion(node.Version); ok && major < 3 {
+			blocking("%s: Redis %s는 클러스터를 지원하지 않습니다 (3.0 이상 필요)", node.Address, node.Version)
+		}
+		if node.Config == nil {
+			warning("%s: CONFIG GET이 허용되지 않아 설정 비교를 생략합니다", node.Address)
+		}
+		compared = append(compared, node)
+	}
+
+	// 메이저 버전이 다르면 복제/클러스터 버스 호환성 문제 가능
+	versions := groupPreflight(compared, func(n nodePreflight) (string, bool) {
+		major, _, ok := parseRedisVersion(n.Version)
+		return strconv.Itoa(major), ok
+	})
+	if len(versions) > 1 {
+		warning("Redis 메이저 버전 불일치: %s", formatPreflightGroups(versions, "%s.x"))
+	}
+
+	for _, key := range preflightConfigKeys {
+		values := groupPreflight(compared, func(n nodePreflight) (string, bool) {
+			value, ok := n.Config[key]
+			return value, ok
+		})
+		if len(values) > 1 {
+			warning("%s 불일치: %s", key, formatPreflightGroups(values, "%s"))
+		}
+	}
+
+	// 비밀번호 값은 출력하지 않는다
+	passwords := groupPreflight(compared, func(n nodePreflight) (string, bool) { return n.RequirePass, n.Config != nil })
+	if len(passwords) > 1 {
+		warning("requirepass가 노드마다 다릅니다 - 페일오버 후 클라이언트/복제 인증이 실패할 수 있습니다")
+	}
+	for _, node := range compared {
+		if node.Config != nil && node.RequirePass != "" && node.MasterAuth != node.RequirePass {
+			warning("%s: masterauth가 requirepass와 달라 복제본이 되면 마스터 인증에 실패합니다", node.Address)
+		}
+	}
+
+	return findings
+}
+
+// groupPreflight groups nodes by a value, skipping nodes without one
+func groupPreflight(nodes []nodePreflight, value func(nodePreflight) (string, bool)) map[string][]nodePreflight {
+	groups := make(map[string][]nodePreflight)
+	for _, node := range nodes {
+		if v, ok := value(node); ok {
+			groups[v] = append(groups[v], node)
+		}
+	}
+	return groups
+}
+
+// formatPreflightGroups formats groups as "value (addr, addr), value (addr)", printing values with format
+func formatPreflightGroups(groups map[string][]nodePreflight, format string) string {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		addrs := make([]string, 0, len(groups[k]))
+		for _, node := range groups[k] {
+			addrs = append(addrs, node.Address)
+		}
+		name := fmt.Sprintf(format, k)
+		if k == "" {
+			name = "<빈 값>"
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", name, strings.Join(addrs, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseRedisVersion returns the major and minor version of a redis_version string
+func parseRedisVersion(version string) (int, int, bool) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// runPreflight inspects the candidate nodes (and the reference node, if any), prints the findings
+// and fails when a blocking problem was found, or any problem with strict
+func runPreflight(ctx context.Context, cm *redis.ClusterManager, nodes []string, reference string, strict bool) error {
+	fmt.Println("  사전 점검 (INFO, DBSIZE, CONFIG GET):")
+
+	results := collectPreflight(ctx, cm, nodes)
+	var ref *nodePreflight
+	if reference != "" {
+		r := inspectNode(ctx, cm, reference)
+		ref = &r
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("    %s: %s\n", result.Address, styles.RenderError("조회 실패"))
+			continue
+		}
+		fmt.Printf("    %s: Redis %s, 키 %d개\n", result.Address, result.Version, result.Keys)
+	}
+	if ref != nil && ref.Err == nil {
+		fmt.Printf("    %s: Redis %s (기존 클러스터 기준 노드)\n", ref.Address, ref.Version)
+	}
+
+	findings := evaluatePreflight(results, ref)
+	if len(findings) == 0 {
+		fmt.Printf("    %s\n", styles.RenderSuccess("문제 없음"))
+		return nil
+	}
+
+	blocked := 0
+	for _, finding := range findings {
+		if finding.Blocking || strict {
+			blocked++
+			fmt.Printf("    %s\n", styles.RenderError(finding.Message))
+		} else {
+			fmt.Printf("    %s\n", styles.RenderWarning(finding.Message))
+		}
+	}
+
+	if blocked > 0 {
+		if strict {
+			return fmt.Errorf("사전 점검 실패: %d개 문제 (--strict 모드에서는 경고도 중단합니다)", blocked)
+		}
+		return fmt.Errorf("사전 점검 실패: %d개 문제", blocked)
+	}
+	fmt.Println(styles.DescStyle.Render("    경고가 있으면 중단하려면 --strict를 사용하세요"))
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestEvaluatePreflight tests which node problems block and which only warn
+func TestEvaluatePreflight(t *testing.T) {
+	healthy := func(addr string) nodePreflight {
+		return nodePreflight{
+			Address:        addr,
+			Version:        "7.2.4",
+			ClusterEnabled: true,
+			Config: map[string]string{
+				"cluster-node-timeout":          "15000",
+				"maxmemory-policy":              "noeviction",
+				"cluster-require-full-coverage": "yes",
+			},
+			RequirePass: "secret",
+			MasterAuth:  "secret",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		edit       func(nodes []nodePreflight, ref *nodePreflight)
+		useRef     bool
+		blocking   int
+		warnings   int
+		wantInText string
+	}{
+		{"healthy", func([]nodePreflight, *nodePreflight) {}, false, 0, 0, ""},
+		{"keys present", func(n []nodePreflight, _ *nodePreflight) { n[1].Keys = 42 }, false, 1, 0, "키 42개"},
+		{"cluster disabled", func(n []nodePreflight, _ *nodePreflight) { n[0].ClusterEnabled = false }, false, 1, 0, "cluster-enabled no"},
+		{"unreachable", func(n []nodePreflight, _ *nodePreflight) { n[2].Err = errors.New("connection refused") }, false, 1, 0, "connection refused"},
+		{"too old", func(n []nodePreflight, _ *nodePreflight) {
+			for i := range n {
+				n[i].Version = "2.8.24"
+			}
+		}, false, 3, 0, "3.0 이상"},
+		{"major version mismatch", func(n []nodePreflight, _ *nodePreflight) { n[2].Version = "6.2.14" }, false, 0, 1, "6.x"},
+		{"minor version difference", func(n []nodePreflight, _ *nodePreflight) { n[2].Version = "7.0.15" }, false, 0, 0, ""},
+		{"timeout mismatch", func(n []nodePreflight, _ *nodePreflight) { n[1].Config["cluster-node-timeout"] = "5000" }, false, 0, 1, "cluster-node-timeout"},
+		{"requirepass mismatch", func(n []nodePreflight, _ *nodePreflight) {
+			n[1].RequirePass, n[1].MasterAuth = "other", "other"
+		}, false, 0, 1, "requirepass"},
+		{"masterauth missing", func(n []nodePreflight, _ *nodePreflight) { n[0].MasterAuth = "" }, false, 0, 1, "masterauth"},
+		{"config not allowed", func(n []nodePreflight, _ *nodePreflight) { n[0].Config = nil }, false, 0, 1, "CONFIG GET"},
+		{"reference policy differs", func(_ []nodePreflight, ref *nodePreflight) { ref.Config["maxmemory-policy"] = "allkeys-lru" }, true, 0, 1, "maxmemory-policy"},
+		{"reference keys are fine", func(_ []nodePreflight, ref *nodePreflight) { ref.Keys = 1000 }, true, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := []nodePreflight{healthy("10.0.0.1:7001"), healthy("10.0.0.2:7001"), healthy("10.0.0.3:7001")}
+			ref := healthy("10.0.0.9:7001")
+			tt.edit(nodes, &ref)
+
+			var reference *nodePreflight
+			if tt.useRef {
+				reference = &ref
+			}
+			findings := evaluatePreflight(nodes, reference)
+
+			blocking, warnings := 0, 0
+			var text []string
+			for _, f := range findings {
+				if f.Blocking {
+					blocking++
+				} else {
+					warnings++
+				}
+				text = append(text, f.Message)
+			}
+			if blocking != tt.blocking || warnings != tt.warnings {
+				t.Errorf("evaluatePreflight() = %d blocking, %d warnings, want %d, %d: %q", blocking, warnings, tt.blocking, tt.warnings, text)
+			}
+			if tt.wantInText != "" && !strings.Contains(strings.Join(text, "\n"), tt.wantInText) {
+				t.Errorf("findings %q do not mention %q", text, tt.wantInText)
+			}
+			if strings.Contains(strings.Join(text, "\n"), "secret") {
+				t.Errorf("findings leak the password: %q", text)
+			}
+		})
+	}
+}
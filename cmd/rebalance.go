@@ -2,16 +2,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 
 	"redisctl/internal/config"
+	"redisctl/internal/redis"
 	"redisctl/internal/styles"
 )
 
@@ -22,7 +25,7 @@ func NewRebalanceCommand() *cobra.Command {
 	var pipeline int
 
 	cmd := &cobra.Command{
-		Use:   "rebalance <cluster-node-ip:port>",
+		Use:   "rebalance <cluster-node-ip:port[,ip:port...]>",
 		Short: "r 클러스터의 슬롯 분배를 자동으로 균형 조정합니다",
 		Long: styles.TitleStyle.Render("[=] 클러스터 슬롯 자동 균형 조정") + "\n\n" +
 			styles.DescStyle.Render("Redis 클러스터의 슬롯 분배를 모든 마스터 노드에 균등하게 재분배합니다.") + "\n" +
@@ -44,12 +47,13 @@ func NewRebalanceCommand() *cobra.Command {
 
   # 파이프라인 크기 조정으로 성능 최적화
   redisctl rebalance --pipeline 20 localhost:7001`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
-			return runRebalanceCluster(args[0], dryRun, threshold, pipeline)
+			clusterAddr, _ := splitClusterArgs(args, 1)
+			return runRebalanceCluster(cmd.Context(), clusterAddr, dryRun, threshold, pipeline)
 		},
 	}
 
@@ -60,18 +64,6 @@ func NewRebalanceCommand() *cobra.Command {
 	return cmd
 }
 
-type MasterNode struct {
-	ID    string
-	Addr  string
-	Slots []int
-}
-
-type ReplicaNode struct {
-	ID       string
-	Addr     string
-	MasterID string
-}
-
 type RebalancePlan struct {
 	From      string
 	To        string
@@ -79,35 +71,39 @@ type RebalancePlan struct {
 	SlotCount int
 }
 
-func runRebalanceCluster(clusterAddr string, dryRun bool, threshold, pipeline int) error {
+func runRebalanceCluster(ctx context.Context, clusterAddr string, dryRun bool, threshold, pipeline int) error {
+	seeds, err := clusterSeeds(clusterAddr)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(styles.InfoStyle.Render("Redis 클러스터 슬롯 균형 조정"))
-	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(clusterAddr))
+	fmt.Printf("클러스터: %s\n", styles.HighlightStyle.Render(strings.Join(seeds, ", ")))
 	if dryRun {
 		fmt.Println(styles.WarningStyle.Render("드라이런 모드: 실제 변경 없이 계획만 표시"))
 	}
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 연결 실패: %w", err)
+	}
 	fmt.Println()
 
 	// Connect to cluster
-	user, password := config.GetAuth()
-	client := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:    []string{clusterAddr},
-		Username: user,
-		Password: password,
-	})
+	client := redis.NewClusterClient(selection.Addresses()...)
 	defer client.Close()
 
-	ctx := context.Background()
-
 	// Validate cluster connectivity
 	if err := validateRebalanceConnectivity(ctx, client); err != nil {
 		return fmt.Errorf("클러스터 연결 실패: %w", err)
 	}
 
 	// Get current slot distribution and cluster topology
-	masters, replicas, err := getCurrentClusterTopology(ctx, client)
+	topology, err := getCurrentClusterTopology(ctx, client)
 	if err != nil {
 		return fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
 	}
+	masters := topology.Masters()
 
 	// Validate that we have masters
 	if len(masters) == 0 {
@@ -120,7 +116,7 @@ func runRebalanceCluster(clusterAddr string, dryRun bool, threshold, pipeline in
 	}
 
 	// Check cluster health and provide recommendations
-	checkClusterTopology(masters, replicas)
+	checkClusterTopology(topology)
 
 	// Calculate current imbalance
 	imbalance := calculateImbalance(masters)
@@ -135,7 +131,7 @@ func runRebalanceCluster(clusterAddr string, dryRun bool, threshold, pipeline in
 	}
 
 	// Generate rebalancing plan
-	plan := generateRebalancePlan(masters)
+	plan := generateRebalancePlan(masters, nil)
 	if len(plan) == 0 {
 		fmt.Println(styles.SuccessStyle.Render("OK 리밸런싱이 필요하지 않습니다!"))
 		return nil
@@ -168,7 +164,7 @@ func runRebalanceCluster(clusterAddr string, dryRun bool, threshold, pipeline in
 	return nil
 }
 
-func validateRebalanceConnectivity(ctx context.Context, client *redis.ClusterClient) error {
+func validateRebalanceConnectivity(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("1. 클러스터 연결 확인..."))
 
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -180,7 +176,7 @@ func validateRebalanceConnectivity(ctx context.Context, client *redis.ClusterCli
 	return nil
 }
 
-func validateClusterForRebalancing(ctx context.Context, client *redis.ClusterClient) error {
+func validateClusterForRebalancing(ctx context.Context, client *redisv9.ClusterClient) error {
 	fmt.Print(styles.InfoStyle.Render("  클러스터 안전성 검증..."))
 
 	// Check cluster state
@@ -197,116 +193,62 @@ func validateClusterForRebalancing(ctx context.Context, client *redis.ClusterCli
 	}
 
 	// Check for ongoing cluster operations
-	nodes := client.ClusterNodes(ctx)
-	if nodes.Err() != nil {
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
 		fmt.Println(styles.ErrorStyle.Render(" 실패"))
-		return fmt.Errorf("클러스터 노드 조회 실패: %w", nodes.Err())
+		return fmt.Errorf("클러스터 노드 조회 실패: %w", err)
 	}
 
-	// Look for nodes in transitional states
-	lines := strings.Split(nodes.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		// Check for handshake, fail, or other problematic states
-		if strings.Contains(line, "handshake") || strings.Contains(line, "fail") {
+	// Look for nodes in transitional states (handshake, fail, loading)
+	for _, node := range topology.Nodes {
+		if node.HasFlag("handshake") || !node.IsHealthy() {
 			fmt.Println(styles.ErrorStyle.Render(" 실패"))
-			return fmt.Errorf("클러스터에 불안정한 노드가 있습니다. 리밸런싱 전에 해결하세요")
+			return fmt.Errorf("클러스터에 불안정한 노드가 있습니다 (%s). 리밸런싱 전에 해결하세요", node.HostPort())
 		}
+	}
 
-		// Check for ongoing slot migration (migrating/importing states)
-		if strings.Contains(line, "migrating") || strings.Contains(line, "importing") {
-			fmt.Println(styles.ErrorStyle.Render(" 실패"))
-			return fmt.Errorf("진행 중인 슬롯 마이그레이션이 있습니다. 완료 후 다시 시도하세요")
-		}
+	// Check for ongoing slot migration (migrating/importing states)
+	openSlots, err := redis.CollectOpenSlots(ctx, topology)
+	if err != nil {
+		fmt.Println(styles.ErrorStyle.Render(" 실패"))
+		return fmt.Errorf("열린 슬롯 확인 실패: %w", err)
+	}
+	if len(openSlots) > 0 {
+		fmt.Println(styles.ErrorStyle.Render(" 실패"))
+		return fmt.Errorf("진행 중이거나 중단된 슬롯 마이그레이션이 있습니다 (%d개 슬롯). 'redisctl fix'로 복구한 후 다시 시도하세요", len(redis.GroupOpenSlots(openSlots)))
 	}
 
 	fmt.Println(styles.SuccessStyle.Render(" 완료"))
 	return nil
 }
 
-func getCurrentSlotDistribution(ctx context.Context, client *redis.ClusterClient) ([]MasterNode, error) {
-	masters, _, err := getCurrentClusterTopology(ctx, client)
-	return masters, err
+func getCurrentSlotDistribution(ctx context.Context, client *redisv9.ClusterClient) ([]redis.ClusterNode, error) {
+	topology, err := getCurrentClusterTopology(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return topology.Masters(), nil
 }
 
-func getCurrentClusterTopology(ctx context.Context, client *redis.ClusterClient) ([]MasterNode, []ReplicaNode, error) {
+func getCurrentClusterTopology(ctx context.Context, client *redisv9.ClusterClient) (*redis.Topology, error) {
 	fmt.Print(styles.InfoStyle.Render("2. 클러스터 토폴로지 조회..."))
 
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
 		fmt.Println(styles.ErrorStyle.Render(" 실패"))
-		return nil, nil, result.Err()
-	}
-
-	var masters []MasterNode
-	var replicas []ReplicaNode
-	lines := strings.Split(result.Val(), "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) < 8 {
-			continue
-		}
-
-		// Check node type using unified utility
-		nodeFlags := parseNodeFlagsSlice(strings.Split(parts[2], ","))
-
-		if nodeFlags.IsMaster {
-			master := MasterNode{
-				ID:   parts[0],
-				Addr: parts[1],
-			}
-
-			// Parse slots (starting from index 8)
-			if len(parts) > 8 {
-				for i := 8; i < len(parts); i++ {
-					slotRange := parts[i]
-					if strings.Contains(slotRange, "-") {
-						// Range like "0-5460"
-						rangeParts := strings.Split(slotRange, "-")
-						if len(rangeParts) == 2 {
-							start, err1 := strconv.Atoi(rangeParts[0])
-							end, err2 := strconv.Atoi(rangeParts[1])
-							if err1 == nil && err2 == nil {
-								for slot := start; slot <= end; slot++ {
-									master.Slots = append(master.Slots, slot)
-								}
-							}
-						}
-					} else {
-						// Single slot
-						if slot, err := strconv.Atoi(slotRange); err == nil {
-							master.Slots = append(master.Slots, slot)
-						}
-					}
-				}
-			}
-
-			masters = append(masters, master)
-		} else if nodeFlags.IsReplica {
-			replica := ReplicaNode{
-				ID:       parts[0],
-				Addr:     parts[1],
-				MasterID: parts[3],
-			}
-			replicas = append(replicas, replica)
-		}
+		return nil, err
 	}
 
 	fmt.Println(styles.SuccessStyle.Render(" 완료"))
-	return masters, replicas, nil
+	return topology, nil
 }
 
-func checkClusterTopology(masters []MasterNode, replicas []ReplicaNode) {
+func checkClusterTopology(topology *redis.Topology) {
 	fmt.Println(styles.InfoStyle.Render("클러스터 토폴로지 분석"))
 
+	masters := topology.Masters()
+	replicas := topology.Replicas()
+
 	masterCount := len(masters)
 	replicaCount := len(replicas)
 
@@ -348,8 +290,10 @@ func checkClusterTopology(masters []MasterNode, replicas []ReplicaNode) {
 	// Check 3: Uneven replica distribution
 	if masterCount > 0 && replicaCount > 0 {
 		replicaPerMaster := make(map[string]int)
-		for _, replica := range replicas {
-			replicaPerMaster[replica.MasterID]++
+		for _, master := range masters {
+			if count := len(topology.ReplicasOf(master.ID)); count > 0 {
+				replicaPerMaster[master.ID] = count
+			}
 		}
 
 		maxReplicas := 0
@@ -403,7 +347,7 @@ func checkClusterTopology(masters []MasterNode, replicas []ReplicaNode) {
 	fmt.Println()
 }
 
-func calculateImbalance(masters []MasterNode) float64 {
+func calculateImbalance(masters []redis.ClusterNode) float64 {
 	if len(masters) == 0 {
 		return 0
 	}
@@ -412,7 +356,7 @@ func calculateImbalance(masters []MasterNode) float64 {
 	maxDeviation := 0
 
 	for _, master := range masters {
-		deviation := len(master.Slots) - idealSlots
+		deviation := master.SlotCount() - idealSlots
 		if deviation < 0 {
 			deviation = -deviation
 		}
@@ -424,23 +368,41 @@ func calculateImbalance(masters []MasterNode) float64 {
 	return float64(maxDeviation) / float64(idealSlots) * 100
 }
 
-func generateRebalancePlan(originalMasters []MasterNode) []RebalancePlan {
+// generateRebalancePlan plans slot moves from masters above their share to masters below it.
+// Shares are equal, or in proportion to weights by node ID (masters without a weight count as 1).
+func generateRebalancePlan(originalMasters []redis.ClusterNode, weights map[string]float64) []RebalancePlan {
 	if len(originalMasters) == 0 {
 		return nil
 	}
 
-	// Create a deep copy of masters to avoid modifying the original
-	masters := make([]MasterNode, len(originalMasters))
+	// Work on expanded slot lists so the topology is not modified
+	type planMaster struct {
+		ID    string
+		Slots []int
+	}
+	masters := make([]planMaster, len(originalMasters))
 	for i, master := range originalMasters {
-		masters[i] = MasterNode{
+		masters[i] = planMaster{
 			ID:    master.ID,
-			Addr:  master.Addr,
-			Slots: make([]int, len(master.Slots)),
+			Slots: master.SlotList(),
 		}
-		copy(masters[i].Slots, master.Slots)
 	}
 
-	idealSlots := 16384 / len(masters)
+	weightOf := func(id string) float64 {
+		if w, ok := weights[id]; ok {
+			return w
+		}
+		return 1
+	}
+	totalWeight := 0.0
+	for _, master := range masters {
+		totalWeight += weightOf(master.ID)
+	}
+	ideal := make(map[string]int, len(masters))
+	for _, master := range masters {
+		ideal[master.ID] = int(16384 * weightOf(master.ID) / totalWeight)
+	}
+
 	var plan []RebalancePlan
 
 	// Create a more efficient rebalancing plan
@@ -455,9 +417,9 @@ func generateRebalancePlan(originalMasters []MasterNode) []RebalancePlan {
 
 	for i, master := range masters {
 		slotCount := len(master.Slots)
-		if slotCount > idealSlots {
+		if slotCount > ideal[master.ID] {
 			donors = append(donors, i)
-		} else if slotCount < idealSlots {
+		} else if slotCount < ideal[master.ID] {
 			receivers = append(receivers, i)
 		}
 	}
@@ -470,8 +432,8 @@ func generateRebalancePlan(originalMasters []MasterNode) []RebalancePlan {
 		donor := &masters[donors[donorIdx]]
 		receiver := &masters[receivers[receiverIdx]]
 
-		excess := len(donor.Slots) - idealSlots
-		deficit := idealSlots - len(receiver.Slots)
+		excess := len(donor.Slots) - ideal[donor.ID]
+		deficit := ideal[receiver.ID] - len(receiver.Slots)
 
 		// Move the minimum of excess and deficit
 		slotsToMove := excess
@@ -502,10 +464,10 @@ func generateRebalancePlan(originalMasters []MasterNode) []RebalancePlan {
 		receiver.Slots = append(receiver.Slots, slotsToTransfer...)
 
 		// Check if donor or receiver is now balanced
-		if len(donor.Slots) <= idealSlots {
+		if len(donor.Slots) <= ideal[donor.ID] {
 			donorIdx++
 		}
-		if len(receiver.Slots) >= idealSlots {
+		if len(receiver.Slots) >= ideal[receiver.ID] {
 			receiverIdx++
 		}
 	}
@@ -513,19 +475,15 @@ func generateRebalancePlan(originalMasters []MasterNode) []RebalancePlan {
 	return plan
 }
 
-func displayRebalancePlan(plan []RebalancePlan, masters []MasterNode) {
+func displayRebalancePlan(plan []RebalancePlan, masters []redis.ClusterNode) {
 	fmt.Println(styles.TitleStyle.Render("리밸런싱 계획"))
 
 	// Show current distribution
 	fmt.Println(styles.InfoStyle.Render("현재 슬롯 분배:"))
 	for _, master := range masters {
-		addr := master.Addr
-		if strings.Contains(addr, "@") {
-			addr = strings.Split(addr, "@")[0]
-		}
 		fmt.Printf("  %s: %s 슬롯\n",
-			styles.HighlightStyle.Render(addr),
-			styles.HighlightStyle.Render(strconv.Itoa(len(master.Slots))))
+			styles.HighlightStyle.Render(master.Endpoint()),
+			styles.HighlightStyle.Render(strconv.Itoa(master.SlotCount())))
 	}
 
 	fmt.Println()
@@ -536,20 +494,13 @@ func displayRebalancePlan(plan []RebalancePlan, masters []MasterNode) {
 		toAddr := ""
 		for _, master := range masters {
 			if master.ID == p.From {
-				fromAddr = master.Addr
+				fromAddr = master.Endpoint()
 			}
 			if master.ID == p.To {
-				toAddr = master.Addr
+				toAddr = master.Endpoint()
 			}
 		}
 
-		if strings.Contains(fromAddr, "@") {
-			fromAddr = strings.Split(fromAddr, "@")[0]
-		}
-		if strings.Contains(toAddr, "@") {
-			toAddr = strings.Split(toAddr, "@")[0]
-		}
-
 		fmt.Printf("  %d. %s → %s: %s 슬롯\n",
 			i+1,
 			styles.WarningStyle.Render(fromAddr),
@@ -561,7 +512,7 @@ func displayRebalancePlan(plan []RebalancePlan, masters []MasterNode) {
 	fmt.Printf("\n총 이동할 슬롯: %s\n", styles.HighlightStyle.Render(strconv.Itoa(totalSlots)))
 }
 
-func executeRebalancePlan(ctx context.Context, client *redis.ClusterClient, plan []RebalancePlan, pipeline int) error {
+func executeRebalancePlan(ctx context.Context, client *redisv9.ClusterClient, plan []RebalancePlan, pipeline int) error {
 	fmt.Println()
 	fmt.Println(styles.InfoStyle.Render("3. 리밸런싱 실행 중..."))
 
@@ -571,15 +522,28 @@ func executeRebalancePlan(ctx context.Context, client *redis.ClusterClient, plan
 	}
 
 	processedSlots := 0
+	var movedSlots []int
 
 	for i, p := range plan {
+		if ctx.Err() != nil {
+			displayInterruptSummary(ctx, interruptTopology(ctx, client), movedSlots, totalSlots)
+			return ErrInterrupted
+		}
+
 		fmt.Printf("  %d/%d 단계: %d개 슬롯 이동 중... ",
 			i+1, len(plan), p.SlotCount)
 
 		startTime := time.Now()
 
 		// Use the reshard logic to move slots
-		if err := reshardSlots(ctx, client, p.From, p.To, p.Slots, pipeline); err != nil {
+		moved, err := reshardSlots(ctx, client, p.From, p.To, p.Slots, pipeline)
+		movedSlots = append(movedSlots, moved...)
+		if errors.Is(err, ErrInterrupted) {
+			fmt.Printf("중단됨 (%d/%d 슬롯)\n", len(moved), p.SlotCount)
+			displayInterruptSummary(ctx, interruptTopology(ctx, client), movedSlots, totalSlots)
+			return err
+		}
+		if err != nil {
 			// Provide more detailed error information
 			fmt.Printf("\n    X 실패: %v\n", err)
 			fmt.Printf("      부분 완료: %d/%d 슬롯 이동됨\n", processedSlots, totalSlots)
@@ -597,96 +561,128 @@ func executeRebalancePlan(ctx context.Context, client *redis.ClusterClient, plan
 	return nil
 }
 
-// Reuse reshard logic for slot migration
-func reshardSlots(ctx context.Context, client *redis.ClusterClient, fromID, toID string, slots []int, pipeline int) error {
+// Reuse reshard logic for slot migration.
+// Returns the slots that were fully moved; on interrupt the slot in flight is
+// finished first and ErrInterrupted is returned.
+func reshardSlots(ctx context.Context, client *redisv9.ClusterClient, fromID, toID string, slots []int, pipeline int) ([]int, error) {
+	topology, err := redis.LoadClusterTopology(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+	}
+
 	// Get source and target client connections
-	sourceClient, err := getNodeClient(ctx, client, fromID)
+	sourceClient, err := getNodeClient(ctx, topology, fromID)
 	if err != nil {
-		return fmt.Errorf("소스 노드 클라이언트 생성 실패: %w", err)
+		return nil, fmt.Errorf("소스 노드 클라이언트 생성 실패: %w", err)
 	}
 	defer sourceClient.Close()
 
-	targetClient, err := getNodeClient(ctx, client, toID)
+	targetClient, err := getNodeClient(ctx, topology, toID)
 	if err != nil {
-		return fmt.Errorf("대상 노드 클라이언트 생성 실패: %w", err)
+		return nil, fmt.Errorf("대상 노드 클라이언트 생성 실패: %w", err)
 	}
 	defer targetClient.Close()
 
+	if err := verifyMigrateTransport(ctx, sourceClient); err != nil {
+		return nil, err
+	}
+
 	// Get target node address
-	targetAddr, err := getNodeAddressFromCluster(ctx, client, toID)
-	if err != nil {
-		return fmt.Errorf("대상 노드 주소 조회 실패: %w", err)
+	targetNode, ok := topology.NodeByID(toID)
+	if !ok {
+		return nil, fmt.Errorf("대상 노드 주소 조회 실패: 노드 ID %s를 찾을 수 없습니다", toID)
 	}
+	targetAddr := targetNode.Endpoint()
 
-	parts := strings.Split(targetAddr, ":")
-	if len(parts) != 2 {
-		return fmt.Errorf("잘못된 노드 주소 형식: %s", targetAddr)
+	targetHost, targetPort, err := parseNodeAddress(targetAddr)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 노드 주소 형식: %s", targetAddr)
 	}
 
-	targetHost := parts[0]
-	targetPort := parts[1]
+	// 일시적 오류(IOERR, TRYAGAIN, 네트워크 등)는 재시도 정책에 따라 재시도
+	policy := redis.DefaultRetryPolicy()
+
+	var moved []int
+
+	// 실패한 슬롯은 열린 채로 두지 않는다: 되돌리거나 마무리한 뒤 양쪽 노드에서 확인
+	failSlot := func(slot int, err error) ([]int, error) {
+		owner, closeErr := closeFailedSlot(context.WithoutCancel(ctx), sourceClient, targetClient, slot, fromID, toID)
+		if closeErr == nil && owner == toID {
+			moved = append(moved, slot)
+		}
+		return moved, failedSlotError(slot, err, closeErr)
+	}
 
 	for _, slot := range slots {
+		if ctx.Err() != nil {
+			return moved, ErrInterrupted
+		}
+
+		// 진행 중인 슬롯은 중단 요청과 관계없이 끝까지 이동
+		slotCtx := context.WithoutCancel(ctx)
+
 		// Step 1: Set slot as migrating on source
-		err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "MIGRATING", toID).Err()
+		err := sourceClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "MIGRATING", toID).Err()
 		if err != nil {
-			return fmt.Errorf("MIGRATING 설정 실패 (슬롯 %d): %w", slot, err)
+			return failSlot(slot, fmt.Errorf("MIGRATING 설정 실패 (슬롯 %d): %w", slot, err))
 		}
 
 		// Step 2: Set slot as importing on target
-		err = targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "IMPORTING", fromID).Err()
+		err = targetClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "IMPORTING", fromID).Err()
 		if err != nil {
-			return fmt.Errorf("IMPORTING 설정 실패 (슬롯 %d): %w", slot, err)
+			return failSlot(slot, fmt.Errorf("IMPORTING 설정 실패 (슬롯 %d): %w", slot, err))
 		}
 
 		// Step 3: Migrate all keys in this slot - repeat until slot is empty
 		for {
 			// Get keys in the slot (limited by pipeline size)
-			keys := sourceClient.ClusterGetKeysInSlot(ctx, slot, pipeline)
-			if keys.Err() != nil {
-				return fmt.Errorf("슬롯 %d 키 조회 실패: %w", slot, keys.Err())
+			var keys []string
+			err := policy.Do(slotCtx, func() error {
+				var err error
+				keys, err = sourceClient.ClusterGetKeysInSlot(slotCtx, slot, pipeline).Result()
+				return err
+			})
+			if err != nil {
+				return failSlot(slot, fmt.Errorf("슬롯 %d 키 조회 실패: %w", slot, err))
 			}
 
 			// If no keys left, migration is complete
-			if len(keys.Val()) == 0 {
+			if len(keys) == 0 {
 				break
 			}
 
 			// Migrate keys in batches for better performance
-			if err := migrateKeysBatch(ctx, sourceClient, keys.Val(), targetHost, targetPort); err != nil {
-				return fmt.Errorf("키 배치 마이그레이션 실패 (슬롯 %d): %w", slot, err)
+			if err := migrateKeysBatch(slotCtx, sourceClient, keys, targetHost, targetPort, policy); err != nil {
+				return failSlot(slot, fmt.Errorf("키 배치 마이그레이션 실패 (슬롯 %d): %w", slot, err))
 			}
 		}
 
 		// Step 4: Set slot to stable state on both nodes
-		err = sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", toID).Err()
+		err = sourceClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "NODE", toID).Err()
 		if err != nil {
-			return fmt.Errorf("소스 노드 슬롯 상태 설정 실패 (슬롯 %d): %w", slot, err)
+			return failSlot(slot, fmt.Errorf("소스 노드 슬롯 상태 설정 실패 (슬롯 %d): %w", slot, err))
 		}
 
-		err = targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", toID).Err()
+		err = targetClient.Do(slotCtx, "CLUSTER", "SETSLOT", slot, "NODE", toID).Err()
 		if err != nil {
-			return fmt.Errorf("대상 노드 슬롯 상태 설정 실패 (슬롯 %d): %w", slot, err)
+			return failSlot(slot, fmt.Errorf("대상 노드 슬롯 상태 설정 실패 (슬롯 %d): %w", slot, err))
 		}
+
+		moved = append(moved, slot)
 	}
 
-	return nil
+	return moved, nil
 }
 
-func getNodeClient(ctx context.Context, client *redis.ClusterClient, nodeID string) (*redis.Client, error) {
+func getNodeClient(ctx context.Context, topology *redis.Topology, nodeID string) (*redisv9.Client, error) {
 	// Get node address
-	addr, err := getNodeAddressFromCluster(ctx, client, nodeID)
-	if err != nil {
-		return nil, err
+	node, ok := topology.NodeByID(nodeID)
+	if !ok {
+		return nil, fmt.Errorf("노드 ID %s를 찾을 수 없습니다", nodeID)
 	}
 
 	// Create individual client for this node
-	user, password := config.GetAuth()
-	nodeClient := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Username: user,
-		Password: password,
-	})
+	nodeClient := redis.NewNodeClient(node.Endpoint())
 
 	// Test connection
 	if err := nodeClient.Ping(ctx).Err(); err != nil {
@@ -697,64 +693,49 @@ func getNodeClient(ctx context.Context, client *redis.ClusterClient, nodeID stri
 	return nodeClient, nil
 }
 
-func getNodeAddressFromCluster(ctx context.Context, client *redis.ClusterClient, nodeID string) (string, error) {
-	result := client.ClusterNodes(ctx)
-	if result.Err() != nil {
-		return "", result.Err()
-	}
-
-	lines := strings.Split(result.Val(), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) >= 2 && parts[0] == nodeID {
-			addr := parts[1]
-			if strings.Contains(addr, "@") {
-				addr = strings.Split(addr, "@")[0]
-			}
-			return addr, nil
-		}
-	}
-
-	return "", fmt.Errorf("노드 ID %s를 찾을 수 없습니다", nodeID)
-}
-
 // migrateKeysBatch migrates multiple keys efficiently using pipelining
-func migrateKeysBatch(ctx context.Context, sourceClient *redis.Client, keys []string, targetHost, targetPort string) error {
+func migrateKeysBatch(ctx context.Context, sourceClient *redisv9.Client, keys []string, targetHost, targetPort string, policy redis.RetryPolicy) error {
 	if len(keys) == 0 {
 		return nil
 	}
 
-	user, password := config.GetAuth()
+	// MIGRATE AUTH2에는 대상 노드가 실제로 받아들이는 인증 정보를 사용
+	targetCred := redis.CredentialFor(net.JoinHostPort(targetHost, targetPort))
 
 	// Use pipeline for better performance when migrating multiple keys
 	pipeline := sourceClient.Pipeline()
 
-	for _, key := range keys {
-		var migrateCmd []any
-		if user != "" {
-			// AUTH2 format: MIGRATE host port key destination-db timeout [COPY | REPLACE] [AUTH2 username password]
-			migrateCmd = []any{"MIGRATE", targetHost, targetPort, key, 0, 60000, "AUTH2", user, password}
-		} else {
-			// AUTH format: MIGRATE host port key destination-db timeout [COPY | REPLACE] [AUTH password]
-			migrateCmd = []any{"MIGRATE", targetHost, targetPort, key, 0, 60000, "AUTH", password}
-		}
-		pipeline.Do(ctx, migrateCmd...)
+	migrateCmds := make([][]any, len(keys))
+	for i, key := range keys {
+		// MIGRATE host port key destination-db timeout [AUTH password | AUTH2 username password]
+		migrateCmds[i] = buildMigrateCommandForReshard(targetHost, targetPort, key, targetCred.User, targetCred.Password)
+		pipeline.Do(ctx, migrateCmds[i]...)
 	}
 
 	// Execute all migrations in one batch
+	// Exec reports the first failed command; per-key results are checked below
 	results, err := pipeline.Exec(ctx)
-	if err != nil {
+	if err != nil && len(results) != len(keys) {
 		return fmt.Errorf("파이프라인 실행 실패: %w", err)
 	}
 
-	// Check individual results
+	// Check individual results - transient failures are retried key by key
 	for i, result := range results {
-		if result.Err() != nil {
-			return fmt.Errorf("키 %s 마이그레이션 실패: %w", keys[i], result.Err())
+		err := result.Err()
+		if err == nil {
+			continue
+		}
+
+		if kind := redis.ClassifyError(err); kind.Retryable() {
+			// 파이프라인에서 이미 한 번 보냈으므로 재시도는 모두 REPLACE를 붙인다
+			err = migrateWithRetry(ctx, policy, migrateCmds[i], true, func(args ...any) error {
+				return sourceClient.Do(ctx, args...).Err()
+			})
+		} else {
+			err = &redis.RetryError{Kind: kind, Attempts: 1, Err: err}
+		}
+		if err != nil {
+			return fmt.Errorf("키 %s 마이그레이션 실패: %w", keys[i], err)
 		}
 	}
 
@@ -6,7 +6,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"redisctl/internal/config"
@@ -23,7 +25,7 @@ func NewReshardCommand() *cobra.Command {
 	var slots, pipeline int
 
 	cmd := &cobra.Command{
-		Use:   "reshard --from str --to str --slots N [--pipeline N] ip:port",
+		Use:   "reshard --from str --to str --slots N [--pipeline N] ip:port[,ip:port...]",
 		Short: "s 마스터 간 슬롯을 이동합니다",
 		Long: styles.TitleStyle.Render("[R] 클러스터 리샤딩") + "\n\n" +
 			styles.DescStyle.Render("MIGRATE 명령을 사용하여 마스터 노드 간 N개의 슬롯을 이동합니다.") + "\n" +
@@ -33,13 +35,14 @@ func NewReshardCommand() *cobra.Command {
 
   # 파이프라인 크기 조정하여 성능 최적화
   redisctl reshard --from source-id --to target-id --slots 500 --pipeline 20 localhost:7001`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := config.ValidateAuth(); err != nil {
 				return err
 			}
 
-			return runReshard(args[0], from, to, slots, pipeline)
+			clusterNode, _ := splitClusterArgs(args, 1)
+			return runReshard(cmd.Context(), clusterNode, from, to, slots, pipeline)
 		},
 	}
 
@@ -55,9 +58,14 @@ func NewReshardCommand() *cobra.Command {
 	return cmd
 }
 
-func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineSize int) error {
+func runReshard(ctx context.Context, clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineSize int) error {
+	seeds, err := clusterSeeds(clusterNode)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(styles.InfoStyle.Render("리샤딩 시작..."))
-	fmt.Printf("클러스터 노드: %s\n", clusterNode)
+	fmt.Printf("클러스터 노드: %s\n", strings.Join(seeds, ", "))
 	fmt.Printf("소스 마스터: %s\n", fromNodeID)
 	fmt.Printf("대상 마스터: %s\n", toNodeID)
 	fmt.Printf("이동할 슬롯: %d개\n", slotsToMove)
@@ -72,16 +80,21 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 	}
 
 	user, password := config.GetAuth()
-	cm := redis.NewClusterManager(user, password)
+	cm := redis.NewClusterManager(ctx, user, password)
 	defer cm.Close()
 
-	ctx := context.Background()
-
 	// Step 1: Connect to cluster and validate
 	fmt.Println(styles.InfoStyle.Render("1단계: 클러스터 연결 및 검증 중..."))
+
+	selection, err := selectSeed(ctx, seeds)
+	if err != nil {
+		return fmt.Errorf("클러스터 노드 연결 실패: %w", err)
+	}
+	clusterNode = selection.Address
+
 	fmt.Printf("  %s 연결 중...", clusterNode)
 
-	_, err := cm.Connect(clusterNode)
+	_, err = cm.Connect(clusterNode)
 	if err != nil {
 		fmt.Printf(" %s\n", styles.RenderError("연결 실패"))
 		return fmt.Errorf("클러스터 노드 연결 실패: %w", err)
@@ -99,41 +112,33 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 	// Step 2: Get cluster nodes and validate source/target
 	fmt.Println(styles.InfoStyle.Render("2단계: 노드 정보 조회 및 검증 중..."))
 
-	clusterNodes, err := cm.GetClusterNodes(clusterNode)
+	topology, err := cm.LoadTopology(clusterNode)
 	if err != nil {
 		return fmt.Errorf("클러스터 노드 정보 조회 실패: %w", err)
 	}
 
-	var sourceNode, targetNode *redis.ClusterNode
-	for i, node := range clusterNodes {
-		if node.ID == fromNodeID {
-			sourceNode = &clusterNodes[i]
-		}
-		if node.ID == toNodeID {
-			targetNode = &clusterNodes[i]
-		}
-	}
-
 	// Validate source node
-	if sourceNode == nil {
+	sourceNode, ok := topology.NodeByID(fromNodeID)
+	if !ok {
 		return fmt.Errorf("소스 노드 ID를 찾을 수 없습니다: %s", fromNodeID)
 	}
 
-	if !isMasterNode(sourceNode.Flags) {
+	if !sourceNode.IsMaster() {
 		return fmt.Errorf("소스 노드가 마스터가 아닙니다: %s", fromNodeID)
 	}
 
-	sourceSlotCount := countSlots(sourceNode.Slots)
+	sourceSlotCount := sourceNode.SlotCount()
 	if sourceSlotCount < slotsToMove {
 		return fmt.Errorf("소스 노드의 슬롯 수(%d)가 이동하려는 슬롯 수(%d)보다 적습니다", sourceSlotCount, slotsToMove)
 	}
 
 	// Validate target node
-	if targetNode == nil {
+	targetNode, ok := topology.NodeByID(toNodeID)
+	if !ok {
 		return fmt.Errorf("대상 노드 ID를 찾을 수 없습니다: %s", toNodeID)
 	}
 
-	if !isMasterNode(targetNode.Flags) {
+	if !targetNode.IsMaster() {
 		return fmt.Errorf("대상 노드가 마스터가 아닙니다: %s", toNodeID)
 	}
 
@@ -141,8 +146,8 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 		return fmt.Errorf("소스와 대상 노드가 동일합니다")
 	}
 
-	fmt.Printf("  소스: %s (%d개 슬롯)\n", sourceNode.Address, sourceSlotCount)
-	fmt.Printf("  대상: %s (%d개 슬롯)\n", targetNode.Address, countSlots(targetNode.Slots))
+	fmt.Printf("  소스: %s (%d개 슬롯)\n", sourceNode.HostPort(), sourceSlotCount)
+	fmt.Printf("  대상: %s (%d개 슬롯)\n", targetNode.HostPort(), targetNode.SlotCount())
 
 	// Step 3: Select slots to move
 	fmt.Println(styles.InfoStyle.Render("3단계: 이동할 슬롯 선택 중..."))
@@ -157,9 +162,8 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 	// Step 4: Prepare for migration
 	fmt.Println(styles.InfoStyle.Render("4단계: 마이그레이션 준비 중..."))
 
-	// Normalize addresses before connecting
-	sourceAddr := normalizeClusterAddress(sourceNode.Address)
-	targetAddr := normalizeClusterAddress(targetNode.Address)
+	sourceAddr := sourceNode.Endpoint()
+	targetAddr := targetNode.Endpoint()
 
 	sourceClient, err := cm.Connect(sourceAddr)
 	if err != nil {
@@ -171,6 +175,10 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 		return fmt.Errorf("대상 노드 연결 실패: %w", err)
 	}
 
+	if err := verifyMigrateTransport(ctx, sourceClient); err != nil {
+		return err
+	}
+
 	// Parse target address for MIGRATE commands
 	targetHost, targetPort, err := parseNodeAddress(targetAddr)
 	if err != nil {
@@ -183,22 +191,29 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 	var migratedSlots []int // 롤백을 위한 추적
 
 	for i, slot := range slotsToMigrate {
+		// 중단 요청은 슬롯 사이에서만 처리 (진행 중인 슬롯은 끝까지 이동)
+		if ctx.Err() != nil {
+			displayInterruptSummary(ctx, topology, migratedSlots, len(slotsToMigrate))
+			return ErrInterrupted
+		}
+
 		fmt.Printf("  [%d/%d] 슬롯 %d 마이그레이션 중...", i+1, len(slotsToMigrate), slot)
 
-		err := migrateSlot(ctx, sourceClient, targetClient, slot, targetHost, targetPort, pipelineSize, fromNodeID, toNodeID)
+		slotCtx := context.WithoutCancel(ctx)
+		err := migrateSlot(slotCtx, sourceClient, targetClient, slot, targetHost, targetPort, pipelineSize, fromNodeID, toNodeID)
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("실패"))
 			// 롤백 수행
-			rollbackResharding(ctx, cm, migratedSlots, fromNodeID, toNodeID)
+			rollbackResharding(slotCtx, cm, sourceClient, targetClient, migratedSlots, slot, fromNodeID, toNodeID)
 			return fmt.Errorf("슬롯 %d 마이그레이션 실패: %w", slot, err)
 		}
 
 		// Update all nodes in cluster with new slot ownership
-		err = updateAllNodesSlotOwnership(ctx, cm, clusterNode, slot, toNodeID)
+		err = updateAllNodesSlotOwnership(slotCtx, cm.WithContext(slotCtx), clusterNode, slot, toNodeID)
 		if err != nil {
 			fmt.Printf(" %s\n", styles.RenderError("클러스터 업데이트 실패"))
 			// 롤백 수행
-			rollbackResharding(ctx, cm, migratedSlots, fromNodeID, toNodeID)
+			rollbackResharding(slotCtx, cm, sourceClient, targetClient, migratedSlots, slot, fromNodeID, toNodeID)
 			return fmt.Errorf("슬롯 %d 클러스터 업데이트 실패: %w", slot, err)
 		}
 
@@ -213,6 +228,12 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 		}
 	}
 
+	// 마지막 슬롯 처리 중 중단 요청이 들어온 경우 검증 단계는 생략
+	if ctx.Err() != nil {
+		displayInterruptSummary(ctx, topology, migratedSlots, len(slotsToMigrate))
+		return ErrInterrupted
+	}
+
 	// Step 6: Verify migration (동적 대기로 개선)
 	fmt.Println(styles.InfoStyle.Render("6단계: 마이그레이션 검증 중..."))
 
@@ -225,22 +246,13 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 		fmt.Printf(" %s\n", styles.SuccessStyle.Render("완료"))
 	}
 
-	updatedNodes, err := cm.GetClusterNodes(clusterNode)
+	updatedTopology, err := cm.LoadTopology(clusterNode)
 	if err != nil {
 		return fmt.Errorf("검증을 위한 클러스터 정보 조회 실패: %w", err)
 	}
-
-	var updatedSource, updatedTarget *redis.ClusterNode
-	for i, node := range updatedNodes {
-		if node.ID == fromNodeID {
-			updatedSource = &updatedNodes[i]
-		}
-		if node.ID == toNodeID {
-			updatedTarget = &updatedNodes[i]
-		}
-	}
-
-	if updatedSource == nil || updatedTarget == nil {
+	updatedSource, sourceOK := updatedTopology.NodeByID(fromNodeID)
+	updatedTarget, targetOK := updatedTopology.NodeByID(toNodeID)
+	if !sourceOK || !targetOK {
 		return fmt.Errorf("업데이트된 노드 정보를 찾을 수 없습니다")
 	}
 
@@ -253,9 +265,9 @@ func runReshard(clusterNode, fromNodeID, toNodeID string, slotsToMove, pipelineS
 	summary := styles.SubtitleStyle.Render("마이그레이션 요약") + "\n" +
 		fmt.Sprintf("• 이동된 슬롯 수: %d개\n", len(slotsToMigrate)) +
 		fmt.Sprintf("• 소스 노드 (%s): %d개 → %d개 슬롯\n",
-			updatedSource.Address, sourceSlotCount, countSlots(updatedSource.Slots)) +
+			updatedSource.HostPort(), sourceSlotCount, updatedSource.SlotCount()) +
 		fmt.Sprintf("• 대상 노드 (%s): %d개 → %d개 슬롯\n",
-			updatedTarget.Address, countSlots(targetNode.Slots), countSlots(updatedTarget.Slots)) +
+			updatedTarget.HostPort(), targetNode.SlotCount(), updatedTarget.SlotCount()) +
 		fmt.Sprintf("• 파이프라인 크기: %d\n", pipelineSize)
 
 	fmt.Println(styles.BoxStyle.Render(summary))
@@ -276,10 +288,20 @@ func migrateSlot(ctx context.Context, sourceClient, targetClient *redisv9.Client
 		return fmt.Errorf("IMPORTING 설정 실패: %w", err)
 	}
 
+	// 일시적 오류(IOERR, TRYAGAIN, 네트워크 등)는 재시도 정책에 따라 재시도
+	policy := redis.DefaultRetryPolicy()
+	// MIGRATE AUTH2에는 대상 노드가 실제로 받아들이는 인증 정보를 사용
+	targetCred := redis.CredentialFor(net.JoinHostPort(targetHost, targetPort))
+
 	// Migrate all keys in the slot - repeat until slot is empty
 	for {
 		// Get keys in the slot (limited by pipeline size)
-		keys, err := sourceClient.ClusterGetKeysInSlot(ctx, slot, pipelineSize).Result()
+		var keys []string
+		err := policy.Do(ctx, func() error {
+			var err error
+			keys, err = sourceClient.ClusterGetKeysInSlot(ctx, slot, pipelineSize).Result()
+			return err
+		})
 		if err != nil {
 			return fmt.Errorf("슬롯 키 조회 실패: %w", err)
 		}
@@ -291,11 +313,11 @@ func migrateSlot(ctx context.Context, sourceClient, targetClient *redisv9.Client
 
 		// Migrate each key in this batch (AUTH 명령어 통합)
 		for _, key := range keys {
-			// Use simplified AUTH command building
-			user, password := config.GetAuth()
-			migrateCmd := buildMigrateCommandForReshard(targetHost, targetPort, key, user, password)
+			migrateCmd := buildMigrateCommandForReshard(targetHost, targetPort, key, targetCred.User, targetCred.Password)
 
-			err = sourceClient.Do(ctx, migrateCmd...).Err()
+			err = migrateWithRetry(ctx, policy, migrateCmd, false, func(args ...any) error {
+				return sourceClient.Do(ctx, args...).Err()
+			})
 			if err != nil {
 				return fmt.Errorf("MIGRATE 명령 실패 (키: %s): %w", key, err)
 			}
@@ -320,17 +342,16 @@ func migrateSlot(ctx context.Context, sourceClient, targetClient *redisv9.Client
 // updateAllNodesSlotOwnership ensures all cluster nodes know about slot ownership change
 func updateAllNodesSlotOwnership(ctx context.Context, cm *redis.ClusterManager, clusterNode string, slot int, targetNodeID string) error {
 	// Get all cluster nodes
-	allNodes, err := cm.GetClusterNodes(clusterNode)
+	topology, err := cm.LoadTopology(clusterNode)
 	if err != nil {
 		return fmt.Errorf("클러스터 노드 목록 조회 실패: %w", err)
 	}
-
 	var errors []string
 	successCount := 0
 
 	// Update every single node in the cluster
-	for _, node := range allNodes {
-		nodeAddr := normalizeClusterAddress(node.Address)
+	for _, node := range topology.Nodes {
+		nodeAddr := node.Endpoint()
 		nodeClient, err := cm.Connect(nodeAddr)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("노드 %s 연결 실패: %v", nodeAddr, err))
@@ -371,30 +392,136 @@ func waitForClusterStableForReshard(ctx context.Context, cm *redis.ClusterManage
 		if err == nil && info["cluster_state"] == "ok" {
 			return nil
 		}
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
 	}
 	return fmt.Errorf("클러스터가 %v 내에 안정화되지 않았습니다", maxWait)
 }
 
 // 리샤딩 롤백 함수
-func rollbackResharding(ctx context.Context, cm *redis.ClusterManager, migratedSlots []int, fromNodeID, toNodeID string) {
+// 실패한 슬롯은 열린 상태로 남기지 않도록 닫은 뒤 양쪽 노드에서 확인하고,
+// 이미 이동된 슬롯은 목록만 보고한다
+func rollbackResharding(ctx context.Context, cm *redis.ClusterManager, sourceClient, targetClient *redisv9.Client, migratedSlots []int, failedSlot int, fromNodeID, toNodeID string) {
+	fmt.Println(styles.WarningStyle.Render("리샤딩 실패 - 롤백 중..."))
+
+	fmt.Printf("  실패한 슬롯 %d 정리 중...", failedSlot)
+	wantOwner, err := closeFailedSlot(ctx, sourceClient, targetClient, failedSlot, fromNodeID, toNodeID)
+	if err != nil {
+		fmt.Printf(" %s\n", styles.RenderError("실패"))
+		fmt.Printf("  경고: 슬롯 %d이 열린 상태로 남았을 수 있습니다: %v\n", failedSlot, err)
+		fmt.Println("  'redisctl fix <노드>'로 열린 슬롯을 복구하세요")
+	} else if wantOwner == toNodeID {
+		fmt.Printf(" %s\n", styles.RenderSuccess("대상 노드로 이동 마무리"))
+		migratedSlots = append(migratedSlots, failedSlot)
+	} else {
+		fmt.Printf(" %s\n", styles.RenderSuccess("소스 노드로 되돌림"))
+	}
+
 	if len(migratedSlots) == 0 {
 		return
 	}
 
-	fmt.Println(styles.WarningStyle.Render("리샤딩 실패 - 롤백 중..."))
-
-	// 이미 이동된 슬롯들을 원래 노드로 되돌리기
-	// 일단 경고만 출력
-	// TODO: 자동으로 롤백?
+	// 이미 이동된 슬롯은 되돌리지 않는다 (다시 실행하면 남은 슬롯만 이동)
 	fmt.Printf("  경고: %d개 슬롯이 부분적으로 이동되었습니다\n", len(migratedSlots))
 	fmt.Println("  수동으로 클러스터 상태를 확인하고 필요시 슬롯을 재조정하세요")
 	fmt.Printf("  이동된 슬롯: %v\n", formatSlotRanges(migratedSlots))
 }
 
+// revertInFlightSlot closes the slot whose migration failed and returns the node
+// that should own it. While the target holds no keys for the slot, MIGRATING and
+// IMPORTING are cleared on both ends so the slot stays where it was. Once every key
+// has reached the target the migration is finished instead. Keys on both sides
+// cannot be resolved safely here and are left to 'redisctl fix'.
+func revertInFlightSlot(ctx context.Context, sourceClient, targetClient *redisv9.Client, slot int, sourceNodeID, targetNodeID string) (string, error) {
+	sourceKeys, err := sourceClient.ClusterCountKeysInSlot(ctx, slot).Result()
+	if err != nil {
+		return "", fmt.Errorf("소스 노드 키 수 조회 실패: %w", err)
+	}
+	targetKeys, err := targetClient.ClusterCountKeysInSlot(ctx, slot).Result()
+	if err != nil {
+		return "", fmt.Errorf("대상 노드 키 수 조회 실패: %w", err)
+	}
+
+	switch {
+	case targetKeys == 0:
+		// 대상 노드부터 IMPORTING을 해제해야 소스 노드의 ASK 리다이렉트가 끊기지 않는다
+		if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "STABLE").Err(); err != nil {
+			return "", fmt.Errorf("대상 노드 SETSLOT STABLE 실패: %w", err)
+		}
+		if err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "STABLE").Err(); err != nil {
+			return "", fmt.Errorf("소스 노드 SETSLOT STABLE 실패: %w", err)
+		}
+		if sourceKeys == 0 {
+			// 빈 슬롯은 소유권이 이미 넘어갔을 수 있으므로 양쪽이 합의한 소유자를 그대로 둔다
+			return "", nil
+		}
+		return sourceNodeID, nil
+	case sourceKeys == 0:
+		if err := targetClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
+			return "", fmt.Errorf("대상 노드 슬롯 할당 실패: %w", err)
+		}
+		if err := sourceClient.Do(ctx, "CLUSTER", "SETSLOT", slot, "NODE", targetNodeID).Err(); err != nil {
+			return "", fmt.Errorf("소스 노드 슬롯 할당 실패: %w", err)
+		}
+		return targetNodeID, nil
+	default:
+		return "", fmt.Errorf("소스(%d개)와 대상(%d개) 노드 모두 키를 가지고 있습니다", sourceKeys, targetKeys)
+	}
+}
+
+// closeFailedSlot reverts or finishes the slot whose migration failed, then checks
+// on both ends that it is closed. It returns the node both ends agree owns the slot.
+func closeFailedSlot(ctx context.Context, sourceClient, targetClient *redisv9.Client, slot int, sourceNodeID, targetNodeID string) (string, error) {
+	wantOwner, err := revertInFlightSlot(ctx, sourceClient, targetClient, slot, sourceNodeID, targetNodeID)
+	if err != nil {
+		return "", err
+	}
+	return verifySlotClosed(ctx, []*redisv9.Client{sourceClient, targetClient}, slot, wantOwner)
+}
+
+// failedSlotError adds the outcome of closeFailedSlot to the migration error of slot
+func failedSlotError(slot int, err, closeErr error) error {
+	if closeErr != nil {
+		return fmt.Errorf("%w (슬롯 %d 정리 실패, 열린 상태일 수 있음: %v - 'redisctl fix <노드>'로 복구하세요)", err, slot, closeErr)
+	}
+	return fmt.Errorf("%w (슬롯 %d는 정리되어 열린 상태로 남지 않았습니다)", err, slot)
+}
+
+// verifySlotClosed checks on each node that slot is no longer open and that all of
+// them agree on its owner (wantOwner, unless empty). It returns that owner.
+func verifySlotClosed(ctx context.Context, clients []*redisv9.Client, slot int, wantOwner string) (string, error) {
+	owner := wantOwner
+	for _, client := range clients {
+		addr := client.Options().Addr
+		topology, err := redis.LoadTopology(ctx, client)
+		if err != nil {
+			return "", fmt.Errorf("노드 %s 확인 실패: %w", addr, err)
+		}
+		if myself, ok := topology.Myself(); ok {
+			for _, open := range myself.OpenSlots {
+				if open.Slot == slot {
+					return "", fmt.Errorf("노드 %s에서 슬롯 %d이 아직 %s 상태입니다", addr, slot, open.State)
+				}
+			}
+		}
+		node, ok := topology.SlotOwner(slot)
+		if !ok {
+			return "", fmt.Errorf("노드 %s에서 슬롯 %d의 소유자가 없습니다", addr, slot)
+		}
+		if owner == "" {
+			owner = node.ID
+		}
+		if node.ID != owner {
+			return "", fmt.Errorf("노드 %s가 슬롯 %d의 소유자를 %s로 보고 있습니다 (예상: %s)", addr, slot, node.ID, owner)
+		}
+	}
+	return owner, nil
+}
+
 // MIGRATE 명령어 통합 구성 함수 (reshard용)
 func buildMigrateCommandForReshard(targetHost, targetPort, key, user, password string) []interface{} {
-	baseCmd := []any{"MIGRATE", targetHost, targetPort, key, 0, 60000}
+	baseCmd := []any{"MIGRATE", targetHost, targetPort, key, 0, redis.MigrateTimeout().Milliseconds()}
 
 	if user != "" {
 		return append(baseCmd, "AUTH2", user, password)
@@ -405,14 +532,6 @@ func buildMigrateCommandForReshard(targetHost, targetPort, key, user, password s
 	return baseCmd
 }
 
-func countSlots(slots []redis.SlotRange) int {
-	count := 0
-	for _, slotRange := range slots {
-		count += slotRange.End - slotRange.Start + 1
-	}
-	return count
-}
-
 func selectSlotsToMove(availableSlots []redis.SlotRange, count int) []int {
 	var selected []int
 
@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"redisctl/internal/config"
+	"redisctl/internal/redis"
+	"redisctl/internal/sandbox"
+	"redisctl/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+// sandboxOptions are the flags of 'sandbox up'
+type sandboxOptions struct {
+	masters     int
+	replicas    int
+	spare       int
+	basePort    int
+	redisServer string
+}
+
+// NewSandboxCommand 'sandbox' 명령어
+func NewSandboxCommand() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "sandbox",
+		Short: "s 로컬 테스트용 클러스터를 실행합니다",
+		Long: styles.TitleStyle.Render("[S] 로컬 샌드박스 클러스터") + "\n\n" +
+			styles.DescStyle.Render("로컬 redis-server 프로세스로 테스트용 클러스터를 만들고 관리합니다.") + "\n" +
+			styles.DescStyle.Render("설정 파일 생성, 인증 설정, 프로세스 실행, 'create'와 같은 로직의 클러스터 구성까지 한 번에 수행합니다.") + "\n\n" +
+			styles.DescStyle.Render("• up - 노드 설정 생성, 실행, 클러스터 구성 (중지된 노드는 다시 시작)") + "\n" +
+			styles.DescStyle.Render("• status - 노드별 프로세스, 역할, 슬롯 표시") + "\n" +
+			styles.DescStyle.Render("• logs <port> - 노드 로그 표시") + "\n" +
+			styles.DescStyle.Render("• kill <port> - 노드 강제 종료 (장애 시뮬레이션)") + "\n" +
+			styles.DescStyle.Render("• down - 모든 노드 종료 및 샌드박스 삭제"),
+		Example: `  # 3 마스터 + 3 복제본 (포트 9001-9006)
+  redisctl sandbox up --masters 3 --replicas 1 --base-port 9001
+
+  # test.md 시나리오: 클러스터 6개 + add-node용 예비 노드 2개 (9007, 9008)
+  redisctl sandbox up --masters 3 --replicas 1 --spare 2 --base-port 9001
+
+  # 샌드박스 비밀번호로 다른 명령 실행
+  redisctl --password-file redis-sandbox/password check localhost:9001
+
+  # 마스터 장애 시뮬레이션 후 다시 시작
+  redisctl sandbox kill 9001
+  redisctl sandbox up
+
+  # 종료 및 삭제
+  redisctl sandbox down`,
+	}
+
+	cmd.PersistentFlags().StringVar(&dir, "dir", sandbox.DefaultDir, "샌드박스 디렉터리 (설정, 데이터, 로그)")
+
+	cmd.AddCommand(
+		newSandboxUpCommand(&dir),
+		newSandboxDownCommand(&dir),
+		newSandboxStatusCommand(&dir),
+		newSandboxLogsCommand(&dir),
+		newSandboxKillCommand(&dir),
+	)
+	return cmd
+}
+
+func newSandboxUpCommand(dir *string) *cobra.Command {
+	var opts sandboxOptions
+
+	cmd := &cobra.Command{
+		Use:   "up [--masters N] [--replicas N] [--spare N] [--base-port PORT]",
+		Short: "노드를 실행하고 클러스터를 구성합니다",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// 기존 샌드박스와 다른 레이아웃을 명시하면 섞이지 않도록 중단
+			if sandbox.Exists(*dir) {
+				s, err := sandbox.Load(*dir)
+				if err != nil {
+					return err
+				}
+				flags := cmd.Flags()
+				if (flags.Changed("masters") && opts.masters != s.Masters) ||
+					(flags.Changed("replicas") && opts.replicas != s.Replicas) ||
+					(flags.Changed("spare") && opts.spare != s.Spare) ||
+					(flags.Changed("base-port") && opts.basePort != s.BasePort) {
+					return fmt.Errorf("%s에 다른 레이아웃의 샌드박스가 있습니다 (마스터 %d, 복제본 %d, 포트 %d-). 'redisctl sandbox down' 후 다시 실행하세요",
+						*dir, s.Masters, s.Replicas, s.BasePort)
+				}
+			}
+			return runSandboxUp(cmd.Context(), *dir, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.masters, "masters", 3, "마스터 수 (최소 3)")
+	cmd.Flags().IntVar(&opts.replicas, "replicas", 1, "마스터당 복제본 수")
+	cmd.Flags().IntVar(&opts.spare, "spare", 0, "클러스터에 넣지 않고 실행만 할 예비 노드 수 (add-node 테스트용)")
+	cmd.Flags().IntVar(&opts.basePort, "base-port", 9001, "첫 노드 포트 (이후 연속 포트 사용)")
+	cmd.Flags().StringVar(&opts.redisServer, "redis-server", "redis-server", "redis-server 실행 파일")
+	return cmd
+}
+
+func newSandboxDownCommand(dir *string) *cobra.Command {
+	var keep bool
+
+	cmd := &cobra.Command{
+		Use:   "down [--keep]",
+		Short: "모든 노드를 종료하고 샌드박스를 삭제합니다",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxDown(*dir, keep)
+		},
+	}
+
+	cmd.Flags().BoolVar(&keep, "keep", false, "노드만 종료하고 설정/데이터는 유지 (다시 up으로 시작)")
+	return cmd
+}
+
+func newSandboxStatusCommand(dir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "노드별 프로세스, 역할, 슬롯을 표시합니다",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxStatus(cmd.Context(), *dir)
+		},
+	}
+}
+
+func newSandboxLogsCommand(dir *string) *cobra.Command {
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "logs <port> [-n N]",
+		Short: "노드 로그를 표시합니다",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, port, err := loadSandboxPort(*dir, args[0])
+			if err != nil {
+				return err
+			}
+			logLines, err := s.TailLog(port, lines)
+			if err != nil {
+				return err
+			}
+			fmt.Println(styles.DescStyle.Render(s.LogPath(port)))
+			fmt.Println(strings.Join(logLines, "\n"))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "마지막 N줄 (0: 전체)")
+	return cmd
+}
+
+func newSandboxKillCommand(dir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "kill <port>",
+		Short: "노드를 강제 종료합니다 (SIGKILL, 장애 시뮬레이션)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, port, err := loadSandboxPort(*dir, args[0])
+			if err != nil {
+				return err
+			}
+			pid, alive := s.PID(port)
+			if !alive {
+				fmt.Println(styles.RenderWarning(fmt.Sprintf("노드 %d는 실행 중이 아닙니다", port)))
+				return nil
+			}
+			if err := s.Stop(port, syscall.SIGKILL); err != nil {
+				return err
+			}
+			fmt.Println(styles.RenderSuccess(fmt.Sprintf("노드 %d (PID %d) 강제 종료", port, pid)))
+			fmt.Println(styles.DescStyle.Render("다시 시작하려면 'redisctl sandbox up'을 실행하세요"))
+			return nil
+		},
+	}
+}
+
+// loadSandboxPort loads the sandbox and checks that port is one of its nodes
+func loadSandboxPort(dir, arg string) (*sandbox.Sandbox, int, error) {
+	s, err := sandbox.Load(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	port, err := strconv.Atoi(arg)
+	if err != nil || !s.Has(port) {
+		return nil, 0, fmt.Errorf("샌드박스 노드 포트가 아닙니다: %s (포트 %d-%d)", arg, s.BasePort, s.LastPort())
+	}
+	return s, port, nil
+}
+
+// runSandboxUp creates the sandbox if needed, starts every stopped node and forms the cluster
+// with the create logic unless the nodes already form one
+func runSandboxUp(ctx context.Context, dir string, opts sandboxOptions) error {
+	var s *sandbox.Sandbox
+	var err error
+
+	if sandbox.Exists(dir) {
+		s, err = sandbox.Load(dir)
+		if err != nil {
+			return err
+		}
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("기존 샌드박스 시작: %s", dir)))
+	} else {
+		s, err = sandbox.New(dir, opts.masters, opts.replicas, opts.spare, opts.basePort, opts.redisServer)
+		if err != nil {
+			return err
+		}
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("샌드박스 생성: %s", dir)))
+	}
+
+	if _, err := exec.LookPath(s.RedisServer); err != nil {
+		return fmt.Errorf("redis-server를 찾을 수 없습니다 (%s): --redis-server로 경로를 지정하세요", s.RedisServer)
+	}
+
+	if !sandbox.Exists(dir) {
+		// 전역 비밀번호가 있으면 사용하고, 없으면 임의로 생성
+		_, password := config.GetAuth()
+		if err := s.Init(password); err != nil {
+			return err
+		}
+	}
+
+	password, err := s.Password()
+	if err != nil {
+		return err
+	}
+	config.SetAuth("", password)
+
+	fmt.Printf("마스터 %d개, 마스터당 복제본 %d개, 예비 노드 %d개, 포트 %d-%d\n", s.Masters, s.Replicas, s.Spare, s.BasePort, s.LastPort())
+	fmt.Println()
+
+	fmt.Println(styles.InfoStyle.Render("노드 시작 중..."))
+	for _, port := range s.Ports() {
+		if pid, alive := s.PID(port); alive {
+			fmt.Printf("  %d: %s\n", port, styles.DescStyle.Render(fmt.Sprintf("실행 중 (PID %d)", pid)))
+			continue
+		}
+		fmt.Printf("  %d:", port)
+		if err := s.Start(ctx, port); err != nil {
+			fmt.Printf(" %s\n", styles.RenderError("실패"))
+			return err
+		}
+		pid, _ := s.PID(port)
+		fmt.Printf(" %s\n", styles.RenderSuccess(fmt.Sprintf("시작됨 (PID %d)", pid)))
+	}
+	fmt.Println()
+
+	cm := redis.NewClusterManager(ctx, "", password)
+	defer cm.Close()
+
+	// 이미 구성된 클러스터(재시작)는 그대로 둔다
+	formed := false
+	if info, err := cm.GetClusterInfo(s.Address(s.BasePort)); err == nil {
+		known, _ := strconv.Atoi(info["cluster_known_nodes"])
+		formed = known > 1
+	}
+
+	// 이전 up에서 생성이 중단되었으면 기록된 단계부터 이어서 진행
+	progressFile := filepath.Join(s.Dir(), "create-progress.json")
+	if _, err := os.Stat(progressFile); err == nil {
+		if err := runCreateCluster(ctx, nil, createOptions{resume: true, progressFile: progressFile}); err != nil {
+			return err
+		}
+	} else if formed {
+		fmt.Println(styles.RenderSuccess("클러스터가 이미 구성되어 있습니다"))
+		if err := waitForClusterStable(ctx, cm, s.Address(s.BasePort), 30*time.Second); err != nil {
+			fmt.Println(styles.RenderWarning(fmt.Sprintf("클러스터 상태가 아직 ok가 아닙니다: %v", err)))
+		}
+	} else {
+		if err := runCreateCluster(ctx, s.ClusterAddresses(), createOptions{replicas: s.Replicas, progressFile: progressFile}); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	summary := styles.SubtitleStyle.Render("샌드박스") + "\n" +
+		fmt.Sprintf("• 클러스터 노드: %s\n", strings.Join(s.ClusterAddresses(), ", ")) +
+		spareText(s) +
+		fmt.Sprintf("• 비밀번호 파일: %s\n", s.PasswordFile()) +
+		fmt.Sprintf("• 사용 예: redisctl --password-file %s check %s\n", s.PasswordFile(), s.Address(s.BasePort)) +
+		"• 상태/로그: redisctl sandbox status, redisctl sandbox logs <port>\n" +
+		"• 종료: redisctl sandbox down"
+	fmt.Println(styles.BoxStyle.Render(summary))
+	return nil
+}
+
+// spareText lists the spare nodes for the summary, empty when there are none
+func spareText(s *sandbox.Sandbox) string {
+	spare := s.Addresses()[len(s.ClusterAddresses()):]
+	if len(spare) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("• 예비 노드 (add-node용): %s\n", strings.Join(spare, ", "))
+}
+
+// runSandboxDown stops every node and removes the sandbox unless keep
+func runSandboxDown(dir string, keep bool) error {
+	s, err := sandbox.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.InfoStyle.Render("노드 종료 중..."))
+	failed := 0
+	for _, port := range s.Ports() {
+		pid, alive := s.PID(port)
+		if !alive {
+			fmt.Printf("  %d: %s\n", port, styles.DescStyle.Render("중지됨"))
+			continue
+		}
+		fmt.Printf("  %d (PID %d):", port, pid)
+		if err := s.Stop(port, syscall.SIGTERM); err != nil {
+			failed++
+			fmt.Printf(" %s\n", styles.RenderError(err.Error()))
+			continue
+		}
+		fmt.Printf(" %s\n", styles.RenderSuccess("종료"))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d개 노드를 종료하지 못했습니다", failed)
+	}
+
+	if keep {
+		fmt.Println(styles.DescStyle.Render(fmt.Sprintf("설정과 데이터를 유지합니다: %s ('redisctl sandbox up'으로 다시 시작)", dir)))
+		return nil
+	}
+	if err := s.Remove(); err != nil {
+		return fmt.Errorf("샌드박스 삭제 실패: %w", err)
+	}
+	fmt.Println(styles.RenderSuccess(fmt.Sprintf("샌드박스 삭제: %s", dir)))
+	return nil
+}
+
+// runSandboxStatus shows the process, role and slots of every node
+func runSandboxStatus(ctx context.Context, dir string) error {
+	s, err := sandbox.Load(dir)
+	if err != nil {
+		return err
+	}
+	password, err := s.Password()
+	if err != nil {
+		return err
+	}
+
+	cm := redis.NewClusterManager(ctx, "", password)
+	defer cm.Close()
+
+	// 실행 중인 첫 노드의 관점으로 역할/슬롯을 표시
+	var topology *redis.Topology
+	var clusterState string
+	for _, port := range s.Ports() {
+		if _, alive := s.PID(port); !alive {
+			continue
+		}
+		if topology, err = cm.LoadTopology(s.Address(port)); err == nil {
+			if info, err := cm.GetClusterInfo(s.Address(port)); err == nil {
+				clusterState = info["cluster_state"]
+			}
+			break
+		}
+	}
+
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("샌드박스 %s", dir)))
+	fmt.Printf("%-7s %-8s %-10s %-8s %s\n", "포트", "PID", "프로세스", "역할", "슬롯")
+	for _, port := range s.Ports() {
+		pid, alive := s.PID(port)
+		pidText, process := "-", styles.RenderError("중지됨")
+		if alive {
+			pidText, process = strconv.Itoa(pid), styles.RenderSuccess("실행 중")
+		}
+
+		role, slots := "-", "-"
+		if port > s.BasePort+len(s.ClusterAddresses())-1 {
+			role = "예비"
+		}
+		if topology != nil {
+			if node, ok := topology.NodeByAddress(s.Address(port)); ok {
+				role = "마스터"
+				if node.IsReplica() {
+					role = "복제본"
+				}
+				if node.IsFail() {
+					role += " (fail)"
+				}
+				slots = strconv.Itoa(node.SlotCount())
+			}
+		}
+		fmt.Printf("%-7d %-8s %-10s %-8s %s\n", port, pidText, process, role, slots)
+	}
+
+	fmt.Println()
+	switch {
+	case topology == nil:
+		fmt.Println(styles.RenderWarning("실행 중인 노드에서 클러스터 정보를 얻지 못했습니다"))
+	case clusterState == "ok":
+		fmt.Printf("클러스터 상태: %s\n", styles.RenderSuccess(clusterState))
+	default:
+		fmt.Printf("클러스터 상태: %s\n", styles.RenderError(clusterState))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"redisctl/internal/config"
+	"redisctl/internal/redis"
+	"redisctl/internal/sandbox"
+)
+
+// 로컬 redis-server로 샌드박스 클러스터를 띄워 실제 명령 로직을 검증한다.
+// redis-server가 없거나 -short이면 건너뛴다. 포트는 REDISCTL_SANDBOX_BASE_PORT (기본 19001)부터 사용한다.
+
+// startTestSandbox brings up a 3 master / 3 replica sandbox with spare nodes and tears it down when the test ends
+func startTestSandbox(t *testing.T, spare int) (*sandbox.Sandbox, *redis.ClusterManager) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("통합 테스트는 -short에서 건너뜁니다")
+	}
+	if _, err := exec.LookPath("redis-server"); err != nil {
+		t.Skip("redis-server가 없어 통합 테스트를 건너뜁니다")
+	}
+
+	basePort := 19001
+	if value := os.Getenv("REDISCTL_SANDBOX_BASE_PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			t.Fatalf("REDISCTL_SANDBOX_BASE_PORT: %v", err)
+		}
+		basePort = port
+	}
+
+	config.Init()
+	config.SetLabelOptions(nil, "", filepath.Join(t.TempDir(), "inventory.yaml"))
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	t.Cleanup(func() {
+		if err := runSandboxDown(dir, false); err != nil {
+			t.Errorf("sandbox down: %v", err)
+		}
+	})
+
+	opts := sandboxOptions{masters: 3, replicas: 1, spare: spare, basePort: basePort, redisServer: "redis-server"}
+	if err := runSandboxUp(ctx, dir, opts); err != nil {
+		t.Fatalf("sandbox up: %v", err)
+	}
+
+	s, err := sandbox.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, err := s.Password()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := redis.NewClusterManager(ctx, "", password)
+	t.Cleanup(func() { cm.Close() })
+	return s, cm
+}
+
+// TestSandboxIntegrationCreate tests that sandbox up forms a healthy cluster and is idempotent
+func TestSandboxIntegrationCreate(t *testing.T) {
+	s, cm := startTestSandbox(t, 0)
+	seed := s.Address(s.BasePort)
+
+	info, err := cm.GetClusterInfo(seed)
+	if err != nil || info["cluster_state"] != "ok" {
+		t.Fatalf("cluster_state = %q, %v", info["cluster_state"], err)
+	}
+
+	topology, err := cm.LoadTopology(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masters := topology.Masters()
+	if len(masters) != 3 {
+		t.Fatalf("masters = %d, want 3", len(masters))
+	}
+	for _, master := range masters {
+		if replicas := topology.ReplicasOf(master.ID); len(replicas) != 1 {
+			t.Errorf("master %s has %d replicas, want 1", master.HostPort(), len(replicas))
+		}
+	}
+	if covered := topology.CoveredSlots(); covered != redis.ClusterSlots {
+		t.Errorf("covered slots = %d, want %d", covered, redis.ClusterSlots)
+	}
+
+	// 두 번째 up은 실행 중인 노드와 구성된 클러스터를 그대로 둔다
+	if err := runSandboxUp(context.Background(), s.Dir(), sandboxOptions{}); err != nil {
+		t.Fatalf("second sandbox up: %v", err)
+	}
+	again, err := cm.LoadTopology(seed)
+	if err != nil || len(again.Nodes) != len(topology.Nodes) {
+		t.Errorf("second up changed the cluster: %d nodes, %v", len(again.Nodes), err)
+	}
+}
+
+// TestSandboxIntegrationReshard tests that keys follow slots moved by the reshard logic
+func TestSandboxIntegrationReshard(t *testing.T) {
+	s, cm := startTestSandbox(t, 0)
+	ctx := context.Background()
+
+	client := redis.NewClusterClient(s.ClusterAddresses()...)
+	defer client.Close()
+
+	for i := 0; i < 500; i++ {
+		if err := client.Set(ctx, fmt.Sprintf("key:%d", i), i, 0).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	topology, err := cm.LoadTopology(s.Address(s.BasePort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	masters := topology.Masters()
+	from, to := masters[0], masters[1]
+	slots := from.SlotList()[:100]
+
+	moved, err := reshardSlots(ctx, client, from.ID, to.ID, slots, 10)
+	if err != nil || len(moved) != len(slots) {
+		t.Fatalf("reshardSlots() moved %d/%d: %v", len(moved), len(slots), err)
+	}
+
+	after, err := cm.LoadTopology(s.Address(s.BasePort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, slot := range slots {
+		if owner, ok := after.SlotOwner(slot); !ok || owner.ID != to.ID {
+			t.Fatalf("slot %d owner = %s, want %s", slot, owner.ID, to.ID)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		if value, err := client.Get(ctx, fmt.Sprintf("key:%d", i)).Int(); err != nil || value != i {
+			t.Fatalf("key:%d = %d, %v", i, value, err)
+		}
+	}
+}
+
+// TestSandboxIntegrationFailover tests that killing a master promotes its replica and up restarts it
+func TestSandboxIntegrationFailover(t *testing.T) {
+	s, cm := startTestSandbox(t, 0)
+	ctx := context.Background()
+
+	topology, err := cm.LoadTopology(s.Address(s.BasePort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	master, ok := topology.NodeByAddress(s.Address(s.BasePort))
+	if !ok || !master.IsMaster() {
+		t.Fatalf("node %d is not a master", s.BasePort)
+	}
+	replica := topology.ReplicasOf(master.ID)[0]
+
+	if err := s.Stop(s.BasePort, syscall.SIGKILL); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		current, err := cm.LoadTopology(replica.HostPort())
+		if err == nil {
+			if node, ok := current.NodeByID(replica.ID); ok && node.IsMaster() && node.SlotCount() > 0 {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("replica %s was not promoted within 30s", replica.HostPort())
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	// 다시 up하면 종료된 노드가 재시작되어 클러스터에 복귀
+	if err := runSandboxUp(ctx, s.Dir(), sandboxOptions{}); err != nil {
+		t.Fatalf("sandbox up after kill: %v", err)
+	}
+	if _, alive := s.PID(s.BasePort); !alive {
+		t.Errorf("node %d was not restarted", s.BasePort)
+	}
+}
+
+// TestSandboxIntegrationAddNode tests that add-node joins a new shard through a correct MEET
+func TestSandboxIntegrationAddNode(t *testing.T) {
+	s, cm := startTestSandbox(t, 2)
+	ctx := context.Background()
+
+	seed := s.Address(s.BasePort)
+	master, replica := s.Address(s.LastPort()-1), s.Address(s.LastPort())
+	opts := addNodeOptions{replicaOf: map[string]string{replica: master}}
+	if err := runAddNode(ctx, []string{master, replica}, seed, opts); err != nil {
+		t.Fatalf("add-node: %v", err)
+	}
+
+	// 모든 기존 노드가 새 노드를 handshake 없이 알아야 한다
+	for _, addr := range s.ClusterAddresses() {
+		topology, err := cm.LoadTopology(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newMaster, ok := topology.NodeByAddress(master)
+		if !ok || !newMaster.IsMaster() || newMaster.IsHandshake() {
+			t.Fatalf("%s does not list %s as a joined master", addr, master)
+		}
+		newReplica, ok := topology.NodeByAddress(replica)
+		if !ok || newReplica.Master != newMaster.ID {
+			t.Errorf("%s does not list %s as a replica of %s", addr, replica, master)
+		}
+	}
+}
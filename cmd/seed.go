@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"redisctl/internal/config"
+	"redisctl/internal/redis"
+	"redisctl/internal/styles"
+)
+
+// clusterSeeds returns the seed list: the cluster address argument (comma-separated)
+// followed by --seed / REDIS_SEEDS values, without duplicates.
+func clusterSeeds(addrArg string) ([]string, error) {
+	seeds, err := redis.ParseSeeds(append([]string{addrArg}, config.GetSeeds()...)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("클러스터 노드 주소가 필요합니다 (ip:port[,ip:port...] 또는 --seed)")
+	}
+	return seeds, nil
+}
+
+// splitClusterArgs separates the optional leading cluster address from the remaining
+// positional arguments. want is the argument count when the address is given;
+// with one argument less the address must come from --seed.
+func splitClusterArgs(args []string, want int) (string, []string) {
+	if len(args) >= want {
+		return args[0], args[1:]
+	}
+	return "", args
+}
+
+// selectSeed picks the entry node from the seeds and shows which seed was used
+func selectSeed(ctx context.Context, seeds []string) (*redis.SeedSelection, error) {
+	selection, err := redis.SelectSeed(ctx, seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시드 하나가 정상 응답하면 기존 출력 그대로
+	if len(seeds) == 1 && selection.Consistent {
+		return selection, nil
+	}
+
+	for _, attempt := range selection.Attempts {
+		switch {
+		case attempt.Err != nil:
+			fmt.Printf("  시드 %s: %s\n", attempt.Address, styles.RenderError(fmt.Sprintf("연결 실패 (%v)", attempt.Err)))
+		case attempt.Address == selection.Address:
+			fmt.Printf("  시드 %s: %s\n", attempt.Address, styles.RenderSuccess("선택됨"))
+		default:
+			fmt.Printf("  시드 %s: %s\n", attempt.Address, styles.RenderWarning(fmt.Sprintf("건너뜀 (%s)", attempt.Reason)))
+		}
+	}
+
+	fmt.Printf("사용한 시드: %s\n", styles.HighlightStyle.Render(selection.Address))
+	if !selection.Consistent {
+		fmt.Println(styles.WarningStyle.Render("  일관된 클러스터 뷰를 가진 시드가 없어 처음 응답한 노드를 사용합니다"))
+	}
+
+	return selection, nil
+}
@@ -5,46 +5,46 @@ TODO: TUI 나 GUI 면 sync.Pool?
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"redisctl/internal/config"
 	"redisctl/internal/redis"
 	"strconv"
 	"strings"
 	"time"
+
+	redisv9 "github.com/redis/go-redis/v9"
 )
 
-// normalizeClusterAddress normalizes a cluster node address by removing bus port and converting localhost
+// normalizeClusterAddress normalizes a cluster node address by removing bus port/hostname and converting localhost.
+// With --tls the Redis 7.2 tls-port aux field is preferred so that ad-hoc connections hit the TLS listener.
 func normalizeClusterAddress(address string) string {
-	// Remove @busport if present (format: ip:port@busport)
-	if idx := strings.Index(address, "@"); idx != -1 {
-		address = address[:idx]
-	}
-
-	// Parse and normalize the address
-	host, port, err := parseNodeAddress(address)
+	addr, err := redis.ParseAddress(address)
 	if err != nil {
-		return address // riginal
+		return address // 원래 값 유지
 	}
 
-	return fmt.Sprintf("%s:%s", host, port)
+	return addr.Endpoint(config.IsTLSEnabled())
 }
 
+// parseNodeAddress splits an address into host and port.
+// IPv6 hosts are returned without brackets ([::1]:7000 -> "::1", "7000").
 func parseNodeAddress(address string) (string, string, error) {
-	parts := strings.Split(address, ":")
-	if len(parts) != 2 {
-		return "", "", fmt.Errorf("잘못된 주소 형식: %s", address)
+	addr, err := redis.ParseAddress(address)
+	if err != nil {
+		return "", "", err
 	}
 
-	host := parts[0]
-	if host == "" || host == "localhost" {
-		host = "127.0.0.1"
-	}
+	return addr.Host, addr.PortString(), nil
+}
 
-	port := parts[1]
-	if _, err := strconv.Atoi(port); err != nil {
-		return "", "", fmt.Errorf("잘못된 포트: %s", port)
+// isValidNodeAddress reports whether a CLUSTER NODES address carries a usable host and port
+func isValidNodeAddress(address string) bool {
+	if address == "" || strings.HasPrefix(address, ":") {
+		return false
 	}
-
-	return host, port, nil
+	_, err := redis.ParseAddress(address)
+	return err == nil
 }
 
 func formatNumber(n int64) string {
@@ -63,79 +63,89 @@ func formatNumber(n int64) string {
 }
 
 // 클러스터 상태 동적 확인
-func waitForClusterStable(cm *redis.ClusterManager, node string, maxWait time.Duration) error {
+func waitForClusterStable(ctx context.Context, cm *redis.ClusterManager, node string, maxWait time.Duration) error {
 	start := time.Now()
 	for time.Since(start) < maxWait {
 		info, err := cm.GetClusterInfo(node)
 		if err == nil && info["cluster_state"] == "ok" {
 			return nil
 		}
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
 	}
 	return fmt.Errorf("클러스터가 %v 내에 안정화되지 않았습니다", maxWait)
 }
 
-// NodeFlags represents parsed Redis node flags
-type NodeFlags struct {
-	IsMaster    bool
-	IsReplica   bool
-	IsFail      bool
-	IsHandshake bool
-	IsNoAddr    bool
-	IsNoFlags   bool
+// sleepContext waits for d, returning ErrInterrupted early when ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ErrInterrupted
+	case <-timer.C:
+		return nil
+	}
 }
 
-// parseNodeFlags parses Redis cluster node flags into a structured format
-func parseNodeFlags(flagsStr string) NodeFlags {
-	if flagsStr == "" {
-		return NodeFlags{IsNoFlags: true}
+// verifyMigrateTransport checks that MIGRATE can reach TLS-only targets.
+// MIGRATE connections are opened by the source server itself, so with --tls
+// the source must run with tls-cluster yes; otherwise it dials the TLS port in plaintext.
+func verifyMigrateTransport(ctx context.Context, sourceClient *redisv9.Client) error {
+	if !config.IsTLSEnabled() {
+		return nil
 	}
 
-	flags := strings.Split(flagsStr, ",")
-	var nodeFlags NodeFlags
-
-	for _, flag := range flags {
-		switch strings.TrimSpace(flag) {
-		case "master":
-			nodeFlags.IsMaster = true
-		case "slave":
-			nodeFlags.IsReplica = true
-		case "fail":
-			nodeFlags.IsFail = true
-		case "handshake":
-			nodeFlags.IsHandshake = true
-		case "noaddr":
-			nodeFlags.IsNoAddr = true
-		}
+	result, err := sourceClient.ConfigGet(ctx, "tls-cluster").Result()
+	if err != nil {
+		// CONFIG가 비활성화/rename된 환경에서는 검증을 건너뜀
+		return nil
 	}
 
-	return nodeFlags
+	if value, ok := result["tls-cluster"]; ok && value != "yes" {
+		return fmt.Errorf("TLS 클러스터에서 MIGRATE를 사용하려면 소스 노드에 'tls-cluster yes' 설정이 필요합니다 (현재: %s)", value)
+	}
+	return nil
 }
 
-// parseNodeFlagsSlice parses Redis cluster node flags from a slice
-func parseNodeFlagsSlice(flags []string) NodeFlags {
-	if len(flags) == 0 {
-		return NodeFlags{IsNoFlags: true}
+// migrateWithRetry sends one MIGRATE and retries transient errors per policy.
+// A MIGRATE that failed with IOERR or a network error may already have copied the
+// key to the target, so retries add REPLACE: the source copy stays authoritative
+// until MIGRATE deletes it, and a plain retry would stop with BUSYKEY.
+// retried is set when the first attempt was already sent elsewhere (e.g. in a pipeline).
+func migrateWithRetry(ctx context.Context, policy redis.RetryPolicy, migrateCmd []any, retried bool, send func(args ...any) error) error {
+	return policy.DoAttempt(ctx, func(attempt int) error {
+		return send(withMigrateReplace(migrateCmd, retried || attempt > 0)...)
+	})
+}
+
+// withMigrateReplace adds REPLACE after the timeout argument of a MIGRATE command
+func withMigrateReplace(migrateCmd []any, replace bool) []any {
+	if !replace || len(migrateCmd) < 6 {
+		return migrateCmd
 	}
+	args := make([]any, 0, len(migrateCmd)+1)
+	args = append(args, migrateCmd[:6]...)
+	args = append(args, "REPLACE")
+	return append(args, migrateCmd[6:]...)
+}
 
-	var nodeFlags NodeFlags
-
-	for _, flag := range flags {
-		switch strings.TrimSpace(flag) {
-		case "master":
-			nodeFlags.IsMaster = true
-		case "slave":
-			nodeFlags.IsReplica = true
-		case "fail":
-			nodeFlags.IsFail = true
-		case "handshake":
-			nodeFlags.IsHandshake = true
-		case "noaddr":
-			nodeFlags.IsNoAddr = true
-		}
+// NodeFlags represents parsed Redis node flags
+type NodeFlags = redis.NodeFlags
+
+// parseNodeFlags parses Redis cluster node flags into a structured format
+func parseNodeFlags(flagsStr string) NodeFlags {
+	if flagsStr == "" {
+		return NodeFlags{IsNoFlags: true}
 	}
+	return redis.ParseNodeFlags(strings.Split(flagsStr, ","))
+}
 
-	return nodeFlags
+// parseNodeFlagsSlice parses Redis cluster node flags from a slice
+func parseNodeFlagsSlice(flags []string) NodeFlags {
+	return redis.ParseNodeFlags(flags)
 }
 
 // isMasterNode checks if node flags indicate a master node
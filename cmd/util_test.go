@@ -1,7 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"testing"
+	"time"
+
+	"redisctl/internal/config"
+	"redisctl/internal/redis"
 )
 
 // TestNormalizeClusterAddress tests the address normalization function
@@ -49,7 +57,32 @@ func TestNormalizeClusterAddress(t *testing.T) {
 		{
 			name:     "ipv6 localhost",
 			input:    "[::1]:7001",
-			expected: "[::1]:7001", // IPv6 not supported
+			expected: "[::1]:7001",
+		},
+		{
+			name:     "ipv6 with bus port",
+			input:    "[::1]:7001@17001",
+			expected: "[::1]:7001",
+		},
+		{
+			name:     "unbracketed ipv6 from cluster nodes",
+			input:    "2001:db8::1:7001@17001",
+			expected: "[2001:db8::1]:7001",
+		},
+		{
+			name:     "redis 7 announced hostname",
+			input:    "10.0.0.1:7001@17001,redis-1.example.com",
+			expected: "10.0.0.1:7001",
+		},
+		{
+			name:     "redis 7.2 aux fields without tls",
+			input:    "10.0.0.1:7001@17001,,shard-id=abc123,tls-port=7101",
+			expected: "10.0.0.1:7001",
+		},
+		{
+			name:     "noaddr node",
+			input:    ":0@0",
+			expected: ":0@0",
 		},
 	}
 
@@ -125,6 +158,47 @@ func TestParseNodeAddress(t *testing.T) {
 			input:       "192.168.1.100:",
 			shouldError: true,
 		},
+		{
+			name:         "bracketed ipv6",
+			input:        "[::1]:7001",
+			expectedHost: "::1",
+			expectedPort: "7001",
+			shouldError:  false,
+		},
+		{
+			name:         "unbracketed ipv6 from cluster nodes",
+			input:        "fe80::1:7001@17001",
+			expectedHost: "fe80::1",
+			expectedPort: "7001",
+			shouldError:  false,
+		},
+		{
+			name:         "bus port and announced hostname",
+			input:        "10.0.0.5:7000@17000,node-a.example.com",
+			expectedHost: "10.0.0.5",
+			expectedPort: "7000",
+			shouldError:  false,
+		},
+		{
+			name:        "ipv6 without port",
+			input:       "[::1]",
+			shouldError: true,
+		},
+		{
+			name:        "colons in non-ipv6 host",
+			input:       "redis:master:7000",
+			shouldError: true,
+		},
+		{
+			name:        "port out of range",
+			input:       "192.168.1.100:70000",
+			shouldError: true,
+		},
+		{
+			name:        "noaddr node",
+			input:       ":0@0",
+			shouldError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,6 +228,130 @@ func TestParseNodeAddress(t *testing.T) {
 	}
 }
 
+// TestSleepContext tests that waits return early on interrupt
+func TestSleepContext(t *testing.T) {
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("sleepContext() = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := sleepContext(ctx, time.Minute)
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("sleepContext() after cancel = %v, want ErrInterrupted", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("sleepContext() did not return early after cancel")
+	}
+}
+
+// fakeRedisError mimics a server error reply
+type fakeRedisError string
+
+func (e fakeRedisError) Error() string { return string(e) }
+func (fakeRedisError) RedisError()     {}
+
+// TestMigrateWithRetry tests that a MIGRATE retried after IOERR does not stop on BUSYKEY
+// when the failed attempt already copied the key to the target
+func TestMigrateWithRetry(t *testing.T) {
+	policy := redis.RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	migrateCmd := buildMigrateCommandForReshard("127.0.0.1", "7002", "user:1", "", "secret")
+
+	hasReplace := func(args []any) bool {
+		for _, arg := range args {
+			if arg == "REPLACE" {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		name      string
+		retried   bool
+		onTarget  bool // key already on the target before the first attempt
+		firstErr  error
+		wantErr   bool
+		wantCalls int
+	}{
+		{"ioerr after copy then retry", false, false, fakeRedisError("IOERR error or timeout reading to target instance"), false, 2},
+		{"network error after copy then retry", false, false, io.EOF, false, 2},
+		{"pipeline attempt already sent", true, true, nil, false, 1},
+		{"duplicate key on first attempt", false, true, nil, true, 1},
+		{"success", false, false, nil, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onTarget := tt.onTarget
+			var calls [][]any
+			err := migrateWithRetry(context.Background(), policy, migrateCmd, tt.retried, func(args ...any) error {
+				calls = append(calls, args)
+				// MIGRATE without REPLACE refuses to overwrite an existing target key
+				if onTarget && !hasReplace(args) {
+					return fakeRedisError("BUSYKEY Target key name already exists.")
+				}
+				onTarget = true
+				if len(calls) == 1 && tt.firstErr != nil {
+					return tt.firstErr
+				}
+				return nil
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrateWithRetry() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if len(calls) != tt.wantCalls {
+				t.Fatalf("migrateWithRetry() sent %d commands, want %d", len(calls), tt.wantCalls)
+			}
+			for i, args := range calls {
+				if want := tt.retried || i > 0; hasReplace(args) != want {
+					t.Errorf("attempt %d REPLACE = %t, want %t (args %v)", i, hasReplace(args), want, args)
+				}
+			}
+		})
+	}
+}
+
+// TestWithMigrateReplace tests that REPLACE goes before the AUTH options
+func TestWithMigrateReplace(t *testing.T) {
+	migrateCmd := buildMigrateCommandForReshard("127.0.0.1", "7002", "k", "app", "secret")
+
+	got := fmt.Sprint(withMigrateReplace(migrateCmd, true))
+	want := fmt.Sprint([]any{"MIGRATE", "127.0.0.1", "7002", "k", 0, redis.MigrateTimeout().Milliseconds(), "REPLACE", "AUTH2", "app", "secret"})
+	if got != want {
+		t.Errorf("withMigrateReplace() = %s, want %s", got, want)
+	}
+	if fmt.Sprint(withMigrateReplace(migrateCmd, false)) != fmt.Sprint(migrateCmd) {
+		t.Errorf("withMigrateReplace(false) changed the command")
+	}
+}
+
+// TestNormalizeClusterAddressTLS tests that the tls-port aux field is preferred with --tls
+func TestNormalizeClusterAddressTLS(t *testing.T) {
+	if err := config.SetTLS(config.TLSOptions{Enabled: true}); err != nil {
+		t.Fatalf("SetTLS failed: %v", err)
+	}
+	defer config.SetTLS(config.TLSOptions{})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"10.0.0.1:7001@17001,,tls-port=7101", "10.0.0.1:7101"},
+		{"10.0.0.1:7101@17001,,tcp-port=7001", "10.0.0.1:7101"},
+		{"10.0.0.1:7001@17001", "10.0.0.1:7001"},
+	}
+
+	for _, tt := range tests {
+		if result := normalizeClusterAddress(tt.input); result != tt.expected {
+			t.Errorf("normalizeClusterAddress(%q) with TLS = %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
 // TestFormatNumber tests the number formatting function
 func TestFormatNumber(t *testing.T) {
 	tests := []struct {
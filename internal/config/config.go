@@ -1,23 +1,65 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 // GlobalConfig holds the global configuration
 type GlobalConfig struct {
-	User           string
-	Password       string
-	Debug          bool
-	ConnectTimeout time.Duration
-	CommandTimeout time.Duration
-	MaxRetries     int
-	PoolSize       int
-	mutex          sync.RWMutex
+	User            string
+	Password        string
+	Debug           bool
+	ConnectTimeout  time.Duration
+	CommandTimeout  time.Duration
+	MaxRetries      int
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+	PoolSize        int
+
+	// Retry policy for key migration (MIGRATE/GETKEYSINSLOT) on transient errors
+	MigrateRetries    int
+	MigrateMinBackoff time.Duration
+	MigrateMaxBackoff time.Duration
+
+	// Password resolution (see credentials.go)
+	PasswordSource    PasswordSource
+	PasswordFile      string // REDIS_PASSWORD_FILE
+	CredentialCommand string // exec-style helper printing user/password
+	userFromFlag      bool
+
+	// Per-node overrides and fallback passwords for mixed-auth clusters
+	NodeCredentials     []NodeCredential
+	NodeCredentialsFile string
+
+	// Seed nodes from --seed, tried in order after the positional cluster address
+	Seeds []string
+
+	// Node labels from --label / --topology-file, recorded in the local inventory
+	Labels        []string
+	TopologyFile  string
+	InventoryPath string // empty: REDIS_INVENTORY or the user config directory
+
+	TLS       TLSOptions
+	tlsConfig *tls.Config
+	mutex     sync.RWMutex
+}
+
+// TLSOptions holds the TLS/mTLS settings shared by every connection
+type TLSOptions struct {
+	Enabled            bool
+	EnabledSet         bool // Enabled was given explicitly (--tls, REDIS_TLS) and is not inferred
+	CACert             string
+	Cert               string
+	Key                string
+	InsecureSkipVerify bool
+	ServerName         string
 }
 
 var global = &GlobalConfig{}
@@ -31,7 +73,12 @@ func Init() {
 	global.ConnectTimeout = 10 * time.Second
 	global.CommandTimeout = 60 * time.Second
 	global.MaxRetries = 3
+	global.MinRetryBackoff = 100 * time.Millisecond
+	global.MaxRetryBackoff = 2 * time.Second
 	global.PoolSize = 10
+	global.MigrateRetries = 5
+	global.MigrateMinBackoff = 200 * time.Millisecond
+	global.MigrateMaxBackoff = 5 * time.Second
 	global.Debug = false
 
 	// Load from environment variables if available
@@ -43,32 +90,88 @@ func Init() {
 	}
 }
 
-// SetAuth sets the authentication credentials
-func SetAuth(user, password string) {
+// SetTLS validates the TLS options and builds the shared tls.Config.
+// Specifying any certificate or verification option implies --tls,
+// unless TLS was explicitly turned on or off (e.g. --tls=false wins).
+func SetTLS(opts TLSOptions) error {
 	global.mutex.Lock()
 	defer global.mutex.Unlock()
 
-	global.User = user
-	global.Password = password
+	if !opts.EnabledSet && (opts.CACert != "" || opts.Cert != "" || opts.Key != "" || opts.InsecureSkipVerify || opts.ServerName != "") {
+		opts.Enabled = true
+	}
+
+	tlsConfig, err := buildTLSConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	global.TLS = opts
+	global.tlsConfig = tlsConfig
+	return nil
+}
+
+// GetTLSOptions returns the configured TLS options
+func GetTLSOptions() TLSOptions {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.TLS
 }
 
-// GetAuth returns the authentication credentials
-func GetAuth() (string, string) {
+// GetTLSConfig returns a copy of the shared tls.Config, or nil when TLS is disabled
+func GetTLSConfig() *tls.Config {
 	global.mutex.RLock()
 	defer global.mutex.RUnlock()
 
-	return global.User, global.Password
+	if global.tlsConfig == nil {
+		return nil
+	}
+	return global.tlsConfig.Clone()
 }
 
-// ValidateAuth checks if password is provided (required)
-func ValidateAuth() error {
+// IsTLSEnabled returns whether connections use TLS
+func IsTLSEnabled() bool {
 	global.mutex.RLock()
 	defer global.mutex.RUnlock()
+	return global.TLS.Enabled
+}
 
-	if global.Password == "" {
-		return fmt.Errorf("비밀번호가 필요합니다. --password 플래그를 사용하세요")
+// buildTLSConfig loads the CA bundle and client key pair referenced by opts
+func buildTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if !opts.Enabled {
+		return nil, nil
 	}
-	return nil
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if opts.CACert != "" {
+		pem, err := os.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("CA 인증서 읽기 실패 (%s): %w", opts.CACert, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA 인증서에서 유효한 PEM 인증서를 찾을 수 없습니다: %s", opts.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (opts.Cert == "") != (opts.Key == "") {
+		return nil, fmt.Errorf("mTLS를 사용하려면 --cert와 --key를 함께 지정해야 합니다")
+	}
+	if opts.Cert != "" {
+		pair, err := tls.LoadX509KeyPair(opts.Cert, opts.Key)
+		if err != nil {
+			return nil, fmt.Errorf("클라이언트 인증서 로드 실패: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	return tlsConfig, nil
 }
 
 // loadFromEnvironment loads configuration from environment variables
@@ -79,6 +182,16 @@ func loadFromEnvironment() {
 	}
 	if password := os.Getenv("REDIS_PASSWORD"); password != "" {
 		global.Password = password
+		global.PasswordSource = PasswordSourceEnv
+	}
+	if file := os.Getenv("REDIS_PASSWORD_FILE"); file != "" {
+		global.PasswordFile = file
+	}
+	if command := os.Getenv("REDIS_CREDENTIAL_COMMAND"); command != "" {
+		global.CredentialCommand = command
+	}
+	if file := os.Getenv("REDIS_NODE_CREDENTIALS"); file != "" {
+		global.NodeCredentialsFile = file
 	}
 
 	// Load connection settings from environment
@@ -97,12 +210,69 @@ func loadFromEnvironment() {
 			global.MaxRetries = r
 		}
 	}
+	if backoff := os.Getenv("REDIS_MIN_RETRY_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err == nil && d > 0 {
+			global.MinRetryBackoff = d
+		}
+	}
+	if backoff := os.Getenv("REDIS_MAX_RETRY_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err == nil && d > 0 {
+			global.MaxRetryBackoff = d
+		}
+	}
+	if global.MaxRetryBackoff < global.MinRetryBackoff {
+		global.MaxRetryBackoff = global.MinRetryBackoff
+	}
+	if retries := os.Getenv("REDIS_MIGRATE_RETRIES"); retries != "" {
+		if r, err := strconv.Atoi(retries); err == nil && r >= 0 {
+			global.MigrateRetries = r
+		}
+	}
+	if backoff := os.Getenv("REDIS_MIGRATE_MIN_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err == nil && d > 0 {
+			global.MigrateMinBackoff = d
+		}
+	}
+	if backoff := os.Getenv("REDIS_MIGRATE_MAX_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err == nil && d > 0 {
+			global.MigrateMaxBackoff = d
+		}
+	}
+	if global.MigrateMaxBackoff < global.MigrateMinBackoff {
+		global.MigrateMaxBackoff = global.MigrateMinBackoff
+	}
+	if seeds := os.Getenv("REDIS_SEEDS"); seeds != "" {
+		global.Seeds = strings.Split(seeds, ",")
+	}
 	if poolSize := os.Getenv("REDIS_POOL_SIZE"); poolSize != "" {
 		if p, err := strconv.Atoi(poolSize); err == nil && p > 0 {
 			global.PoolSize = p
 		}
 	}
 
+	// Load TLS settings from environment
+	switch os.Getenv("REDIS_TLS") {
+	case "true", "1":
+		global.TLS.Enabled, global.TLS.EnabledSet = true, true
+	case "false", "0":
+		global.TLS.Enabled, global.TLS.EnabledSet = false, true
+	}
+	if caCert := os.Getenv("REDIS_TLS_CACERT"); caCert != "" {
+		global.TLS.CACert = caCert
+	}
+	if cert := os.Getenv("REDIS_TLS_CERT"); cert != "" {
+		global.TLS.Cert = cert
+	}
+	if key := os.Getenv("REDIS_TLS_KEY"); key != "" {
+		global.TLS.Key = key
+	}
+	if skip := os.Getenv("REDIS_TLS_INSECURE_SKIP_VERIFY"); skip == "true" || skip == "1" {
+		global.TLS.InsecureSkipVerify = true
+	}
+	if sni := os.Getenv("REDIS_TLS_SNI"); sni != "" {
+		global.TLS.ServerName = sni
+	}
+
 	// Enable debug mode
 	if debug := os.Getenv("REDIS_DEBUG"); debug == "true" || debug == "1" {
 		global.Debug = true
@@ -130,6 +300,71 @@ func GetMaxRetries() int {
 	return global.MaxRetries
 }
 
+// GetRetryBackoff returns the minimum and maximum retry backoff
+func GetRetryBackoff() (time.Duration, time.Duration) {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.MinRetryBackoff, global.MaxRetryBackoff
+}
+
+// SetSeeds sets the additional seed nodes (--seed)
+func SetSeeds(seeds []string) {
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+	global.Seeds = append([]string(nil), seeds...)
+}
+
+// GetSeeds returns the additional seed nodes
+func GetSeeds() []string {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return append([]string(nil), global.Seeds...)
+}
+
+// SetLabelOptions sets the node label sources (--label, --topology-file, --inventory)
+func SetLabelOptions(labels []string, topologyFile, inventoryPath string) {
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+	global.Labels = append([]string(nil), labels...)
+	global.TopologyFile = topologyFile
+	global.InventoryPath = inventoryPath
+}
+
+// GetLabelOptions returns the --label values, the topology file and the inventory path
+func GetLabelOptions() ([]string, string, string) {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return append([]string(nil), global.Labels...), global.TopologyFile, global.InventoryPath
+}
+
+// SetMigrateRetryPolicy overrides the migration retry policy (CLI flags)
+func SetMigrateRetryPolicy(retries int, minBackoff, maxBackoff time.Duration) error {
+	if retries < 0 {
+		return fmt.Errorf("재시도 횟수는 0 이상이어야 합니다: %d", retries)
+	}
+	if minBackoff <= 0 || maxBackoff <= 0 {
+		return fmt.Errorf("재시도 백오프는 0보다 커야 합니다")
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+
+	global.MigrateRetries = retries
+	global.MigrateMinBackoff = minBackoff
+	global.MigrateMaxBackoff = maxBackoff
+	return nil
+}
+
+// GetMigrateRetryPolicy returns the migration retry count and backoff range
+func GetMigrateRetryPolicy() (int, time.Duration, time.Duration) {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.MigrateRetries, global.MigrateMinBackoff, global.MigrateMaxBackoff
+}
+
 // GetPoolSize returns the connection pool size
 func GetPoolSize() int {
 	global.mutex.RLock()
@@ -158,18 +393,28 @@ func GetConfigSummary() string {
 
 	return fmt.Sprintf(`Configuration:
   User: %s
-  Password: %s
+  Password: %s (%s)
   Connect Timeout: %v
   Command Timeout: %v
   Max Retries: %d
+  Retry Backoff: %v ~ %v
   Pool Size: %d
+  Migrate Retries: %d (%v ~ %v)
+  TLS: %t
   Debug: %t`,
 		maskString(global.User),
 		maskString(global.Password),
+		global.PasswordSource,
 		global.ConnectTimeout,
 		global.CommandTimeout,
 		global.MaxRetries,
+		global.MinRetryBackoff,
+		global.MaxRetryBackoff,
 		global.PoolSize,
+		global.MigrateRetries,
+		global.MigrateMinBackoff,
+		global.MigrateMaxBackoff,
+		global.TLS.Enabled,
 		global.Debug)
 }
 
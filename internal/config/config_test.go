@@ -0,0 +1,35 @@
+package config
+
+import "testing"
+
+// TestSetTLSEnabled tests that certificate options imply TLS only when --tls was not given
+func TestSetTLSEnabled(t *testing.T) {
+	defer SetTLS(TLSOptions{})
+
+	tests := []struct {
+		name string
+		opts TLSOptions
+		want bool
+	}{
+		{"nothing set", TLSOptions{}, false},
+		{"explicit tls", TLSOptions{Enabled: true, EnabledSet: true}, true},
+		{"sni implies tls", TLSOptions{ServerName: "redis.internal"}, true},
+		{"skip verify implies tls", TLSOptions{InsecureSkipVerify: true}, true},
+		{"explicit tls=false wins over sni", TLSOptions{EnabledSet: true, ServerName: "redis.internal"}, false},
+		{"explicit tls=false wins over skip verify", TLSOptions{EnabledSet: true, InsecureSkipVerify: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetTLS(tt.opts); err != nil {
+				t.Fatalf("SetTLS() error = %v", err)
+			}
+			if got := IsTLSEnabled(); got != tt.want {
+				t.Errorf("IsTLSEnabled() = %t, want %t", got, tt.want)
+			}
+			if got := GetTLSConfig() != nil; got != tt.want {
+				t.Errorf("GetTLSConfig() != nil = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// PasswordSource identifies where the password was taken from
+type PasswordSource string
+
+const (
+	PasswordSourceNone    PasswordSource = ""
+	PasswordSourceFlag    PasswordSource = "--password"
+	PasswordSourceStdin   PasswordSource = "--password-stdin"
+	PasswordSourceFile    PasswordSource = "--password-file"
+	PasswordSourceEnvFile PasswordSource = "REDIS_PASSWORD_FILE"
+	PasswordSourceEnv     PasswordSource = "REDIS_PASSWORD"
+	PasswordSourceCommand PasswordSource = "credential_command"
+	PasswordSourcePrompt  PasswordSource = "prompt"
+)
+
+// PasswordPrecedence lists the password sources from highest to lowest priority
+var PasswordPrecedence = []PasswordSource{
+	PasswordSourceFlag,
+	PasswordSourceStdin,
+	PasswordSourceFile,
+	PasswordSourceEnvFile,
+	PasswordSourceEnv,
+	PasswordSourceCommand,
+	PasswordSourcePrompt,
+}
+
+// credentialCommandTimeout bounds how long a credential helper may run
+const credentialCommandTimeout = 30 * time.Second
+
+// CredentialOptions holds the credential flags given on the command line
+type CredentialOptions struct {
+	User              string
+	UserSet           bool // --user was given explicitly
+	Password          string
+	PasswordSet       bool // --password was given explicitly
+	PasswordFile      string
+	PasswordStdin     bool
+	CredentialCommand string
+}
+
+// SetCredentials applies the credential flags on top of the environment.
+// Sources that need no interaction (flag, stdin, file, environment) are resolved here;
+// credential_command and the prompt run lazily in ValidateAuth, only for commands that connect.
+func SetCredentials(opts CredentialOptions, stdin io.Reader) error {
+	explicit := 0
+	for _, set := range []bool{opts.PasswordSet, opts.PasswordStdin, opts.PasswordFile != ""} {
+		if set {
+			explicit++
+		}
+	}
+	if explicit > 1 {
+		return fmt.Errorf("--password, --password-stdin, --password-file 중 하나만 지정하세요")
+	}
+
+	var password string
+	var source PasswordSource
+
+	switch {
+	case opts.PasswordSet:
+		password, source = opts.Password, PasswordSourceFlag
+	case opts.PasswordStdin:
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("표준 입력에서 비밀번호 읽기 실패: %w", err)
+		}
+		password, source = trimSecret(string(data)), PasswordSourceStdin
+		if password == "" {
+			return fmt.Errorf("--password-stdin: 표준 입력이 비어 있습니다")
+		}
+	case opts.PasswordFile != "":
+		p, err := readPasswordFile(opts.PasswordFile)
+		if err != nil {
+			return err
+		}
+		password, source = p, PasswordSourceFile
+	}
+
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+
+	if opts.UserSet {
+		global.User = opts.User
+		global.userFromFlag = true
+	}
+	if opts.CredentialCommand != "" {
+		global.CredentialCommand = opts.CredentialCommand
+	}
+
+	if source != PasswordSourceNone {
+		global.Password = password
+		global.PasswordSource = source
+		return nil
+	}
+
+	// 플래그가 없으면 환경 변수: REDIS_PASSWORD_FILE > REDIS_PASSWORD
+	if global.PasswordFile != "" {
+		p, err := readPasswordFile(global.PasswordFile)
+		if err != nil {
+			return err
+		}
+		global.Password = p
+		global.PasswordSource = PasswordSourceEnvFile
+	}
+	return nil
+}
+
+// SetAuth sets the authentication credentials
+func SetAuth(user, password string) {
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+
+	global.User = user
+	global.Password = password
+	global.PasswordSource = PasswordSourceFlag
+}
+
+// GetAuth returns the authentication credentials
+func GetAuth() (string, string) {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+
+	return global.User, global.Password
+}
+
+// GetPasswordSource returns where the current password came from
+func GetPasswordSource() PasswordSource {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.PasswordSource
+}
+
+// GetPasswordFile returns the password file from REDIS_PASSWORD_FILE
+func GetPasswordFile() string {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.PasswordFile
+}
+
+// GetCredentialCommand returns the configured credential helper command
+func GetCredentialCommand() string {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.CredentialCommand
+}
+
+// ValidateAuth makes sure a password is available (required).
+// When no flag or environment variable provided one, it runs credential_command
+// and finally prompts on the terminal.
+func ValidateAuth() error {
+	global.mutex.RLock()
+	password := global.Password
+	command := global.CredentialCommand
+	global.mutex.RUnlock()
+
+	if password != "" {
+		return nil
+	}
+
+	if command != "" {
+		user, password, err := runCredentialCommand(command)
+		if err != nil {
+			return err
+		}
+
+		global.mutex.Lock()
+		defer global.mutex.Unlock()
+		// --user가 지정되지 않았으면 헬퍼가 돌려준 사용자명을 사용
+		if user != "" && !global.userFromFlag {
+			global.User = user
+		}
+		global.Password = password
+		global.PasswordSource = PasswordSourceCommand
+		return nil
+	}
+
+	if term.IsTerminal(os.Stdin.Fd()) {
+		password, err := promptPassword()
+		if err != nil {
+			return err
+		}
+
+		global.mutex.Lock()
+		defer global.mutex.Unlock()
+		global.Password = password
+		global.PasswordSource = PasswordSourcePrompt
+		return nil
+	}
+
+	return fmt.Errorf("비밀번호가 필요합니다. --password-file, --password-stdin, REDIS_PASSWORD_FILE 또는 --credential-command를 사용하세요")
+}
+
+// readPasswordFile reads a password from a file, ignoring the trailing newline
+func readPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("비밀번호 파일 읽기 실패 (%s): %w", path, err)
+	}
+	password := trimSecret(string(data))
+	if password == "" {
+		return "", fmt.Errorf("비밀번호 파일이 비어 있습니다: %s", path)
+	}
+	return password, nil
+}
+
+// promptPassword asks for the password on the terminal without echo
+func promptPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Redis 비밀번호: ")
+	data, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("비밀번호 입력 실패: %w", err)
+	}
+	password := trimSecret(string(data))
+	if password == "" {
+		return "", fmt.Errorf("비밀번호가 입력되지 않았습니다")
+	}
+	return password, nil
+}
+
+// runCredentialCommand runs the credential helper through the shell and parses its stdout.
+// stderr and stdin are passed through so that helpers can ask for a passphrase.
+func runCredentialCommand(command string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("credential_command 실행 실패: %w", err)
+	}
+
+	user, password, err := ParseCredentialOutput(string(out))
+	if err != nil {
+		return "", "", fmt.Errorf("credential_command 출력 해석 실패: %w", err)
+	}
+	return user, password, nil
+}
+
+// ParseCredentialOutput parses a credential helper's stdout.
+// Either "user=<name>" / "password=<secret>" lines (git credential style, username= also accepted)
+// or a single line holding just the password.
+func ParseCredentialOutput(out string) (string, string, error) {
+	lines := strings.Split(trimSecret(out), "\n")
+
+	var user, password string
+	keyed := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "user", "username":
+			user, keyed = value, true
+		case "password":
+			password, keyed = value, true
+		}
+	}
+
+	if !keyed {
+		if len(lines) != 1 {
+			return "", "", fmt.Errorf("password=<값> 형식이 아니면 한 줄에 비밀번호만 출력해야 합니다")
+		}
+		password = strings.TrimRight(lines[0], "\r")
+	}
+
+	if password == "" {
+		return "", "", fmt.Errorf("비밀번호가 비어 있습니다")
+	}
+	return user, password, nil
+}
+
+// trimSecret removes trailing newlines only; surrounding spaces may be part of the secret
+func trimSecret(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
+
+// NodeCredential overrides the credentials for one node during password rotation.
+// Node is an address (host:port) or a node ID; "*" marks a fallback tried on any node.
+// An empty User keeps the default user.
+type NodeCredential struct {
+	Node     string
+	User     string
+	Password string
+}
+
+// FallbackNode is the Node value of fallback entries
+const FallbackNode = "*"
+
+// LoadNodeCredentials reads per-node credentials from a file (--node-credentials / REDIS_NODE_CREDENTIALS)
+func LoadNodeCredentials(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("노드 인증 파일 읽기 실패 (%s): %w", path, err)
+	}
+	defer f.Close()
+
+	creds, err := ParseNodeCredentials(f)
+	if err != nil {
+		return fmt.Errorf("노드 인증 파일 해석 실패 (%s): %w", path, err)
+	}
+
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+	global.NodeCredentialsFile = path
+	global.NodeCredentials = creds
+	return nil
+}
+
+// ParseNodeCredentials parses "<address|node-id|*> [user] <password>" lines.
+// Blank lines and lines starting with # are ignored; fallback order is kept.
+func ParseNodeCredentials(r io.Reader) ([]NodeCredential, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []NodeCredential
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 2:
+			creds = append(creds, NodeCredential{Node: fields[0], Password: fields[1]})
+		case 3:
+			creds = append(creds, NodeCredential{Node: fields[0], User: fields[1], Password: fields[2]})
+		default:
+			return nil, fmt.Errorf("%d번째 줄: '<주소|노드 ID|*> [사용자명] <비밀번호>' 형식이어야 합니다", i+1)
+		}
+	}
+	return creds, nil
+}
+
+// GetNodeCredentials returns the per-node overrides and fallbacks in file order
+func GetNodeCredentials() []NodeCredential {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return append([]NodeCredential(nil), global.NodeCredentials...)
+}
+
+// GetNodeCredentialsFile returns the file the per-node credentials were loaded from
+func GetNodeCredentialsFile() string {
+	global.mutex.RLock()
+	defer global.mutex.RUnlock()
+	return global.NodeCredentialsFile
+}
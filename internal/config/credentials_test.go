@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseCredentialOutput tests parsing of credential helper output
+func TestParseCredentialOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		out          string
+		expectedUser string
+		expectedPass string
+		wantErr      bool
+	}{
+		{"password only", "s3cret\n", "", "s3cret", false},
+		{"password with equals", "YWJj==\n", "", "YWJj==", false},
+		{"keyed", "user=admin\npassword=s3cret\n", "admin", "s3cret", false},
+		{"username key and crlf", "username=admin\r\npassword=s3cret\r\n", "admin", "s3cret", false},
+		{"keyed without password", "user=admin\n", "", "", true},
+		{"multiple bare lines", "a\nb\n", "", "", true},
+		{"empty", "\n", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, password, err := ParseCredentialOutput(tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCredentialOutput(%q) error = %v, wantErr %t", tt.out, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if user != tt.expectedUser || password != tt.expectedPass {
+				t.Errorf("ParseCredentialOutput(%q) = (%q, %q), want (%q, %q)", tt.out, user, password, tt.expectedUser, tt.expectedPass)
+			}
+		})
+	}
+}
+
+// TestSetCredentialsPrecedence tests which source wins when several are configured
+func TestSetCredentialsPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	flagFile := filepath.Join(dir, "flag")
+	envFile := filepath.Join(dir, "env")
+	if err := os.WriteFile(flagFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(envFile, []byte("from-env-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		envPassword    string
+		envFile        string
+		opts           CredentialOptions
+		stdin          string
+		expectedPass   string
+		expectedSource PasswordSource
+		wantErr        bool
+	}{
+		{"flag beats environment", "from-env", envFile, CredentialOptions{Password: "from-flag", PasswordSet: true}, "", "from-flag", PasswordSourceFlag, false},
+		{"stdin", "from-env", "", CredentialOptions{PasswordStdin: true}, "from-stdin\n", "from-stdin", PasswordSourceStdin, false},
+		{"password file", "from-env", envFile, CredentialOptions{PasswordFile: flagFile}, "", "from-file", PasswordSourceFile, false},
+		{"env file beats env password", "from-env", envFile, CredentialOptions{}, "", "from-env-file", PasswordSourceEnvFile, false},
+		{"env password", "from-env", "", CredentialOptions{}, "", "from-env", PasswordSourceEnv, false},
+		{"conflicting flags", "", "", CredentialOptions{Password: "x", PasswordSet: true, PasswordFile: flagFile}, "", "", PasswordSourceNone, true},
+		{"empty stdin", "", "", CredentialOptions{PasswordStdin: true}, "", "", PasswordSourceNone, true},
+		{"missing file", "", "", CredentialOptions{PasswordFile: filepath.Join(dir, "missing")}, "", "", PasswordSourceNone, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REDIS_PASSWORD", tt.envPassword)
+			t.Setenv("REDIS_PASSWORD_FILE", tt.envFile)
+			global = &GlobalConfig{}
+			Init()
+
+			err := SetCredentials(tt.opts, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetCredentials() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			_, password := GetAuth()
+			if password != tt.expectedPass || GetPasswordSource() != tt.expectedSource {
+				t.Errorf("password = %q (%s), want %q (%s)", password, GetPasswordSource(), tt.expectedPass, tt.expectedSource)
+			}
+		})
+	}
+}
+
+// TestParseNodeCredentials tests the per-node credentials file format
+func TestParseNodeCredentials(t *testing.T) {
+	input := `# 비밀번호 교체 중
+10.0.0.1:7001 newpass
+07c37dfeb235213a872192d90877d0cd55635b91  admin  newpass
+
+* oldpass
+* admin olderpass
+`
+	creds, err := ParseNodeCredentials(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseNodeCredentials() error = %v", err)
+	}
+
+	expected := []NodeCredential{
+		{Node: "10.0.0.1:7001", Password: "newpass"},
+		{Node: "07c37dfeb235213a872192d90877d0cd55635b91", User: "admin", Password: "newpass"},
+		{Node: FallbackNode, Password: "oldpass"},
+		{Node: FallbackNode, User: "admin", Password: "olderpass"},
+	}
+	if len(creds) != len(expected) {
+		t.Fatalf("ParseNodeCredentials() returned %d entries, want %d", len(creds), len(expected))
+	}
+	for i := range expected {
+		if creds[i] != expected[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, creds[i], expected[i])
+		}
+	}
+
+	if _, err := ParseNodeCredentials(strings.NewReader("10.0.0.1:7001\n")); err == nil {
+		t.Error("ParseNodeCredentials() accepted a line without password")
+	}
+}
@@ -0,0 +1,183 @@
+package inventory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"redisctl/internal/redis"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ZoneLabel is the label key used for availability zone / rack placement
+const ZoneLabel = "zone"
+
+// Labels are key=value attributes of a node (zone, rack, ...)
+type Labels map[string]string
+
+// Inventory is the local store of node labels, so that labels given once with
+// --label or a topology file are reused by later commands.
+// The same format is accepted as a topology file (YAML or JSON):
+//
+//	nodes:
+//	  10.0.1.5:7000: {zone: a, rack: r1}
+//	  10.0.2.5:7000: {zone: b}
+type Inventory struct {
+	Nodes map[string]Labels `yaml:"nodes"`
+
+	path string
+}
+
+// DefaultPath returns the inventory location: REDIS_INVENTORY or <user config dir>/redisctl/inventory.yaml
+func DefaultPath() string {
+	if path := os.Getenv("REDIS_INVENTORY"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "redisctl-inventory.yaml"
+	}
+	return filepath.Join(dir, "redisctl", "inventory.yaml")
+}
+
+// Load reads the inventory at path; a missing file is an empty inventory
+func Load(path string) (*Inventory, error) {
+	inv := &Inventory{Nodes: make(map[string]Labels), path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return inv, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("인벤토리 읽기 실패 (%s): %w", path, err)
+	}
+
+	nodes, err := parseNodes(data)
+	if err != nil {
+		return nil, fmt.Errorf("인벤토리 해석 실패 (%s): %w", path, err)
+	}
+	inv.Merge(nodes)
+	return inv, nil
+}
+
+// LoadTopologyFile reads node labels from a topology file (YAML or JSON, same format as the inventory)
+func LoadTopologyFile(path string) (map[string]Labels, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("토폴로지 파일 읽기 실패 (%s): %w", path, err)
+	}
+	nodes, err := parseNodes(data)
+	if err != nil {
+		return nil, fmt.Errorf("토폴로지 파일 해석 실패 (%s): %w", path, err)
+	}
+	return nodes, nil
+}
+
+// parseNodes decodes the nodes section; YAML is a superset of JSON so both are accepted
+func parseNodes(data []byte) (map[string]Labels, error) {
+	var file struct {
+		Nodes map[string]Labels `yaml:"nodes"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]Labels, len(file.Nodes))
+	for addr, labels := range file.Nodes {
+		key, err := nodeKey(addr)
+		if err != nil {
+			return nil, err
+		}
+		nodes[key] = labels
+	}
+	return nodes, nil
+}
+
+// ParseLabel parses a --label value: <host:port>=<key>=<value>
+func ParseLabel(value string) (string, string, string, error) {
+	addr, label, ok := strings.Cut(value, "=")
+	if !ok {
+		return "", "", "", fmt.Errorf("잘못된 라벨 %q (형식: host:port=key=value)", value)
+	}
+	key, val, ok := strings.Cut(label, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return "", "", "", fmt.Errorf("잘못된 라벨 %q (형식: host:port=key=value)", value)
+	}
+
+	nodeAddr, err := nodeKey(addr)
+	if err != nil {
+		return "", "", "", fmt.Errorf("잘못된 라벨 %q: %w", value, err)
+	}
+	return nodeAddr, strings.TrimSpace(key), strings.TrimSpace(val), nil
+}
+
+// nodeKey normalizes a node address so that localhost:7000 and 127.0.0.1:7000 share labels
+func nodeKey(address string) (string, error) {
+	addr, err := redis.ParseAddress(strings.TrimSpace(address))
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// Path returns the file the inventory is stored in
+func (inv *Inventory) Path() string {
+	return inv.path
+}
+
+// Merge adds labels, overriding existing keys of the same node
+func (inv *Inventory) Merge(nodes map[string]Labels) {
+	for addr, labels := range nodes {
+		if inv.Nodes[addr] == nil {
+			inv.Nodes[addr] = make(Labels)
+		}
+		for k, v := range labels {
+			inv.Nodes[addr][k] = v
+		}
+	}
+}
+
+// Labels returns the labels of a node (any accepted address form)
+func (inv *Inventory) Labels(address string) Labels {
+	key, err := nodeKey(address)
+	if err != nil {
+		return nil
+	}
+	return inv.Nodes[key]
+}
+
+// Zone returns the zone label of a node, empty when unknown
+func (inv *Inventory) Zone(address string) string {
+	return inv.Labels(address)[ZoneLabel]
+}
+
+// Zones returns the zone of every labelled node, keyed by normalized address
+func (inv *Inventory) Zones() map[string]string {
+	zones := make(map[string]string)
+	for addr, labels := range inv.Nodes {
+		if zone := labels[ZoneLabel]; zone != "" {
+			zones[addr] = zone
+		}
+	}
+	return zones
+}
+
+// Save writes the inventory, creating its directory when needed
+func (inv *Inventory) Save() error {
+	if err := os.MkdirAll(filepath.Dir(inv.path), 0o755); err != nil {
+		return fmt.Errorf("인벤토리 디렉터리 생성 실패: %w", err)
+	}
+
+	data, err := yaml.Marshal(struct {
+		Nodes map[string]Labels `yaml:"nodes"`
+	}{inv.Nodes})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(inv.path, data, 0o644); err != nil {
+		return fmt.Errorf("인벤토리 저장 실패 (%s): %w", inv.path, err)
+	}
+	return nil
+}
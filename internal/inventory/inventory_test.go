@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestParseLabel tests parsing of --label values
+func TestParseLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		addr    string
+		key     string
+		val     string
+		wantErr bool
+	}{
+		{"zone", "10.0.1.5:7000=zone=a", "10.0.1.5:7000", "zone", "a", false},
+		{"localhost normalized", "localhost:7001=rack=r1", "127.0.0.1:7001", "rack", "r1", false},
+		{"empty value", "10.0.1.5:7000=zone=", "10.0.1.5:7000", "zone", "", false},
+		{"missing label", "10.0.1.5:7000", "", "", "", true},
+		{"missing value", "10.0.1.5:7000=zone", "", "", "", true},
+		{"empty key", "10.0.1.5:7000==a", "", "", "", true},
+		{"invalid address", "10.0.1.5=zone=a", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, key, val, err := ParseLabel(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabel(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if addr != tt.addr || key != tt.key || val != tt.val {
+				t.Errorf("ParseLabel(%q) = %q, %q, %q, want %q, %q, %q", tt.value, addr, key, val, tt.addr, tt.key, tt.val)
+			}
+		})
+	}
+}
+
+// TestParseNodes tests YAML and JSON topology files
+func TestParseNodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected map[string]Labels
+		wantErr  bool
+	}{
+		{
+			"yaml",
+			"nodes:\n  10.0.1.5:7000: {zone: a, rack: r1}\n  localhost:7001:\n    zone: b\n",
+			map[string]Labels{
+				"10.0.1.5:7000":  {"zone": "a", "rack": "r1"},
+				"127.0.0.1:7001": {"zone": "b"},
+			},
+			false,
+		},
+		{
+			"json",
+			`{"nodes": {"10.0.2.5:7000": {"zone": "c"}}}`,
+			map[string]Labels{"10.0.2.5:7000": {"zone": "c"}},
+			false,
+		},
+		{"empty", "", map[string]Labels{}, false},
+		{"invalid address", "nodes:\n  10.0.1.5: {zone: a}\n", nil, true},
+		{"invalid syntax", "nodes: [", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := parseNodes([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodes() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(nodes, tt.expected) {
+				t.Errorf("parseNodes() = %v, want %v", nodes, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInventorySaveLoad tests that labels survive a save and reload, and that later labels override
+func TestInventorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redisctl", "inventory.yaml")
+
+	inv, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of a missing file: %v", err)
+	}
+	if len(inv.Nodes) != 0 {
+		t.Fatalf("missing inventory has %d nodes, want 0", len(inv.Nodes))
+	}
+
+	inv.Merge(map[string]Labels{
+		"10.0.1.5:7000":  {"zone": "a", "rack": "r1"},
+		"127.0.0.1:7001": {"rack": "r2"},
+	})
+	inv.Merge(map[string]Labels{"10.0.1.5:7000": {"zone": "b"}})
+	if err := inv.Save(); err != nil {
+		t.Fatalf("Save(): %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(): %v", err)
+	}
+
+	if got := loaded.Labels("10.0.1.5:7000"); !reflect.DeepEqual(got, Labels{"zone": "b", "rack": "r1"}) {
+		t.Errorf("Labels(10.0.1.5:7000) = %v", got)
+	}
+	if got := loaded.Zone("localhost:7001"); got != "" {
+		t.Errorf("Zone(localhost:7001) = %q, want empty", got)
+	}
+	if got := loaded.Zones(); !reflect.DeepEqual(got, map[string]string{"10.0.1.5:7000": "b"}) {
+		t.Errorf("Zones() = %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Address represents a Redis node address.
+// It understands plain host:port, bracketed IPv6 ([::1]:7000) and the
+// CLUSTER NODES form ip:port@cport[,hostname[,aux=value...]] used by Redis 7.
+type Address struct {
+	Host     string
+	Port     int
+	BusPort  int
+	Hostname string // cluster-announce-hostname (Redis 7+)
+	TLSPort  int    // aux field tls-port: TLS port when Port is the plain port
+	TCPPort  int    // aux field tcp-port: plain port when Port is the TLS port
+	ShardID  string // aux field shard-id (Redis 7.2+)
+}
+
+// ParseAddress parses a user supplied or CLUSTER NODES address.
+// localhost and an empty host are normalized to 127.0.0.1.
+func ParseAddress(address string) (Address, error) {
+	var addr Address
+
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return addr, fmt.Errorf("빈 주소입니다")
+	}
+
+	// ip:port@cport,hostname,aux=value,...
+	fields := strings.Split(address, ",")
+	endpoint := fields[0]
+	if len(fields) > 1 {
+		addr.Hostname = fields[1]
+	}
+	for _, aux := range fields[min(2, len(fields)):] {
+		key, value, ok := strings.Cut(aux, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "tls-port":
+			addr.TLSPort, _ = strconv.Atoi(value)
+		case "tcp-port":
+			addr.TCPPort, _ = strconv.Atoi(value)
+		case "shard-id":
+			addr.ShardID = value
+		}
+	}
+
+	if hostPort, busPort, ok := strings.Cut(endpoint, "@"); ok {
+		endpoint = hostPort
+		port, err := strconv.Atoi(busPort)
+		if err != nil {
+			return addr, fmt.Errorf("잘못된 버스 포트: %s", busPort)
+		}
+		addr.BusPort = port
+	}
+
+	host, portStr, err := splitHostPort(endpoint)
+	if err != nil {
+		return addr, err
+	}
+
+	if host == "" || host == "localhost" {
+		host = "127.0.0.1"
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return addr, fmt.Errorf("잘못된 포트: %s", portStr)
+	}
+	if port < 1 || port > 65535 {
+		return addr, fmt.Errorf("포트 범위 오류: %d (1-65535)", port)
+	}
+
+	addr.Host = host
+	addr.Port = port
+	return addr, nil
+}
+
+// splitHostPort splits host and port, accepting the unbracketed IPv6
+// form (2001:db8::1:7000) that CLUSTER NODES prints.
+func splitHostPort(endpoint string) (string, string, error) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err == nil {
+		return host, port, nil
+	}
+
+	idx := strings.LastIndex(endpoint, ":")
+	if idx == -1 {
+		return "", "", fmt.Errorf("잘못된 주소 형식: %s (예: localhost:6379, [::1]:6379)", endpoint)
+	}
+
+	host = endpoint[:idx]
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return "", "", fmt.Errorf("잘못된 주소 형식: %s (예: localhost:6379, [::1]:6379)", endpoint)
+	}
+	return host, endpoint[idx+1:], nil
+}
+
+// String formats the client address as host:port ([host]:port for IPv6)
+func (a Address) String() string {
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
+}
+
+// ClusterString formats the address in the CLUSTER NODES form (ip:port@cport,hostname,aux=value...)
+// so that it round-trips through ParseAddress.
+func (a Address) ClusterString() string {
+	s := a.String()
+	if a.BusPort > 0 {
+		s += "@" + strconv.Itoa(a.BusPort)
+	}
+	if a.Hostname == "" && a.ShardID == "" && a.TLSPort == 0 && a.TCPPort == 0 {
+		return s
+	}
+
+	s += "," + a.Hostname
+	if a.ShardID != "" {
+		s += ",shard-id=" + a.ShardID
+	}
+	if a.TLSPort > 0 {
+		s += ",tls-port=" + strconv.Itoa(a.TLSPort)
+	}
+	if a.TCPPort > 0 {
+		s += ",tcp-port=" + strconv.Itoa(a.TCPPort)
+	}
+	return s
+}
+
+// PortString returns the client port as a string (MIGRATE, CLUSTER MEET arguments)
+func (a Address) PortString() string {
+	return strconv.Itoa(a.Port)
+}
+
+// Endpoint returns host:port for the transport in use.
+// Redis 7.2 reports the second listener in the tls-port/tcp-port aux fields.
+func (a Address) Endpoint(useTLS bool) string {
+	port := a.Port
+	if useTLS && a.TLSPort > 0 {
+		port = a.TLSPort
+	} else if !useTLS && a.TCPPort > 0 {
+		port = a.TCPPort
+	}
+	return net.JoinHostPort(a.Host, strconv.Itoa(port))
+}
+
+// IsIPv6 reports whether the host is an IPv6 literal
+func (a Address) IsIPv6() bool {
+	ip := net.ParseIP(a.Host)
+	return ip != nil && ip.To4() == nil
+}
+
+// DisplayName returns the announced hostname when available, otherwise the client address
+func (a Address) DisplayName() string {
+	if a.Hostname != "" {
+		return net.JoinHostPort(a.Hostname, strconv.Itoa(a.Port))
+	}
+	return a.String()
+}
@@ -0,0 +1,145 @@
+package redis
+
+import (
+	"context"
+	"sync"
+
+	"redisctl/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Credential is the user/password pair used to authenticate to one node
+type Credential struct {
+	User     string
+	Password string
+}
+
+// nodeAuth remembers which credential authenticated on each node, so that a node
+// is probed only once and MIGRATE AUTH2 uses the same pair as direct connections.
+var nodeAuth = struct {
+	sync.RWMutex
+	working map[string]Credential // normalized host:port -> credential that authenticated
+	nodeIDs map[string]string     // normalized host:port -> node ID, learned from topologies
+}{
+	working: make(map[string]Credential),
+	nodeIDs: make(map[string]string),
+}
+
+// CredentialFor returns the credential to use for the node at address.
+// Without per-node overrides this is always the global user/password.
+func CredentialFor(address string) Credential {
+	user, password := config.GetAuth()
+	return credentialFor(context.Background(), address, Credential{User: user, Password: password})
+}
+
+// credentialFor picks the credential for address with def as the default pair.
+// When several candidates apply (override, default, fallbacks) they are tried with
+// a PING in order and the first one the node accepts is remembered.
+// Probing stops at the first candidate once ctx is cancelled.
+func credentialFor(ctx context.Context, address string, def Credential) Credential {
+	key := authKey(address)
+	candidates := CredentialCandidates(address, def, config.GetNodeCredentials())
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	nodeAuth.RLock()
+	cred, ok := nodeAuth.working[key]
+	nodeAuth.RUnlock()
+	if ok {
+		return cred
+	}
+
+	for _, candidate := range candidates {
+		err := probeCredential(ctx, address, candidate)
+		if err == nil {
+			nodeAuth.Lock()
+			nodeAuth.working[key] = candidate
+			nodeAuth.Unlock()
+			return candidate
+		}
+		// 인증 실패만 다음 후보로 넘어가고, 연결 오류는 실제 명령에서 드러나게 둔다
+		if ClassifyError(err) != ErrorNoAuth {
+			return candidates[0]
+		}
+	}
+	return candidates[0]
+}
+
+// CredentialCandidates lists the credentials to try for a node, most specific first:
+// node ID override, address override, the default pair, then "*" fallbacks in file order.
+// Overrides without a user keep the default user. Duplicates are removed.
+func CredentialCandidates(address string, def Credential, overrides []config.NodeCredential) []Credential {
+	key := authKey(address)
+
+	nodeAuth.RLock()
+	nodeID := nodeAuth.nodeIDs[key]
+	nodeAuth.RUnlock()
+
+	var byID, byAddr, fallbacks []Credential
+	for _, o := range overrides {
+		cred := Credential{User: o.User, Password: o.Password}
+		if cred.User == "" {
+			cred.User = def.User
+		}
+
+		switch {
+		case o.Node == config.FallbackNode:
+			fallbacks = append(fallbacks, cred)
+		case nodeID != "" && o.Node == nodeID:
+			byID = append(byID, cred)
+		case authKey(o.Node) == key:
+			byAddr = append(byAddr, cred)
+		}
+	}
+
+	var candidates []Credential
+	seen := make(map[Credential]bool)
+	for _, group := range [][]Credential{byID, byAddr, {def}, fallbacks} {
+		for _, cred := range group {
+			if !seen[cred] {
+				seen[cred] = true
+				candidates = append(candidates, cred)
+			}
+		}
+	}
+	return candidates
+}
+
+// rememberNodeIDs records the node ID behind each address so that overrides
+// keyed by node ID apply to connections made by address
+func rememberNodeIDs(t *Topology) {
+	nodeAuth.Lock()
+	defer nodeAuth.Unlock()
+
+	for _, n := range t.Nodes {
+		if n.ID == "" {
+			continue
+		}
+		nodeAuth.nodeIDs[authKey(n.Address)] = n.ID
+		nodeAuth.nodeIDs[authKey(n.Endpoint())] = n.ID
+	}
+}
+
+// probeCredential checks whether the node accepts cred
+func probeCredential(ctx context.Context, address string, cred Credential) error {
+	opts := nodeOptions(address, cred.User, cred.Password)
+	opts.MaxRetries = -1 // 후보마다 한 번만 시도
+
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, config.GetConnectTimeout())
+	defer cancel()
+	return client.Ping(ctx).Err()
+}
+
+// authKey normalizes an address for credential lookups; node IDs are returned unchanged
+func authKey(address string) string {
+	addr, err := ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return addr.String()
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+
+	"redisctl/internal/config"
+)
+
+// TestCredentialCandidates tests the order in which credentials are tried for a node
+func TestCredentialCandidates(t *testing.T) {
+	const nodeID = "07c37dfeb235213a872192d90877d0cd55635b91"
+	rememberNodeIDs(NewTopology(SourceClusterNodes, []ClusterNode{
+		{ID: nodeID, Address: "127.0.0.1:7002@17002"},
+	}))
+
+	def := Credential{User: "admin", Password: "current"}
+	overrides := []config.NodeCredential{
+		{Node: config.FallbackNode, Password: "old"},
+		{Node: "localhost:7001", Password: "new"},
+		{Node: nodeID, User: "rotator", Password: "new"},
+		{Node: config.FallbackNode, Password: "current"},
+	}
+
+	tests := []struct {
+		name     string
+		address  string
+		expected []Credential
+	}{
+		{
+			name:     "address override",
+			address:  "127.0.0.1:7001",
+			expected: []Credential{{"admin", "new"}, def, {"admin", "old"}},
+		},
+		{
+			name:     "node id override",
+			address:  "127.0.0.1:7002",
+			expected: []Credential{{"rotator", "new"}, def, {"admin", "old"}},
+		},
+		{
+			name:     "fallbacks only",
+			address:  "127.0.0.1:7003",
+			expected: []Credential{def, {"admin", "old"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CredentialCandidates(tt.address, def, overrides)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CredentialCandidates(%s) = %+v, want %+v", tt.address, got, tt.expected)
+			}
+		})
+	}
+
+	if got := CredentialCandidates("127.0.0.1:7001", def, nil); !reflect.DeepEqual(got, []Credential{def}) {
+		t.Errorf("CredentialCandidates without overrides = %+v, want only the default", got)
+	}
+}
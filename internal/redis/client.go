@@ -6,119 +6,109 @@ package redis
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"sync"
-	"time"
+
+	"redisctl/internal/config"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// ClusterNode represents a Redis cluster node
-type ClusterNode struct {
-	ID          string
-	Address     string
-	Flags       []string
-	Master      string
-	Slots       []SlotRange
-	LinkState   string
-	PingSent    int64
-	PongRecv    int64
-	ConfigEpoch int64
-}
-
-// SlotRange represents a range of hash slots
-type SlotRange struct {
-	Start int
-	End   int
-}
-
 // ClusterManager manages Redis cluster operations
 type ClusterManager struct {
 	nodes    map[string]*redis.Client
-	nodesMu  sync.RWMutex // Protects nodes map from concurrent access
+	nodesMu  *sync.RWMutex // Protects nodes map from concurrent access (shared by WithContext copies)
 	ctx      context.Context
 	user     string
 	password string
 }
 
-// NewClusterManager creates a new cluster manager
-func NewClusterManager(user, password string) *ClusterManager {
+// NewClusterManager creates a new cluster manager.
+// ctx is the command context; it is cancelled on Ctrl-C / SIGTERM.
+func NewClusterManager(ctx context.Context, user, password string) *ClusterManager {
 	return &ClusterManager{
 		nodes:    make(map[string]*redis.Client),
-		ctx:      context.Background(),
+		nodesMu:  &sync.RWMutex{},
+		ctx:      ctx,
 		user:     user,
 		password: password,
 	}
 }
 
+// WithContext returns a manager bound to ctx that shares this manager's connections.
+// Steps that must complete after an interrupt (closing the slot in flight, rollback)
+// use it with context.WithoutCancel.
+func (cm *ClusterManager) WithContext(ctx context.Context) *ClusterManager {
+	clone := *cm
+	clone.ctx = ctx
+	return &clone
+}
+
 // Connect connects to a Redis node
+// Connections are cached per normalized endpoint, so "localhost:7001" and
+// "127.0.0.1:7001@17001" share the same client.
 func (cm *ClusterManager) Connect(address string) (*redis.Client, error) {
-	// First, try to get existing connection with read lock
-	cm.nodesMu.RLock()
-	if client, exists := cm.nodes[address]; exists {
-		cm.nodesMu.RUnlock()
-		return client, nil
-	}
-	cm.nodesMu.RUnlock()
-
-	// If connection doesn't exist, acquire write lock to create new connection
-	cm.nodesMu.Lock()
-	defer cm.nodesMu.Unlock()
-
-	// Double-check pattern: another goroutine might have created the connection
-	if client, exists := cm.nodes[address]; exists {
-		return client, nil
-	}
-
 	// Parse address
-	host, port, err := parseAddress(address)
+	addr, err := ParseAddress(address)
 	if err != nil {
 		return nil, fmt.Errorf("주소 파싱 실패: %w", err)
 	}
+	endpoint := addr.Endpoint(config.IsTLSEnabled())
 
-	// Create Redis client options
-	opts := &redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", host, port),
-		Username: cm.user,
-		Password: cm.password,
-		DB:       0, // Redis Cluster only supports DB 0
-
-		// Connection settings
-		DialTimeout:  10 * time.Second,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-
-		// Pool settings
-		PoolSize:     10,
-		MinIdleConns: 5,
+	// First, try to get existing connection with read lock
+	cm.nodesMu.RLock()
+	if client, exists := cm.nodes[endpoint]; exists {
+		cm.nodesMu.RUnlock()
+		return client, nil
 	}
+	cm.nodesMu.RUnlock()
 
-	client := redis.NewClient(opts)
+	// Pick the credential and test the connection without holding the lock:
+	// credential probes can take a connect timeout per candidate and must not
+	// block parallel connections to other nodes
+	cred := credentialFor(cm.ctx, endpoint, Credential{User: cm.user, Password: cm.password})
+	client := redis.NewClient(nodeOptions(endpoint, cred.User, cred.Password))
 
 	// Test connection
 	if err := client.Ping(cm.ctx).Err(); err != nil {
+		client.Close()
 		return nil, fmt.Errorf("redis 연결 실패 (%s): %w", address, err)
 	}
 
+	cm.nodesMu.Lock()
+	defer cm.nodesMu.Unlock()
+
+	// Double-check pattern: another goroutine might have connected in the meantime
+	if existing, exists := cm.nodes[endpoint]; exists {
+		client.Close()
+		return existing, nil
+	}
+
 	// Thread-safe write to map
-	cm.nodes[address] = client
+	cm.nodes[endpoint] = client
 	return client, nil
 }
 
-// GetClusterNodes retrieves cluster nodes information
+// GetClusterNodes returns the nodes as seen by the given node.
+// It uses the same loader as LoadTopology, so shard health, hostname and
+// tls-port from CLUSTER SHARDS are filled in where the server provides them.
 func (cm *ClusterManager) GetClusterNodes(address string) ([]ClusterNode, error) {
-	client, err := cm.Connect(address)
+	topology, err := cm.LoadTopology(address)
 	if err != nil {
 		return nil, err
 	}
+	return topology.Nodes, nil
+}
 
-	result, err := client.ClusterNodes(cm.ctx).Result()
+// LoadTopology loads the cluster topology as seen by the given node
+// (CLUSTER SHARDS first, CLUSTER NODES on older servers)
+func (cm *ClusterManager) LoadTopology(address string) (*Topology, error) {
+	client, err := cm.Connect(address)
 	if err != nil {
-		return nil, fmt.Errorf("CLUSTER NODES 명령 실패: %w", err)
+		return nil, err
 	}
 
-	return parseClusterNodes(result), nil
+	return LoadTopology(cm.ctx, client)
 }
 
 // GetClusterInfo retrieves cluster information
@@ -136,117 +126,37 @@ func (cm *ClusterManager) GetClusterInfo(address string) (map[string]string, err
 	return parseClusterInfo(result), nil
 }
 
+// GetInfo retrieves one INFO section (server, cluster, ...) as key/value pairs
+func (cm *ClusterManager) GetInfo(address, section string) (map[string]string, error) {
+	client, err := cm.Connect(address)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Info(cm.ctx, section).Result()
+	if err != nil {
+		return nil, fmt.Errorf("INFO %s 명령 실패: %w", section, err)
+	}
+
+	return parseClusterInfo(result), nil
+}
+
 // Close closes all connections
 func (cm *ClusterManager) Close() error {
 	cm.nodesMu.Lock()
 	defer cm.nodesMu.Unlock()
 
-	for _, client := range cm.nodes {
+	for endpoint, client := range cm.nodes {
 		if err := client.Close(); err != nil {
 			return err
 		}
+		delete(cm.nodes, endpoint)
 	}
-	cm.nodes = make(map[string]*redis.Client)
 	return nil
 }
 
 // Helper functions
 
-func parseAddress(address string) (string, int, error) {
-	parts := strings.Split(address, ":")
-	if len(parts) != 2 {
-		return "", 0, fmt.Errorf("잘못된 주소 형식: %s (예: localhost:6379)", address)
-	}
-
-	host := parts[0]
-	if host == "" || host == "localhost" {
-		host = "127.0.0.1"
-	}
-
-	port, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return "", 0, fmt.Errorf("잘못된 포트: %s", parts[1])
-	}
-
-	if port < 1 || port > 65535 {
-		return "", 0, fmt.Errorf("포트 범위 오류: %d (1-65535)", port)
-	}
-
-	return host, port, nil
-}
-
-func parseClusterNodes(output string) []ClusterNode {
-	var nodes []ClusterNode
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		fields := strings.Fields(line)
-		if len(fields) < 8 {
-			continue
-		}
-
-		node := ClusterNode{
-			ID:        fields[0],
-			Address:   fields[1],
-			Flags:     strings.Split(fields[2], ","),
-			Master:    fields[3],
-			LinkState: fields[7],
-		}
-
-		// Parse ping sent and pong received
-		if len(fields) > 4 {
-			if val, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
-				node.PingSent = val
-			}
-		}
-		if len(fields) > 5 {
-			if val, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
-				node.PongRecv = val
-			}
-		}
-		if len(fields) > 6 {
-			if val, err := strconv.ParseInt(fields[6], 10, 64); err == nil {
-				node.ConfigEpoch = val
-			}
-		}
-
-		// Parse slots
-		if len(fields) > 8 {
-			for i := 8; i < len(fields); i++ {
-				if slotRange := parseSlotRange(fields[i]); slotRange != nil {
-					node.Slots = append(node.Slots, *slotRange)
-				}
-			}
-		}
-
-		nodes = append(nodes, node)
-	}
-
-	return nodes
-}
-
-func parseSlotRange(slot string) *SlotRange {
-	if strings.Contains(slot, "-") {
-		parts := strings.Split(slot, "-")
-		if len(parts) == 2 {
-			start, err1 := strconv.Atoi(parts[0])
-			end, err2 := strconv.Atoi(parts[1])
-			if err1 == nil && err2 == nil {
-				return &SlotRange{Start: start, End: end}
-			}
-		}
-	} else {
-		if num, err := strconv.Atoi(slot); err == nil {
-			return &SlotRange{Start: num, End: num}
-		}
-	}
-	return nil
-}
-
 func parseClusterInfo(output string) map[string]string {
 	info := make(map[string]string)
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"time"
+
+	"redisctl/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 모든 명령어는 이 파일의 팩토리를 통해 연결을 만든다.
+// 타임아웃, 풀 크기, 재시도/백오프, 인증, TLS 설정을 한 곳에서 적용하기 위함.
+
+// NodeOptions builds client options for a single node from the global config.
+// Credentials come from CredentialFor, so per-node overrides apply.
+func NodeOptions(address string) *redis.Options {
+	cred := CredentialFor(address)
+	return nodeOptions(address, cred.User, cred.Password)
+}
+
+// NewNodeClient creates a client for a single node (no connection is made until first use)
+func NewNodeClient(address string) *redis.Client {
+	return redis.NewClient(NodeOptions(address))
+}
+
+// NewClusterClient creates a cluster-aware client seeded with the given addresses
+func NewClusterClient(addrs ...string) *redis.ClusterClient {
+	user, password := config.GetAuth()
+	minBackoff, maxBackoff := config.GetRetryBackoff()
+
+	return redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:     addrs,
+		Username:  user,
+		Password:  password,
+		TLSConfig: config.GetTLSConfig(),
+
+		DialTimeout:  config.GetConnectTimeout(),
+		ReadTimeout:  config.GetCommandTimeout(),
+		WriteTimeout: config.GetCommandTimeout(),
+
+		PoolSize: config.GetPoolSize(),
+
+		MaxRetries:      config.GetMaxRetries(),
+		MinRetryBackoff: minBackoff,
+		MaxRetryBackoff: maxBackoff,
+
+		// 노드별 인증 정보 적용 (비밀번호 교체 중인 클러스터)
+		NewClient: func(opt *redis.Options) *redis.Client {
+			cred := CredentialFor(opt.Addr)
+			opt.Username, opt.Password = cred.User, cred.Password
+			return redis.NewClient(opt)
+		},
+	})
+}
+
+// MigrateTimeout returns the timeout passed to MIGRATE.
+// It is half of the command timeout so that the server reports an IOERR
+// before the client side read timeout fires.
+func MigrateTimeout() time.Duration {
+	timeout := config.GetCommandTimeout() / 2
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+	return timeout
+}
+
+func nodeOptions(address, user, password string) *redis.Options {
+	minBackoff, maxBackoff := config.GetRetryBackoff()
+
+	return &redis.Options{
+		Addr:      address,
+		Username:  user,
+		Password:  password,
+		DB:        0, // Redis Cluster only supports DB 0
+		TLSConfig: config.GetTLSConfig(),
+
+		// Connection settings
+		DialTimeout:  config.GetConnectTimeout(),
+		ReadTimeout:  config.GetCommandTimeout(),
+		WriteTimeout: config.GetCommandTimeout(),
+
+		// Pool settings
+		PoolSize: config.GetPoolSize(),
+
+		// Retry settings
+		MaxRetries:      config.GetMaxRetries(),
+		MinRetryBackoff: minBackoff,
+		MaxRetryBackoff: maxBackoff,
+	}
+}
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"redisctl/internal/config"
+)
+
+// TestConnectionOptions tests that the configured timeouts, pool size, retries and TLS
+// reach the options of every client the factory builds
+func TestConnectionOptions(t *testing.T) {
+	t.Cleanup(func() {
+		config.Init()
+		config.SetTLS(config.TLSOptions{})
+	})
+
+	type expected struct {
+		dial, command, migrate time.Duration
+		pool, retries          int
+		minBackoff, maxBackoff time.Duration
+		tls                    bool
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		tls  config.TLSOptions
+		want expected
+	}{
+		{
+			name: "defaults",
+			want: expected{dial: 10 * time.Second, command: 60 * time.Second, migrate: 30 * time.Second,
+				pool: 10, retries: 3, minBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second},
+		},
+		{
+			name: "long command timeout for big keys",
+			env:  map[string]string{"REDIS_COMMAND_TIMEOUT": "120s", "REDIS_CONNECT_TIMEOUT": "3s"},
+			want: expected{dial: 3 * time.Second, command: 120 * time.Second, migrate: 60 * time.Second,
+				pool: 10, retries: 3, minBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second},
+		},
+		{
+			name: "migrate timeout has a 1s minimum",
+			env:  map[string]string{"REDIS_COMMAND_TIMEOUT": "1500ms"},
+			want: expected{dial: 10 * time.Second, command: 1500 * time.Millisecond, migrate: time.Second,
+				pool: 10, retries: 3, minBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second},
+		},
+		{
+			name: "pool, retries and backoff",
+			env: map[string]string{"REDIS_POOL_SIZE": "32", "REDIS_MAX_RETRIES": "7",
+				"REDIS_MIN_RETRY_BACKOFF": "50ms", "REDIS_MAX_RETRY_BACKOFF": "20ms"},
+			// a max backoff below the min is raised to the min
+			want: expected{dial: 10 * time.Second, command: 60 * time.Second, migrate: 30 * time.Second,
+				pool: 32, retries: 7, minBackoff: 50 * time.Millisecond, maxBackoff: 50 * time.Millisecond},
+		},
+		{
+			name: "tls",
+			tls:  config.TLSOptions{Enabled: true, EnabledSet: true, ServerName: "redis.internal"},
+			want: expected{dial: 10 * time.Second, command: 60 * time.Second, migrate: 30 * time.Second,
+				pool: 10, retries: 3, minBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second, tls: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"REDIS_CONNECT_TIMEOUT", "REDIS_COMMAND_TIMEOUT", "REDIS_POOL_SIZE",
+				"REDIS_MAX_RETRIES", "REDIS_MIN_RETRY_BACKOFF", "REDIS_MAX_RETRY_BACKOFF"} {
+				t.Setenv(key, tt.env[key])
+			}
+			config.Init()
+			if err := config.SetTLS(tt.tls); err != nil {
+				t.Fatal(err)
+			}
+
+			node := NodeOptions("127.0.0.1:7001")
+			cluster := NewClusterClient("127.0.0.1:7001")
+			defer cluster.Close()
+			clusterOpts := cluster.Options()
+
+			checks := []struct {
+				field     string
+				node, clu any
+				want      any
+			}{
+				{"DialTimeout", node.DialTimeout, clusterOpts.DialTimeout, tt.want.dial},
+				{"ReadTimeout", node.ReadTimeout, clusterOpts.ReadTimeout, tt.want.command},
+				{"WriteTimeout", node.WriteTimeout, clusterOpts.WriteTimeout, tt.want.command},
+				{"PoolSize", node.PoolSize, clusterOpts.PoolSize, tt.want.pool},
+				{"MaxRetries", node.MaxRetries, clusterOpts.MaxRetries, tt.want.retries},
+				{"MinRetryBackoff", node.MinRetryBackoff, clusterOpts.MinRetryBackoff, tt.want.minBackoff},
+				{"MaxRetryBackoff", node.MaxRetryBackoff, clusterOpts.MaxRetryBackoff, tt.want.maxBackoff},
+				{"TLSConfig", node.TLSConfig != nil, clusterOpts.TLSConfig != nil, tt.want.tls},
+			}
+			for _, c := range checks {
+				if c.node != c.want {
+					t.Errorf("NodeOptions().%s = %v, want %v", c.field, c.node, c.want)
+				}
+				if c.clu != c.want {
+					t.Errorf("NewClusterClient().Options().%s = %v, want %v", c.field, c.clu, c.want)
+				}
+			}
+			if tt.want.tls && node.TLSConfig.ServerName != "redis.internal" {
+				t.Errorf("NodeOptions().TLSConfig.ServerName = %q, want redis.internal", node.TLSConfig.ServerName)
+			}
+
+			if got := MigrateTimeout(); got != tt.want.migrate {
+				t.Errorf("MigrateTimeout() = %v, want %v", got, tt.want.migrate)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"redisctl/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrorKind classifies Redis replies and transport errors seen during cluster operations
+type ErrorKind int
+
+const (
+	ErrorNone        ErrorKind = iota
+	ErrorMoved                 // MOVED: slot is served by another node
+	ErrorAsk                   // ASK: slot is being migrated, key lives on the target
+	ErrorTryAgain              // TRYAGAIN: multi-key request during slot migration
+	ErrorClusterDown           // CLUSTERDOWN: cluster is not serving the slot right now
+	ErrorLoading               // LOADING: node is loading its dataset
+	ErrorBusyKey               // BUSYKEY: MIGRATE target already has the key
+	ErrorIOErr                 // IOERR: MIGRATE could not talk to the target in time
+	ErrorNoAuth                // NOAUTH/WRONGPASS: missing or wrong credentials
+	ErrorNetwork               // connection refused/reset, EOF, timeouts
+	ErrorCanceled              // context cancelled or deadline exceeded
+	ErrorOther
+)
+
+// String returns the Redis error prefix (or a short name) for the kind
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorNone:
+		return "OK"
+	case ErrorMoved:
+		return "MOVED"
+	case ErrorAsk:
+		return "ASK"
+	case ErrorTryAgain:
+		return "TRYAGAIN"
+	case ErrorClusterDown:
+		return "CLUSTERDOWN"
+	case ErrorLoading:
+		return "LOADING"
+	case ErrorBusyKey:
+		return "BUSYKEY"
+	case ErrorIOErr:
+		return "IOERR"
+	case ErrorNoAuth:
+		return "NOAUTH"
+	case ErrorNetwork:
+		return "NETWORK"
+	case ErrorCanceled:
+		return "CANCELED"
+	default:
+		return "ERR"
+	}
+}
+
+// Retryable reports whether the same command may succeed if sent again after a backoff.
+// MOVED/ASK mean the slot owner changed under us, so retrying the same node does not help.
+func (k ErrorKind) Retryable() bool {
+	switch k {
+	case ErrorTryAgain, ErrorClusterDown, ErrorLoading, ErrorIOErr, ErrorNetwork:
+		return true
+	default:
+		return false
+	}
+}
+
+// Hint returns a short Korean explanation for errors that need operator action
+func (k ErrorKind) Hint() string {
+	switch k {
+	case ErrorMoved, ErrorAsk:
+		return "슬롯 소유권이 변경되었습니다. 'redisctl check'로 클러스터 상태를 확인하세요"
+	case ErrorBusyKey:
+		return "대상 노드에 같은 키가 이미 있습니다. 중복 키를 정리한 후 다시 시도하세요"
+	case ErrorNoAuth:
+		return "인증에 실패했습니다. 대상 노드의 사용자명/비밀번호를 확인하세요"
+	case ErrorIOErr, ErrorNetwork:
+		return "대상 노드와의 통신이 불안정합니다. 네트워크와 REDIS_COMMAND_TIMEOUT을 확인하세요"
+	case ErrorClusterDown, ErrorLoading:
+		return "클러스터 또는 노드가 아직 준비되지 않았습니다"
+	default:
+		return ""
+	}
+}
+
+// redisErrorPrefixes maps reply prefixes to kinds, checked in order
+var redisErrorPrefixes = []struct {
+	prefix string
+	kind   ErrorKind
+}{
+	{"MOVED", ErrorMoved},
+	{"ASK", ErrorAsk},
+	{"TRYAGAIN", ErrorTryAgain},
+	{"CLUSTERDOWN", ErrorClusterDown},
+	{"LOADING", ErrorLoading},
+	{"BUSYKEY", ErrorBusyKey},
+	{"IOERR", ErrorIOErr},
+	{"NOAUTH", ErrorNoAuth},
+	{"WRONGPASS", ErrorNoAuth},
+}
+
+// ClassifyError maps an error returned by go-redis to an ErrorKind
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorNone
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorCanceled
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		for _, p := range redisErrorPrefixes {
+			if redis.HasErrorPrefix(err, p.prefix) {
+				return p.kind
+			}
+		}
+		// MIGRATE wraps the target's reply: "ERR Target instance replied with error: NOAUTH ..."
+		// Redis 5 and earlier answer a wrong AUTH with "ERR invalid password".
+		msg := redisErr.Error()
+		if strings.Contains(msg, "NOAUTH") || strings.Contains(msg, "WRONGPASS") ||
+			strings.Contains(msg, "invalid password") || strings.Contains(msg, "without any password configured") {
+			return ErrorNoAuth
+		}
+		return ErrorOther
+	}
+
+	if errors.Is(err, redis.ErrClosed) {
+		return ErrorOther
+	}
+
+	var netErr net.Error
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, redis.ErrPoolTimeout) ||
+		errors.As(err, &netErr) {
+		return ErrorNetwork
+	}
+
+	return ErrorOther
+}
+
+// RetryPolicy controls how migrations retry transient errors.
+// The backoff doubles per retry, starting at MinBackoff and capped at MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the migration retry policy from the global config
+func DefaultRetryPolicy() RetryPolicy {
+	retries, minBackoff, maxBackoff := config.GetMigrateRetryPolicy()
+	return RetryPolicy{
+		MaxRetries: retries,
+		MinBackoff: minBackoff,
+		MaxBackoff: maxBackoff,
+	}
+}
+
+// Backoff returns the wait before the given retry (0-based)
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 0; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// RetryError is returned by RetryPolicy.Do when fn keeps failing or fails permanently
+type RetryError struct {
+	Kind     ErrorKind
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	msg := fmt.Sprintf("%s 오류 (시도 %d회): %v", e.Kind, e.Attempts, e.Err)
+	if hint := e.Kind.Hint(); hint != "" {
+		msg += " - " + hint
+	}
+	return msg
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Do runs fn and retries it while the error is retryable.
+// Waiting between retries stops early when ctx is cancelled.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	return p.DoAttempt(ctx, func(int) error { return fn() })
+}
+
+// DoAttempt is Do for commands that change on retries; fn gets the 0-based attempt
+func (p RetryPolicy) DoAttempt(ctx context.Context, fn func(attempt int) error) error {
+	for retry := 0; ; retry++ {
+		err := fn(retry)
+		if err == nil {
+			return nil
+		}
+
+		kind := ClassifyError(err)
+		if !kind.Retryable() || retry >= p.MaxRetries {
+			return &RetryError{Kind: kind, Attempts: retry + 1, Err: err}
+		}
+
+		timer := time.NewTimer(p.Backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Kind: kind, Attempts: retry + 1, Err: err}
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// testRedisError mimics a server error reply
+type testRedisError string
+
+func (e testRedisError) Error() string { return string(e) }
+func (testRedisError) RedisError()     {}
+
+// TestClassifyError tests error classification for server replies and transport errors
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		expected  ErrorKind
+		retryable bool
+	}{
+		{"nil", nil, ErrorNone, false},
+		{"moved", testRedisError("MOVED 3999 127.0.0.1:7002"), ErrorMoved, false},
+		{"ask", testRedisError("ASK 3999 127.0.0.1:7002"), ErrorAsk, false},
+		{"tryagain", testRedisError("TRYAGAIN Multiple keys request during rehashing of slot"), ErrorTryAgain, true},
+		{"clusterdown", testRedisError("CLUSTERDOWN The cluster is down"), ErrorClusterDown, true},
+		{"loading", testRedisError("LOADING Redis is loading the dataset in memory"), ErrorLoading, true},
+		{"busykey", testRedisError("BUSYKEY Target key name already exists."), ErrorBusyKey, false},
+		{"ioerr", testRedisError("IOERR error or timeout reading to target instance"), ErrorIOErr, true},
+		{"noauth", testRedisError("NOAUTH Authentication required."), ErrorNoAuth, false},
+		{"legacy invalid password", testRedisError("ERR invalid password"), ErrorNoAuth, false},
+		{"migrate target noauth", testRedisError("ERR Target instance replied with error: NOAUTH Authentication required."), ErrorNoAuth, false},
+		{"wrapped reply", fmt.Errorf("MIGRATE 실패: %w", testRedisError("IOERR timeout")), ErrorIOErr, true},
+		{"other reply", testRedisError("ERR unknown command"), ErrorOther, false},
+		{"eof", io.EOF, ErrorNetwork, true},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), ErrorNetwork, true},
+		{"canceled", context.Canceled, ErrorCanceled, false},
+		{"plain error", errors.New("something"), ErrorOther, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := ClassifyError(tt.err)
+			if kind != tt.expected {
+				t.Errorf("ClassifyError(%v) = %s, want %s", tt.err, kind, tt.expected)
+			}
+			if kind.Retryable() != tt.retryable {
+				t.Errorf("%s.Retryable() = %t, want %t", kind, kind.Retryable(), tt.retryable)
+			}
+		})
+	}
+}
+
+// TestRetryPolicyBackoff tests exponential backoff with a cap
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for retry, want := range expected {
+		if got := policy.Backoff(retry); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+// TestRetryPolicyDo tests that only retryable errors are retried
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("transient then success", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return testRedisError("TRYAGAIN")
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("Do() = %v after %d calls, want nil after 3", err, calls)
+		}
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return testRedisError("BUSYKEY Target key name already exists.")
+		})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || retryErr.Kind != ErrorBusyKey || calls != 1 {
+			t.Errorf("Do() = %v after %d calls, want BUSYKEY after 1", err, calls)
+		}
+	})
+
+	t.Run("attempt numbers", func(t *testing.T) {
+		var attempts []int
+		err := policy.DoAttempt(context.Background(), func(attempt int) error {
+			attempts = append(attempts, attempt)
+			if attempt < 2 {
+				return testRedisError("IOERR timeout")
+			}
+			return nil
+		})
+		if err != nil || fmt.Sprint(attempts) != "[0 1 2]" {
+			t.Errorf("DoAttempt() = %v with attempts %v, want nil with [0 1 2]", err, attempts)
+		}
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return testRedisError("IOERR timeout")
+		})
+		var retryErr *RetryError
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 4 || calls != 4 {
+			t.Errorf("Do() = %v after %d calls, want 4 attempts", err, calls)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"redisctl/internal/config"
+)
+
+// SeedAttempt records how one seed answered during selection
+type SeedAttempt struct {
+	Address      string
+	ClusterState string // cluster_state reported by the seed, empty when unreachable
+	Consistent   bool
+	Reason       string // why the seed was skipped
+	Err          error
+}
+
+// SeedSelection is the entry node chosen from a seed list
+type SeedSelection struct {
+	Address    string // seed used as entry point
+	Topology   *Topology
+	Consistent bool // false when every reachable seed had an inconsistent view
+	Seeds      []string
+	Attempts   []SeedAttempt
+}
+
+// Addresses returns the chosen seed first, followed by the remaining seeds
+// (used to seed cluster clients so that go-redis can fail over as well)
+func (s *SeedSelection) Addresses() []string {
+	addrs := []string{s.Address}
+	for _, seed := range s.Seeds {
+		if seed != s.Address {
+			addrs = append(addrs, seed)
+		}
+	}
+	return addrs
+}
+
+// ParseSeeds splits comma-separated seed lists and removes duplicates, keeping the order.
+// Addresses are compared after normalization, so localhost:7001 and 127.0.0.1:7001 are the same seed.
+func ParseSeeds(values ...string) ([]string, error) {
+	var seeds []string
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+
+			addr, err := ParseAddress(field)
+			if err != nil {
+				return nil, fmt.Errorf("잘못된 시드 주소 %q: %w", field, err)
+			}
+			if seen[addr.String()] {
+				continue
+			}
+			seen[addr.String()] = true
+			seeds = append(seeds, field)
+		}
+	}
+
+	return seeds, nil
+}
+
+// SelectSeed tries seeds in order and returns the first reachable node with a consistent view:
+// it reports cluster_state:ok and does not consider itself failed or loading.
+// When no seed is consistent the first reachable one is returned with Consistent=false,
+// so that read-only commands can still report a broken cluster.
+func SelectSeed(ctx context.Context, seeds []string) (*SeedSelection, error) {
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("시드 노드가 지정되지 않았습니다")
+	}
+
+	selection := &SeedSelection{Seeds: seeds}
+	fallback := -1
+	var fallbackTopology *Topology
+	var errs []error
+
+	for _, seed := range seeds {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		attempt, topology := probeSeed(ctx, seed)
+		selection.Attempts = append(selection.Attempts, attempt)
+
+		if attempt.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", seed, attempt.Err))
+			continue
+		}
+
+		if attempt.Consistent {
+			selection.Address = seed
+			selection.Topology = topology
+			selection.Consistent = true
+			return selection, nil
+		}
+
+		if fallback < 0 {
+			fallback = len(selection.Attempts) - 1
+			fallbackTopology = topology
+		}
+	}
+
+	if fallback < 0 {
+		return nil, fmt.Errorf("연결 가능한 시드 노드가 없습니다: %w", errors.Join(errs...))
+	}
+
+	selection.Address = selection.Attempts[fallback].Address
+	selection.Topology = fallbackTopology
+	return selection, nil
+}
+
+// probeSeed connects to one seed and checks its view of the cluster
+func probeSeed(ctx context.Context, seed string) (SeedAttempt, *Topology) {
+	attempt := SeedAttempt{Address: seed}
+
+	addr, err := ParseAddress(seed)
+	if err != nil {
+		attempt.Err = err
+		return attempt, nil
+	}
+
+	client := NewNodeClient(addr.Endpoint(config.IsTLSEnabled()))
+	defer client.Close()
+
+	info, err := client.ClusterInfo(ctx).Result()
+	if err != nil {
+		attempt.Err = err
+		return attempt, nil
+	}
+	attempt.ClusterState = parseClusterInfo(info)["cluster_state"]
+
+	topology, err := LoadTopology(ctx, client)
+	if err != nil {
+		attempt.Err = err
+		return attempt, nil
+	}
+
+	switch myself, ok := topology.Myself(); {
+	case attempt.ClusterState != "ok":
+		attempt.Reason = fmt.Sprintf("cluster_state:%s", attempt.ClusterState)
+	case ok && myself.IsFail():
+		attempt.Reason = "자신을 실패 상태로 인식"
+	case ok && myself.IsLoading():
+		attempt.Reason = "데이터 로딩 중"
+	default:
+		attempt.Consistent = true
+	}
+
+	return attempt, topology
+}
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseSeeds tests splitting and de-duplication of seed lists
+func TestParseSeeds(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []string
+		wantErr  bool
+	}{
+		{"single", []string{"127.0.0.1:7001"}, []string{"127.0.0.1:7001"}, false},
+		{"comma separated", []string{"127.0.0.1:7001, 127.0.0.1:7002"}, []string{"127.0.0.1:7001", "127.0.0.1:7002"}, false},
+		{"argument and flags", []string{"127.0.0.1:7001", "127.0.0.1:7002", "127.0.0.1:7003"}, []string{"127.0.0.1:7001", "127.0.0.1:7002", "127.0.0.1:7003"}, false},
+		{"duplicate after normalization", []string{"localhost:7001,127.0.0.1:7001", "127.0.0.1:7002"}, []string{"localhost:7001", "127.0.0.1:7002"}, false},
+		{"empty entries", []string{"", ",127.0.0.1:7001,"}, []string{"127.0.0.1:7001"}, false},
+		{"invalid address", []string{"127.0.0.1"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeds, err := ParseSeeds(tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeeds(%q) error = %v, wantErr %t", tt.values, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(seeds, tt.expected) {
+				t.Errorf("ParseSeeds(%q) = %q, want %q", tt.values, seeds, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSeedSelectionAddresses tests that the chosen seed comes first
+func TestSeedSelectionAddresses(t *testing.T) {
+	selection := &SeedSelection{
+		Address: "127.0.0.1:7002",
+		Seeds:   []string{"127.0.0.1:7001", "127.0.0.1:7002", "127.0.0.1:7003"},
+	}
+
+	expected := []string{"127.0.0.1:7002", "127.0.0.1:7001", "127.0.0.1:7003"}
+	if got := selection.Addresses(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Addresses() = %q, want %q", got, expected)
+	}
+}
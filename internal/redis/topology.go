@@ -0,0 +1,732 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"redisctl/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ClusterSlots is the number of hash slots in a Redis cluster
+const ClusterSlots = 16384
+
+// Node health reported by CLUSTER SHARDS
+const (
+	HealthOnline  = "online"
+	HealthFailed  = "failed"
+	HealthLoading = "loading"
+)
+
+// Topology sources
+const (
+	SourceClusterShards = "CLUSTER SHARDS"
+	SourceClusterNodes  = "CLUSTER NODES"
+)
+
+// ClusterNode represents a Redis cluster node
+type ClusterNode struct {
+	ID          string
+	Address     string // CLUSTER NODES form: ip:port@cport[,hostname[,aux=value...]]
+	Flags       []string
+	Master      string // master node ID, "-" for masters
+	Slots       []SlotRange
+	LinkState   string
+	PingSent    int64
+	PongRecv    int64
+	ConfigEpoch int64
+
+	// Reported by CLUSTER SHARDS (Redis 7+), or derived from the address field
+	Hostname          string
+	TLSPort           int
+	Health            string // online, failed, loading ("" when unknown)
+	ReplicationOffset int64
+
+	// Slots left MIGRATING/IMPORTING. CLUSTER NODES only prints them on the myself line.
+	OpenSlots []OpenSlot
+}
+
+// SlotRange represents a range of hash slots
+type SlotRange struct {
+	Start int
+	End   int
+}
+
+// Open slot states
+const (
+	SlotMigrating = "migrating"
+	SlotImporting = "importing"
+)
+
+// OpenSlot is a slot a node reports as [slot->-peer] (migrating) or [slot-<-peer] (importing)
+type OpenSlot struct {
+	Slot   int
+	State  string // migrating or importing
+	NodeID string // node reporting the state
+	PeerID string // destination when migrating, source when importing
+}
+
+// SlotMigration is an open slot seen from both ends of the migration
+type SlotMigration struct {
+	Slot      int
+	SourceID  string
+	TargetID  string
+	Migrating bool // source reports MIGRATING
+	Importing bool // target reports IMPORTING
+}
+
+// NodeFlags is the parsed form of the CLUSTER NODES flags field
+type NodeFlags struct {
+	IsMaster    bool
+	IsReplica   bool
+	IsFail      bool
+	IsHandshake bool
+	IsNoAddr    bool
+	IsNoFlags   bool
+}
+
+// ParseNodeFlags parses CLUSTER NODES flags. Every command reads flags through it.
+func ParseNodeFlags(flags []string) NodeFlags {
+	if len(flags) == 0 {
+		return NodeFlags{IsNoFlags: true}
+	}
+
+	var nodeFlags NodeFlags
+	for _, flag := range flags {
+		switch strings.TrimSpace(flag) {
+		case "master":
+			nodeFlags.IsMaster = true
+		case "slave":
+			nodeFlags.IsReplica = true
+		case "fail":
+			nodeFlags.IsFail = true
+		case "handshake":
+			nodeFlags.IsHandshake = true
+		case "noaddr":
+			nodeFlags.IsNoAddr = true
+		}
+	}
+
+	return nodeFlags
+}
+
+// Topology is a snapshot of cluster membership and slot ownership as seen by one node.
+// Every command reads the cluster through it instead of parsing CLUSTER NODES itself.
+type Topology struct {
+	Source string // CLUSTER SHARDS or CLUSTER NODES (fallback)
+	Nodes  []ClusterNode
+
+	byID      map[string]int
+	replicas  map[string][]int
+	slotOwner [ClusterSlots]int32 // index into Nodes + 1, 0 when unassigned
+}
+
+// NewTopology builds the lookup indexes (ID, master→replicas, slot→owner) over nodes
+func NewTopology(source string, nodes []ClusterNode) *Topology {
+	t := &Topology{
+		Source:   source,
+		Nodes:    nodes,
+		byID:     make(map[string]int, len(nodes)),
+		replicas: make(map[string][]int),
+	}
+
+	for i, n := range nodes {
+		t.byID[n.ID] = i
+
+		if n.IsReplica() {
+			t.replicas[n.Master] = append(t.replicas[n.Master], i)
+			continue
+		}
+		for _, r := range n.Slots {
+			for slot := max(r.Start, 0); slot <= r.End && slot < ClusterSlots; slot++ {
+				t.slotOwner[slot] = int32(i + 1)
+			}
+		}
+	}
+
+	return t
+}
+
+// ParseTopology builds a topology from CLUSTER NODES output
+func ParseTopology(output string) *Topology {
+	return NewTopology(SourceClusterNodes, parseClusterNodes(output))
+}
+
+// topologyClient is the subset of *redis.Client that LoadTopology uses
+type topologyClient interface {
+	ClusterShards(ctx context.Context) *redis.ClusterShardsCmd
+	ClusterNodes(ctx context.Context) *redis.StringCmd
+}
+
+// LoadTopology loads the topology as seen by one node, preferring CLUSTER SHARDS (Redis 7+)
+// and falling back to CLUSTER NODES on older servers.
+// CLUSTER SHARDS does not report flags, epochs or link state, so CLUSTER NODES from the
+// same node is merged into the shard view when available. A node client is required:
+// a cluster client would send each command to a random node and mix two nodes' views.
+func LoadTopology(ctx context.Context, client *redis.Client) (*Topology, error) {
+	return loadTopology(ctx, client)
+}
+
+// LoadClusterTopology loads the topology from a single node of a cluster client:
+// the first of its seed addresses that answers (the selected seed comes first)
+func LoadClusterTopology(ctx context.Context, client *redis.ClusterClient) (*Topology, error) {
+	var errs []error
+	for _, addr := range client.Options().Addrs {
+		node := NewNodeClient(addr)
+		t, err := LoadTopology(ctx, node)
+		node.Close()
+		if err == nil {
+			return t, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("클러스터 토폴로지 조회 실패: 시드 주소가 없습니다")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func loadTopology(ctx context.Context, client topologyClient) (*Topology, error) {
+	shards, shardsErr := client.ClusterShards(ctx).Result()
+	if shardsErr != nil || len(shards) == 0 {
+		nodesOutput, err := client.ClusterNodes(ctx).Result()
+		if err != nil {
+			return nil, fmt.Errorf("클러스터 토폴로지 조회 실패: %w", err)
+		}
+		t := NewTopology(SourceClusterNodes, parseClusterNodes(nodesOutput))
+		rememberNodeIDs(t)
+		return t, nil
+	}
+
+	nodes := nodesFromShards(shards)
+	if nodesOutput, err := client.ClusterNodes(ctx).Result(); err == nil {
+		nodes = mergeNodesView(nodes, parseClusterNodes(nodesOutput))
+	}
+
+	t := NewTopology(SourceClusterShards, nodes)
+	rememberNodeIDs(t)
+	return t, nil
+}
+
+// NodeByID returns the node with the given ID
+func (t *Topology) NodeByID(id string) (ClusterNode, bool) {
+	i, ok := t.byID[id]
+	if !ok {
+		return ClusterNode{}, false
+	}
+	return t.Nodes[i], true
+}
+
+// NodeByAddress returns the node listening on the given address (any accepted address form)
+func (t *Topology) NodeByAddress(address string) (ClusterNode, bool) {
+	want, err := ParseAddress(address)
+	if err != nil {
+		return ClusterNode{}, false
+	}
+
+	for _, n := range t.Nodes {
+		addr, err := ParseAddress(n.Address)
+		if err != nil {
+			continue
+		}
+		if addr.Host == want.Host && (addr.Port == want.Port || addr.TLSPort == want.Port || addr.TCPPort == want.Port) {
+			return n, true
+		}
+	}
+	return ClusterNode{}, false
+}
+
+// Myself returns the node that produced the CLUSTER NODES output
+func (t *Topology) Myself() (ClusterNode, bool) {
+	for _, n := range t.Nodes {
+		if n.IsMyself() {
+			return n, true
+		}
+	}
+	return ClusterNode{}, false
+}
+
+// Masters returns the master nodes
+func (t *Topology) Masters() []ClusterNode {
+	var masters []ClusterNode
+	for _, n := range t.Nodes {
+		if n.IsMaster() {
+			masters = append(masters, n)
+		}
+	}
+	return masters
+}
+
+// Replicas returns all replica nodes
+func (t *Topology) Replicas() []ClusterNode {
+	var replicas []ClusterNode
+	for _, n := range t.Nodes {
+		if n.IsReplica() {
+			replicas = append(replicas, n)
+		}
+	}
+	return replicas
+}
+
+// ReplicasOf returns the replicas of the given master
+func (t *Topology) ReplicasOf(masterID string) []ClusterNode {
+	var replicas []ClusterNode
+	for _, i := range t.replicas[masterID] {
+		replicas = append(replicas, t.Nodes[i])
+	}
+	return replicas
+}
+
+// SlotOwner returns the master serving the slot
+func (t *Topology) SlotOwner(slot int) (ClusterNode, bool) {
+	if slot < 0 || slot >= ClusterSlots || t.slotOwner[slot] == 0 {
+		return ClusterNode{}, false
+	}
+	return t.Nodes[t.slotOwner[slot]-1], true
+}
+
+// OpenSlots returns the open slots reported in this snapshot
+func (t *Topology) OpenSlots() []OpenSlot {
+	var open []OpenSlot
+	for _, n := range t.Nodes {
+		open = append(open, n.OpenSlots...)
+	}
+	return open
+}
+
+// CoveredSlots returns the number of slots assigned to some master
+func (t *Topology) CoveredSlots() int {
+	count := 0
+	for _, owner := range t.slotOwner {
+		if owner != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// NodeFlags returns the parsed flags
+func (n ClusterNode) NodeFlags() NodeFlags {
+	return ParseNodeFlags(n.Flags)
+}
+
+// HasFlag reports whether the node carries the given CLUSTER NODES flag
+func (n ClusterNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMaster reports whether the node replicates from a master
+func (n ClusterNode) HasMaster() bool {
+	return n.Master != "" && n.Master != "-"
+}
+
+// IsMaster reports whether the node is a master.
+// A node with a master ID is a replica, and a node serving slots is a master,
+// regardless of what the flags say mid-failover.
+func (n ClusterNode) IsMaster() bool {
+	if n.HasMaster() {
+		return false
+	}
+	return n.NodeFlags().IsMaster || len(n.Slots) > 0
+}
+
+// IsReplica reports whether the node is a replica
+func (n ClusterNode) IsReplica() bool {
+	if n.HasMaster() {
+		return true
+	}
+	return n.NodeFlags().IsReplica && len(n.Slots) == 0
+}
+
+// IsFail reports whether the node is marked failed (fail flag or CLUSTER SHARDS health)
+func (n ClusterNode) IsFail() bool {
+	return n.NodeFlags().IsFail || n.Health == HealthFailed
+}
+
+// IsPFail reports whether the node is suspected failed by this node (fail?)
+func (n ClusterNode) IsPFail() bool {
+	return n.HasFlag("fail?")
+}
+
+// IsHandshake reports whether the node is still in the MEET handshake
+func (n ClusterNode) IsHandshake() bool {
+	return n.NodeFlags().IsHandshake
+}
+
+// IsNoAddr reports whether the node address is unknown
+func (n ClusterNode) IsNoAddr() bool {
+	return n.NodeFlags().IsNoAddr
+}
+
+// IsMyself reports whether the node is the one that was queried
+func (n ClusterNode) IsMyself() bool {
+	return n.HasFlag("myself")
+}
+
+// IsLoading reports whether the node is still loading its dataset
+func (n ClusterNode) IsLoading() bool {
+	return n.Health == HealthLoading
+}
+
+// IsConnected reports whether the cluster bus link to the node is up
+func (n ClusterNode) IsConnected() bool {
+	return n.LinkState == "" || n.LinkState == "connected"
+}
+
+// IsHealthy reports whether the node is neither failed, suspected failed nor loading
+func (n ClusterNode) IsHealthy() bool {
+	return !n.IsFail() && !n.IsPFail() && !n.IsLoading()
+}
+
+// SlotCount returns the number of slots assigned to the node
+func (n ClusterNode) SlotCount() int {
+	count := 0
+	for _, r := range n.Slots {
+		count += r.End - r.Start + 1
+	}
+	return count
+}
+
+// SlotList expands the slot ranges into individual slots
+func (n ClusterNode) SlotList() []int {
+	slots := make([]int, 0, n.SlotCount())
+	for _, r := range n.Slots {
+		for slot := r.Start; slot <= r.End; slot++ {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// HostPort returns ip:port without the bus port and aux fields
+func (n ClusterNode) HostPort() string {
+	addr, err := ParseAddress(n.Address)
+	if err != nil {
+		return n.Address
+	}
+	return addr.String()
+}
+
+// Endpoint returns the address to connect to, using the TLS port when TLS is enabled
+func (n ClusterNode) Endpoint() string {
+	addr, err := ParseAddress(n.Address)
+	if err != nil {
+		return n.Address
+	}
+	return addr.Endpoint(config.IsTLSEnabled())
+}
+
+func parseClusterNodes(output string) []ClusterNode {
+	var nodes []ClusterNode
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+
+		node := ClusterNode{
+			ID:        fields[0],
+			Address:   fields[1],
+			Flags:     strings.Split(fields[2], ","),
+			Master:    fields[3],
+			LinkState: fields[7],
+		}
+
+		if addr, err := ParseAddress(fields[1]); err == nil {
+			node.Hostname = addr.Hostname
+			node.TLSPort = addr.TLSPort
+		}
+		if node.NodeFlags().IsFail {
+			node.Health = HealthFailed
+		}
+
+		// Parse ping sent and pong received
+		if val, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+			node.PingSent = val
+		}
+		if val, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
+			node.PongRecv = val
+		}
+		if val, err := strconv.ParseInt(fields[6], 10, 64); err == nil {
+			node.ConfigEpoch = val
+		}
+
+		// Parse slots
+		for _, field := range fields[8:] {
+			if strings.HasPrefix(field, "[") {
+				if open := parseOpenSlot(field, node.ID); open != nil {
+					node.OpenSlots = append(node.OpenSlots, *open)
+				}
+				continue
+			}
+			if slotRange := parseSlotRange(field); slotRange != nil {
+				node.Slots = append(node.Slots, *slotRange)
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func parseSlotRange(slot string) *SlotRange {
+	if strings.Contains(slot, "-") {
+		parts := strings.Split(slot, "-")
+		if len(parts) == 2 {
+			start, err1 := strconv.Atoi(parts[0])
+			end, err2 := strconv.Atoi(parts[1])
+			if err1 == nil && err2 == nil {
+				return &SlotRange{Start: start, End: end}
+			}
+		}
+	} else {
+		if num, err := strconv.Atoi(slot); err == nil {
+			return &SlotRange{Start: num, End: num}
+		}
+	}
+	return nil
+}
+
+// parseOpenSlot parses [slot->-peer] (migrating) and [slot-<-peer] (importing)
+func parseOpenSlot(field, nodeID string) *OpenSlot {
+	field = strings.TrimSuffix(strings.TrimPrefix(field, "["), "]")
+
+	state := SlotMigrating
+	slot, peer, ok := strings.Cut(field, "->-")
+	if !ok {
+		state = SlotImporting
+		slot, peer, ok = strings.Cut(field, "-<-")
+		if !ok {
+			return nil
+		}
+	}
+
+	num, err := strconv.Atoi(slot)
+	if err != nil || num < 0 || num >= ClusterSlots || peer == "" {
+		return nil
+	}
+	return &OpenSlot{Slot: num, State: state, NodeID: nodeID, PeerID: peer}
+}
+
+// GroupOpenSlots pairs the migrating and importing ends of each open slot, ordered by slot
+func GroupOpenSlots(open []OpenSlot) []SlotMigration {
+	bySlot := make(map[int]*SlotMigration)
+	var order []int
+
+	for _, o := range open {
+		m, ok := bySlot[o.Slot]
+		if !ok {
+			m = &SlotMigration{Slot: o.Slot}
+			bySlot[o.Slot] = m
+			order = append(order, o.Slot)
+		}
+
+		if o.State == SlotMigrating {
+			m.Migrating = true
+			m.SourceID = o.NodeID
+			if m.TargetID == "" {
+				m.TargetID = o.PeerID
+			}
+		} else {
+			m.Importing = true
+			m.TargetID = o.NodeID
+			if m.SourceID == "" {
+				m.SourceID = o.PeerID
+			}
+		}
+	}
+
+	sort.Ints(order)
+	migrations := make([]SlotMigration, 0, len(order))
+	for _, slot := range order {
+		migrations = append(migrations, *bySlot[slot])
+	}
+	return migrations
+}
+
+// CollectOpenSlots asks every reachable master for its own open slots.
+// CLUSTER NODES only reports MIGRATING/IMPORTING state on the myself line,
+// so a single node's view misses slots left open on other masters.
+// Nodes that cannot be queried are reported in the returned error.
+func CollectOpenSlots(ctx context.Context, t *Topology) ([]OpenSlot, error) {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		open []OpenSlot
+		errs []error
+	)
+
+	for _, node := range t.Nodes {
+		if !node.IsMaster() || node.IsFail() || node.IsNoAddr() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(node ClusterNode) {
+			defer wg.Done()
+
+			client := NewNodeClient(node.Endpoint())
+			defer client.Close()
+
+			output, err := client.ClusterNodes(ctx).Result()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", node.Endpoint(), err))
+				return
+			}
+			if myself, ok := ParseTopology(output).Myself(); ok {
+				open = append(open, myself.OpenSlots...)
+			}
+		}(node)
+	}
+	wg.Wait()
+
+	sort.Slice(open, func(i, j int) bool {
+		if open[i].Slot != open[j].Slot {
+			return open[i].Slot < open[j].Slot
+		}
+		return open[i].State > open[j].State // migrating first
+	})
+	return open, errors.Join(errs...)
+}
+
+// nodesFromShards converts CLUSTER SHARDS replies into ClusterNode entries
+func nodesFromShards(shards []redis.ClusterShard) []ClusterNode {
+	var nodes []ClusterNode
+
+	for _, shard := range shards {
+		masterID := "-"
+		for _, n := range shard.Nodes {
+			if n.Role == "master" {
+				masterID = n.ID
+				break
+			}
+		}
+
+		var slots []SlotRange
+		for _, r := range shard.Slots {
+			slots = append(slots, SlotRange{Start: int(r.Start), End: int(r.End)})
+		}
+
+		for _, n := range shard.Nodes {
+			node := ClusterNode{
+				ID:                n.ID,
+				Address:           shardNodeAddress(n).ClusterString(),
+				Master:            "-",
+				Hostname:          n.Hostname,
+				TLSPort:           int(n.TLSPort),
+				Health:            n.Health,
+				ReplicationOffset: n.ReplicationOffset,
+			}
+
+			if n.Role == "master" {
+				node.Flags = []string{"master"}
+				node.Slots = slots
+			} else {
+				node.Flags = []string{"slave"}
+				node.Master = masterID
+			}
+			if n.Health == HealthFailed {
+				node.Flags = append(node.Flags, "fail")
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// shardNodeAddress builds the node address from a CLUSTER SHARDS entry.
+// port is absent on TLS-only nodes, in which case tls-port is the client port.
+func shardNodeAddress(n redis.Node) Address {
+	host := n.IP
+	if host == "" {
+		host = n.Endpoint
+	}
+
+	addr := Address{
+		Host:     host,
+		Port:     int(n.Port),
+		Hostname: n.Hostname,
+		TLSPort:  int(n.TLSPort),
+	}
+	if addr.Port == 0 {
+		addr.Port = addr.TLSPort
+	}
+	return addr
+}
+
+// mergeNodesView fills in what CLUSTER SHARDS leaves out (flags such as
+// myself/handshake/pfail, bus port, epochs, link state) from CLUSTER NODES.
+// Nodes only known to CLUSTER NODES (e.g. still in handshake) are appended.
+func mergeNodesView(shardNodes, nodesView []ClusterNode) []ClusterNode {
+	byID := make(map[string]ClusterNode, len(nodesView))
+	for _, n := range nodesView {
+		byID[n.ID] = n
+	}
+
+	seen := make(map[string]bool, len(shardNodes))
+	for i := range shardNodes {
+		node := &shardNodes[i]
+		seen[node.ID] = true
+
+		other, ok := byID[node.ID]
+		if !ok {
+			continue
+		}
+
+		// Role and slots come from CLUSTER SHARDS; everything else from CLUSTER NODES
+		flags := node.Flags
+		for _, flag := range other.Flags {
+			if flag != "master" && flag != "slave" && !node.HasFlag(flag) {
+				flags = append(flags, flag)
+			}
+		}
+		node.Flags = flags
+		node.ConfigEpoch = other.ConfigEpoch
+		node.LinkState = other.LinkState
+		node.PingSent = other.PingSent
+		node.PongRecv = other.PongRecv
+		node.OpenSlots = other.OpenSlots
+
+		if addr, err := ParseAddress(other.Address); err == nil {
+			merged, _ := ParseAddress(node.Address)
+			merged.BusPort = addr.BusPort
+			merged.ShardID = addr.ShardID
+			merged.TCPPort = addr.TCPPort
+			node.Address = merged.ClusterString()
+		}
+	}
+
+	for _, n := range nodesView {
+		if !seen[n.ID] {
+			shardNodes = append(shardNodes, n)
+		}
+	}
+
+	return shardNodes
+}
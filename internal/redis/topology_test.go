@@ -0,0 +1,268 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const testClusterNodes = `07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002 master - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 master - 0 1426238318243 3 connected 10923-16383
+e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460 [5461-<-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1] [93->-292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f]`
+
+// TestParseTopology tests slot ownership, replica index and open slot parsing
+func TestParseTopology(t *testing.T) {
+	topology := ParseTopology(testClusterNodes)
+
+	if got := len(topology.Masters()); got != 3 {
+		t.Errorf("Masters() = %d, want 3", got)
+	}
+	if got := topology.CoveredSlots(); got != ClusterSlots {
+		t.Errorf("CoveredSlots() = %d, want %d", got, ClusterSlots)
+	}
+
+	owner, ok := topology.SlotOwner(5461)
+	if !ok || owner.ID != "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1" {
+		t.Errorf("SlotOwner(5461) = %q, want 67ed2db8...", owner.ID)
+	}
+
+	replicas := topology.ReplicasOf("e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca")
+	if len(replicas) != 1 || replicas[0].ID != "07c37dfeb235213a872192d90877d0cd55635b91" {
+		t.Errorf("ReplicasOf(e7d1eecc...) = %v, want 07c37dfe...", replicas)
+	}
+
+	myself, ok := topology.Myself()
+	if !ok || myself.SlotCount() != 5461 {
+		t.Errorf("Myself() slot count = %d, want 5461 (open slots must not count)", myself.SlotCount())
+	}
+
+	want := []OpenSlot{
+		{Slot: 5461, State: SlotImporting, NodeID: "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", PeerID: "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1"},
+		{Slot: 93, State: SlotMigrating, NodeID: "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", PeerID: "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f"},
+	}
+	if got := topology.OpenSlots(); !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenSlots() = %+v, want %+v", got, want)
+	}
+
+	node, ok := topology.NodeByAddress("localhost:30003")
+	if !ok || node.ID != "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f" {
+		t.Errorf("NodeByAddress(localhost:30003) = %q, want 292f8b36...", node.ID)
+	}
+}
+
+// testShards is the CLUSTER SHARDS view of the first two shards of testClusterNodes
+var testShards = []goredis.ClusterShard{
+	{
+		Slots: []goredis.SlotRange{{Start: 0, End: 5460}},
+		Nodes: []goredis.Node{
+			{ID: "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", IP: "127.0.0.1", Port: 30001, Role: "master", Health: HealthOnline},
+			{ID: "07c37dfeb235213a872192d90877d0cd55635b91", IP: "127.0.0.1", Port: 30004, Role: "replica", Health: HealthOnline, ReplicationOffset: 42},
+		},
+	},
+	{
+		Slots: []goredis.SlotRange{{Start: 5461, End: 10922}},
+		Nodes: []goredis.Node{
+			{ID: "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1", Endpoint: "10.0.0.2", TLSPort: 30002, Role: "master", Health: HealthFailed},
+		},
+	},
+}
+
+// TestNodesFromShards tests the conversion of CLUSTER SHARDS replies
+func TestNodesFromShards(t *testing.T) {
+	nodes := nodesFromShards(testShards)
+
+	tests := []struct {
+		name    string
+		node    ClusterNode
+		address string
+		master  string
+		flags   []string
+		slots   int
+	}{
+		{"master", nodes[0], "127.0.0.1:30001", "-", []string{"master"}, 5461},
+		{"replica", nodes[1], "127.0.0.1:30004", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", []string{"slave"}, 0},
+		{"failed tls-only master", nodes[2], "10.0.0.2:30002", "-", []string{"master", "fail"}, 5462},
+	}
+
+	if len(nodes) != len(tests) {
+		t.Fatalf("nodesFromShards() = %d nodes, want %d", len(nodes), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.HostPort(); got != tt.address {
+				t.Errorf("HostPort() = %q, want %q", got, tt.address)
+			}
+			if tt.node.Master != tt.master || !reflect.DeepEqual(tt.node.Flags, tt.flags) || tt.node.SlotCount() != tt.slots {
+				t.Errorf("node = master %q, flags %v, slots %d, want %q, %v, %d", tt.node.Master, tt.node.Flags, tt.node.SlotCount(), tt.master, tt.flags, tt.slots)
+			}
+		})
+	}
+	if nodes[1].ReplicationOffset != 42 {
+		t.Errorf("replica ReplicationOffset = %d, want 42", nodes[1].ReplicationOffset)
+	}
+}
+
+// TestMergeNodesView tests that CLUSTER NODES fills in what CLUSTER SHARDS lacks
+func TestMergeNodesView(t *testing.T) {
+	nodesView := ParseTopology(testClusterNodes + "\n" +
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 127.0.0.1:30005@31005 handshake - 0 0 0 connected").Nodes
+	merged := mergeNodesView(nodesFromShards(testShards), nodesView)
+	topology := NewTopology(SourceClusterShards, merged)
+
+	tests := []struct {
+		name      string
+		id        string
+		wantFlags []string
+		wantBus   int
+		wantEpoch int64
+	}{
+		{"myself keeps shard role", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", []string{"master", "myself"}, 31001, 1},
+		{"replica", "07c37dfeb235213a872192d90877d0cd55635b91", []string{"slave"}, 31004, 4},
+		{"handshake only in CLUSTER NODES", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", []string{"handshake"}, 31005, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, ok := topology.NodeByID(tt.id)
+			if !ok {
+				t.Fatalf("NodeByID(%s) not found", tt.id)
+			}
+			addr, err := ParseAddress(node.Address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(node.Flags, tt.wantFlags) || addr.BusPort != tt.wantBus || node.ConfigEpoch != tt.wantEpoch {
+				t.Errorf("node = flags %v, bus %d, epoch %d, want %v, %d, %d", node.Flags, addr.BusPort, node.ConfigEpoch, tt.wantFlags, tt.wantBus, tt.wantEpoch)
+			}
+		})
+	}
+
+	myself, _ := topology.Myself()
+	if len(myself.OpenSlots) != 2 {
+		t.Errorf("myself OpenSlots = %v, want the 2 open slots from CLUSTER NODES", myself.OpenSlots)
+	}
+}
+
+// fakeTopologyClient answers CLUSTER SHARDS and CLUSTER NODES with fixed replies
+type fakeTopologyClient struct {
+	shards     []goredis.ClusterShard
+	shardsErr  error
+	nodes      string
+	nodesErr   error
+	nodesCalls int
+}
+
+func (c *fakeTopologyClient) ClusterShards(ctx context.Context) *goredis.ClusterShardsCmd {
+	cmd := goredis.NewClusterShardsCmd(ctx)
+	cmd.SetVal(c.shards)
+	cmd.SetErr(c.shardsErr)
+	return cmd
+}
+
+func (c *fakeTopologyClient) ClusterNodes(ctx context.Context) *goredis.StringCmd {
+	c.nodesCalls++
+	return goredis.NewStringResult(c.nodes, c.nodesErr)
+}
+
+// TestLoadTopologySource tests that CLUSTER SHARDS is preferred and CLUSTER NODES is the fallback
+func TestLoadTopologySource(t *testing.T) {
+	unknown := errors.New("ERR unknown subcommand 'SHARDS'")
+
+	tests := []struct {
+		name       string
+		client     *fakeTopologyClient
+		wantSource string
+		wantNodes  int
+		wantErr    bool
+	}{
+		{"shards merged with nodes", &fakeTopologyClient{shards: testShards, nodes: testClusterNodes}, SourceClusterShards, 4, false},
+		{"shards without nodes", &fakeTopologyClient{shards: testShards, nodesErr: errors.New("timeout")}, SourceClusterShards, 3, false},
+		{"fallback on old server", &fakeTopologyClient{shardsErr: unknown, nodes: testClusterNodes}, SourceClusterNodes, 4, false},
+		{"fallback on empty shards", &fakeTopologyClient{nodes: testClusterNodes}, SourceClusterNodes, 4, false},
+		{"both fail", &fakeTopologyClient{shardsErr: unknown, nodesErr: errors.New("timeout")}, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topology, err := loadTopology(context.Background(), tt.client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadTopology() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.client.nodesCalls != 1 {
+				t.Errorf("CLUSTER NODES sent %d times, want 1", tt.client.nodesCalls)
+			}
+			if err != nil {
+				return
+			}
+			if topology.Source != tt.wantSource || len(topology.Nodes) != tt.wantNodes {
+				t.Errorf("loadTopology() = %s with %d nodes, want %s with %d", topology.Source, len(topology.Nodes), tt.wantSource, tt.wantNodes)
+			}
+		})
+	}
+}
+
+// TestParseOpenSlot tests the open slot marker parsing
+func TestParseOpenSlot(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		expected *OpenSlot
+	}{
+		{
+			name:     "migrating",
+			field:    "[1234->-abc]",
+			expected: &OpenSlot{Slot: 1234, State: SlotMigrating, NodeID: "self", PeerID: "abc"},
+		},
+		{
+			name:     "importing",
+			field:    "[1234-<-abc]",
+			expected: &OpenSlot{Slot: 1234, State: SlotImporting, NodeID: "self", PeerID: "abc"},
+		},
+		{
+			name:     "slot out of range",
+			field:    "[16384->-abc]",
+			expected: nil,
+		},
+		{
+			name:     "missing peer",
+			field:    "[1234->-]",
+			expected: nil,
+		},
+		{
+			name:     "plain range",
+			field:    "[0-100]",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseOpenSlot(tt.field, "self")
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseOpenSlot(%q) = %+v, want %+v", tt.field, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGroupOpenSlots tests pairing of both migration ends
+func TestGroupOpenSlots(t *testing.T) {
+	open := []OpenSlot{
+		{Slot: 7, State: SlotImporting, NodeID: "b", PeerID: "a"},
+		{Slot: 3, State: SlotMigrating, NodeID: "a", PeerID: "c"},
+		{Slot: 7, State: SlotMigrating, NodeID: "a", PeerID: "b"},
+	}
+
+	want := []SlotMigration{
+		{Slot: 3, SourceID: "a", TargetID: "c", Migrating: true},
+		{Slot: 7, SourceID: "a", TargetID: "b", Migrating: true, Importing: true},
+	}
+
+	if got := GroupOpenSlots(open); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupOpenSlots() = %+v, want %+v", got, want)
+	}
+}
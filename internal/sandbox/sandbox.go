@@ -0,0 +1,327 @@
+package sandbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultDir is where sandbox up keeps node configs, data and logs (relative to the working directory)
+const DefaultDir = "redis-sandbox"
+
+// stateFile records the sandbox layout so that down/status/logs/kill work from any later invocation
+const stateFile = "sandbox.json"
+
+// Sandbox is a set of local redis-server processes on consecutive ports: masters, replicas,
+// then spare nodes that are started but left out of the cluster (for add-node tests):
+//
+//	<dir>/sandbox.json          레이아웃
+//	<dir>/password              requirepass/masterauth (0600, --password-file로 사용)
+//	<dir>/<port>/redis.conf     노드 설정, 데이터, nodes.conf, redis.log, redis.pid
+type Sandbox struct {
+	Masters     int    `json:"masters"`
+	Replicas    int    `json:"replicas"`
+	Spare       int    `json:"spare,omitempty"`
+	BasePort    int    `json:"base_port"`
+	Bind        string `json:"bind"`
+	RedisServer string `json:"redis_server"`
+
+	dir string
+}
+
+// New validates a new sandbox layout in dir; nothing is written until Init
+func New(dir string, masters, replicas, spare, basePort int, redisServer string) (*Sandbox, error) {
+	if masters < 3 {
+		return nil, fmt.Errorf("클러스터를 위해 최소 3개의 마스터가 필요합니다 (--masters %d)", masters)
+	}
+	if replicas < 0 {
+		return nil, fmt.Errorf("복제본 수는 0 이상이어야 합니다 (--replicas %d)", replicas)
+	}
+	if spare < 0 {
+		return nil, fmt.Errorf("예비 노드 수는 0 이상이어야 합니다 (--spare %d)", spare)
+	}
+	s := &Sandbox{Masters: masters, Replicas: replicas, Spare: spare, BasePort: basePort, Bind: "127.0.0.1", RedisServer: redisServer, dir: dir}
+	// 클러스터 버스 포트(+10000)도 65535 이하여야 한다
+	if basePort < 1 || s.LastPort()+10000 > 65535 {
+		return nil, fmt.Errorf("포트 범위 %d-%d를 사용할 수 없습니다 (클러스터 버스 포트 = 포트 + 10000)", basePort, s.LastPort())
+	}
+	return s, nil
+}
+
+// Load reads the sandbox in dir
+func Load(dir string) (*Sandbox, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s에 샌드박스가 없습니다. 'redisctl sandbox up'으로 먼저 생성하세요", dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("샌드박스 상태 읽기 실패: %w", err)
+	}
+
+	s := &Sandbox{dir: dir}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("샌드박스 상태 해석 실패 (%s): %w", filepath.Join(dir, stateFile), err)
+	}
+	return s, nil
+}
+
+// Exists reports whether dir holds a sandbox
+func Exists(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, stateFile))
+	return err == nil
+}
+
+// Init writes the state file, the password file (random when password is empty) and every node config
+func (s *Sandbox) Init(password string) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("샌드박스 디렉터리 생성 실패: %w", err)
+	}
+
+	if password == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return err
+		}
+		password = hex.EncodeToString(buf)
+	}
+	if err := os.WriteFile(s.PasswordFile(), []byte(password+"\n"), 0o600); err != nil {
+		return fmt.Errorf("비밀번호 파일 저장 실패: %w", err)
+	}
+
+	for _, port := range s.Ports() {
+		if err := os.MkdirAll(s.NodeDir(port), 0o755); err != nil {
+			return fmt.Errorf("노드 디렉터리 생성 실패: %w", err)
+		}
+		if err := os.WriteFile(s.configPath(port), []byte(s.NodeConfig(port, password)), 0o600); err != nil {
+			return fmt.Errorf("노드 %d 설정 저장 실패: %w", port, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, stateFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("샌드박스 상태 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// NodeConfig returns the redis.conf of one node. Paths are absolute so the node can be
+// started from any working directory; the process daemonizes and writes a pid file.
+func (s *Sandbox) NodeConfig(port int, password string) string {
+	dir, err := filepath.Abs(s.NodeDir(port))
+	if err != nil {
+		dir = s.NodeDir(port)
+	}
+
+	lines := []string{
+		fmt.Sprintf("# redisctl sandbox node %d", port),
+		fmt.Sprintf("port %d", port),
+		fmt.Sprintf("bind %s", s.Bind),
+		"daemonize yes",
+		"dir " + confQuote(dir),
+		"pidfile " + confQuote(filepath.Join(dir, "redis.pid")),
+		"logfile " + confQuote(filepath.Join(dir, "redis.log")),
+		"loglevel notice",
+		"",
+		"cluster-enabled yes",
+		"cluster-config-file nodes.conf",
+		"cluster-node-timeout 5000",
+		"",
+		"requirepass " + confQuote(password),
+		"masterauth " + confQuote(password),
+		"",
+		"appendonly yes",
+		"save \"\"",
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Dir returns the sandbox directory
+func (s *Sandbox) Dir() string {
+	return s.dir
+}
+
+// Ports returns the node ports, masters first
+func (s *Sandbox) Ports() []int {
+	ports := make([]int, 0, s.LastPort()-s.BasePort+1)
+	for port := s.BasePort; port <= s.LastPort(); port++ {
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// Address returns the client address of the node on port
+func (s *Sandbox) Address(port int) string {
+	return net.JoinHostPort(s.Bind, strconv.Itoa(port))
+}
+
+// Addresses returns the client addresses of every node, masters first
+func (s *Sandbox) Addresses() []string {
+	var addrs []string
+	for _, port := range s.Ports() {
+		addrs = append(addrs, s.Address(port))
+	}
+	return addrs
+}
+
+// ClusterAddresses returns the addresses of the nodes that form the cluster (without spare nodes), masters first
+func (s *Sandbox) ClusterAddresses() []string {
+	return s.Addresses()[:s.Masters*(1+s.Replicas)]
+}
+
+// Has reports whether port belongs to the sandbox
+func (s *Sandbox) Has(port int) bool {
+	return port >= s.BasePort && port <= s.LastPort()
+}
+
+// NodeDir returns the directory of the node on port
+func (s *Sandbox) NodeDir(port int) string {
+	return filepath.Join(s.dir, strconv.Itoa(port))
+}
+
+// LogPath returns the log file of the node on port
+func (s *Sandbox) LogPath(port int) string {
+	return filepath.Join(s.NodeDir(port), "redis.log")
+}
+
+// PasswordFile returns the file holding the sandbox password
+func (s *Sandbox) PasswordFile() string {
+	return filepath.Join(s.dir, "password")
+}
+
+// Password reads the sandbox password
+func (s *Sandbox) Password() (string, error) {
+	data, err := os.ReadFile(s.PasswordFile())
+	if err != nil {
+		return "", fmt.Errorf("샌드박스 비밀번호 읽기 실패: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// PID returns the process ID of the node on port and whether the process is alive
+func (s *Sandbox) PID(port int) (int, bool) {
+	data, err := os.ReadFile(filepath.Join(s.NodeDir(port), "redis.pid"))
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, processAlive(pid)
+}
+
+// Start launches the node on port and waits until it accepts connections
+func (s *Sandbox) Start(ctx context.Context, port int) error {
+	if _, alive := s.PID(port); alive {
+		return nil
+	}
+
+	// 비정상 종료로 남은 pid 파일은 새 프로세스가 덮어쓴다
+	cmd := exec.CommandContext(ctx, s.RedisServer, s.configPath(port))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("redis-server 실행 실패 (포트 %d): %w\n%s", port, err, strings.TrimSpace(string(out)))
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, alive := s.PID(port); alive {
+			conn, err := net.DialTimeout("tcp", s.Address(port), 500*time.Millisecond)
+			if err == nil {
+				conn.Close()
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return fmt.Errorf("노드 %d가 10초 내에 시작되지 않았습니다 (로그: %s)", port, s.LogPath(port))
+}
+
+// Stop sends sig (SIGTERM for a clean shutdown, SIGKILL to simulate a crash) to the node on port
+// and waits for the process to exit. A node that is not running is not an error.
+func (s *Sandbox) Stop(port int, sig syscall.Signal) error {
+	pid, alive := s.PID(port)
+	if !alive {
+		return nil
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := process.Signal(sig); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("노드 %d (PID %d) 종료 실패: %w", port, pid, err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("노드 %d (PID %d)가 10초 내에 종료되지 않았습니다", port, pid)
+}
+
+// Remove deletes the sandbox directory; the nodes must be stopped first
+func (s *Sandbox) Remove() error {
+	for _, port := range s.Ports() {
+		if _, alive := s.PID(port); alive {
+			return fmt.Errorf("노드 %d가 아직 실행 중입니다", port)
+		}
+	}
+	return os.RemoveAll(s.dir)
+}
+
+// TailLog returns the last n lines of the log of the node on port
+func (s *Sandbox) TailLog(port, n int) ([]string, error) {
+	data, err := os.ReadFile(s.LogPath(port))
+	if err != nil {
+		return nil, fmt.Errorf("로그 읽기 실패: %w", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+// LastPort returns the port of the last node
+func (s *Sandbox) LastPort() int {
+	return s.BasePort + s.Masters*(1+s.Replicas) + s.Spare - 1
+}
+
+func (s *Sandbox) configPath(port int) string {
+	return filepath.Join(s.NodeDir(port), "redis.conf")
+}
+
+// confQuote quotes a redis.conf argument; only backslash and double quote need escaping
+func confQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// processAlive reports whether a process with pid exists (signal 0 only checks permissions)
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
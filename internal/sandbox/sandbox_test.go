@@ -0,0 +1,134 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNew tests layout validation
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		masters  int
+		replicas int
+		spare    int
+		basePort int
+		ports    []int
+		wantErr  bool
+	}{
+		{"3 masters 1 replica", 3, 1, 0, 9001, []int{9001, 9002, 9003, 9004, 9005, 9006}, false},
+		{"no replicas", 3, 0, 0, 7000, []int{7000, 7001, 7002}, false},
+		{"spare nodes", 3, 0, 2, 7000, []int{7000, 7001, 7002, 7003, 7004}, false},
+		{"too few masters", 2, 1, 0, 9001, nil, true},
+		{"negative replicas", 3, -1, 0, 9001, nil, true},
+		{"negative spare", 3, 1, -1, 9001, nil, true},
+		{"bus port out of range", 3, 1, 0, 55531, nil, true},
+		{"invalid port", 3, 1, 0, 0, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(t.TempDir(), tt.masters, tt.replicas, tt.spare, tt.basePort, "redis-server")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(s.Ports(), tt.ports) {
+				t.Errorf("Ports() = %v, want %v", s.Ports(), tt.ports)
+			}
+		})
+	}
+}
+
+// TestInitLoad tests that the layout, password and node configs are written and read back
+func TestInitLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sandbox dir")
+
+	s, err := New(dir, 3, 1, 1, 9001, "/usr/bin/redis-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Exists(dir) {
+		t.Fatal("Exists() before Init")
+	}
+	if err := s.Init(`pa"ss\word`); err != nil {
+		t.Fatalf("Init(): %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load(): %v", err)
+	}
+	if loaded.Masters != 3 || loaded.Replicas != 1 || loaded.Spare != 1 || loaded.BasePort != 9001 || loaded.RedisServer != "/usr/bin/redis-server" {
+		t.Errorf("Load() = %+v", loaded)
+	}
+	if addrs := loaded.ClusterAddresses(); len(addrs) != 6 || addrs[5] != "127.0.0.1:9006" {
+		t.Errorf("ClusterAddresses() = %v", addrs)
+	}
+	if !loaded.Has(9007) || loaded.Has(9008) {
+		t.Errorf("Has() does not cover exactly the ports 9001-9007")
+	}
+	if password, err := loaded.Password(); err != nil || password != `pa"ss\word` {
+		t.Errorf("Password() = %q, %v", password, err)
+	}
+
+	conf, err := os.ReadFile(filepath.Join(dir, "9006", "redis.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"port 9006\n", "cluster-enabled yes\n", "daemonize yes\n", `requirepass "pa\"ss\\word"` + "\n", `masterauth "pa\"ss\\word"` + "\n"} {
+		if !strings.Contains(string(conf), want) {
+			t.Errorf("redis.conf does not contain %q:\n%s", want, conf)
+		}
+	}
+	if !strings.Contains(string(conf), `dir "/`) {
+		t.Errorf("redis.conf dir is not absolute:\n%s", conf)
+	}
+
+	if _, alive := loaded.PID(9001); alive {
+		t.Error("PID() reports a node that was never started as alive")
+	}
+	if err := loaded.Remove(); err != nil || Exists(dir) {
+		t.Errorf("Remove() = %v, Exists() = %t", err, Exists(dir))
+	}
+}
+
+// TestInitRandomPassword tests that a password is generated when none is given
+func TestInitRandomPassword(t *testing.T) {
+	s, err := New(t.TempDir(), 3, 0, 0, 9001, "redis-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Init(""); err != nil {
+		t.Fatal(err)
+	}
+	if password, err := s.Password(); err != nil || len(password) != 32 {
+		t.Errorf("Password() = %q, %v, want 32 hex characters", password, err)
+	}
+}
+
+// TestTailLog tests reading the last lines of a node log
+func TestTailLog(t *testing.T) {
+	s, err := New(t.TempDir(), 3, 0, 0, 9001, "redis-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Init("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.LogPath(9001), []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines, err := s.TailLog(9001, 2); err != nil || !reflect.DeepEqual(lines, []string{"b", "c"}) {
+		t.Errorf("TailLog(2) = %q, %v", lines, err)
+	}
+	if lines, err := s.TailLog(9001, 0); err != nil || len(lines) != 3 {
+		t.Errorf("TailLog(0) = %q, %v", lines, err)
+	}
+	if _, err := s.TailLog(9002, 10); err == nil {
+		t.Error("TailLog() of a missing log succeeded")
+	}
+}
@@ -4,8 +4,11 @@ TODO: https://github.com/redis/rueidis 가 더 나을지
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"redisctl/cmd"
 	"redisctl/internal/config"
@@ -39,12 +42,86 @@ func main() {
 			styles.DescStyle.Render("Redis 클러스터를 관리하기 위한 도구입니다.") + "\n" +
 			styles.DescStyle.Render("클러스터 생성, 노드 관리, 리샤딩, 상태 확인 등을 지원합니다."),
 		Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			// Global flags setup
-			user, _ := cmd.Flags().GetString("user")
-			password, _ := cmd.Flags().GetString("password")
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// 인증 정보: 지정된 플래그만 환경 변수를 덮어씀 (우선순위는 'redisctl config' 참고)
+			flags := cmd.Flags()
+			creds := config.CredentialOptions{
+				UserSet:     flags.Changed("user"),
+				PasswordSet: flags.Changed("password"),
+			}
+			creds.User, _ = flags.GetString("user")
+			creds.Password, _ = flags.GetString("password")
+			creds.PasswordFile, _ = flags.GetString("password-file")
+			creds.PasswordStdin, _ = flags.GetBool("password-stdin")
+			creds.CredentialCommand, _ = flags.GetString("credential-command")
+
+			if creds.PasswordSet {
+				fmt.Fprintln(os.Stderr, styles.RenderWarning("--password는 셸 히스토리와 ps 출력에 노출됩니다. --password-file, --password-stdin 또는 프롬프트 사용을 권장합니다"))
+			}
+			if err := config.SetCredentials(creds, os.Stdin); err != nil {
+				return err
+			}
+
+			// 비밀번호 교체 중 노드별 인증 정보 (--node-credentials > REDIS_NODE_CREDENTIALS)
+			nodeCredentialsFile := config.GetNodeCredentialsFile()
+			if flags.Changed("node-credentials") {
+				nodeCredentialsFile, _ = flags.GetString("node-credentials")
+			}
+			if nodeCredentialsFile != "" {
+				if err := config.LoadNodeCredentials(nodeCredentialsFile); err != nil {
+					return err
+				}
+			}
+
+			// --seed는 반복 지정하거나 쉼표로 구분 (환경 변수 REDIS_SEEDS보다 우선)
+			if cmd.Flags().Changed("seed") {
+				seeds, _ := cmd.Flags().GetStringSlice("seed")
+				config.SetSeeds(seeds)
+			}
+
+			// 노드 라벨은 로컬 인벤토리에 저장되어 이후 명령에서 재사용
+			labels, _ := flags.GetStringArray("label")
+			topologyFile, _ := flags.GetString("topology-file")
+			inventoryPath, _ := flags.GetString("inventory")
+			config.SetLabelOptions(labels, topologyFile, inventoryPath)
+
+			// TLS 플래그는 지정된 경우에만 환경 변수 값을 덮어씀
+			tlsOpts := config.GetTLSOptions()
+			if flags.Changed("tls") {
+				tlsOpts.Enabled, _ = flags.GetBool("tls")
+				tlsOpts.EnabledSet = true
+			}
+			if flags.Changed("cacert") {
+				tlsOpts.CACert, _ = flags.GetString("cacert")
+			}
+			if flags.Changed("cert") {
+				tlsOpts.Cert, _ = flags.GetString("cert")
+			}
+			if flags.Changed("key") {
+				tlsOpts.Key, _ = flags.GetString("key")
+			}
+			if flags.Changed("insecure-skip-verify") {
+				tlsOpts.InsecureSkipVerify, _ = flags.GetBool("insecure-skip-verify")
+			}
+			if flags.Changed("sni") {
+				tlsOpts.ServerName, _ = flags.GetString("sni")
+			}
 
-			config.SetAuth(user, password)
+			// 마이그레이션 재시도 정책도 지정된 경우에만 덮어씀
+			if flags.Changed("migrate-retries") || flags.Changed("migrate-backoff") {
+				retries, minBackoff, maxBackoff := config.GetMigrateRetryPolicy()
+				if flags.Changed("migrate-retries") {
+					retries, _ = flags.GetInt("migrate-retries")
+				}
+				if flags.Changed("migrate-backoff") {
+					minBackoff, _ = flags.GetDuration("migrate-backoff")
+				}
+				if err := config.SetMigrateRetryPolicy(retries, minBackoff, maxBackoff); err != nil {
+					return err
+				}
+			}
+
+			return config.SetTLS(tlsOpts)
 		},
 		CompletionOptions: cobra.CompletionOptions{
 			HiddenDefaultCmd: true, // hides default "completion" command
@@ -53,7 +130,23 @@ func main() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringP("user", "u", "", "Redis 사용자명 (기본 인증 사용시 생략 가능)")
-	rootCmd.PersistentFlags().StringP("password", "p", "", "Redis 비밀번호 (필수)")
+	rootCmd.PersistentFlags().StringP("password", "p", "", "Redis 비밀번호 (ps/히스토리에 노출되므로 비권장)")
+	rootCmd.PersistentFlags().String("password-file", "", "비밀번호 파일 경로 (환경 변수 REDIS_PASSWORD_FILE)")
+	rootCmd.PersistentFlags().Bool("password-stdin", false, "표준 입력에서 비밀번호 읽기")
+	rootCmd.PersistentFlags().String("credential-command", "", "user=/password= 또는 비밀번호를 출력하는 명령 (환경 변수 REDIS_CREDENTIAL_COMMAND)")
+	rootCmd.PersistentFlags().String("node-credentials", "", "노드별 인증 정보 파일 ('<주소|노드 ID|*> [사용자명] <비밀번호>' 줄, 환경 변수 REDIS_NODE_CREDENTIALS)")
+	rootCmd.PersistentFlags().StringSlice("seed", nil, "추가 시드 노드 (반복 지정 또는 쉼표 구분, 순서대로 시도)")
+	rootCmd.PersistentFlags().StringArray("label", nil, "노드 라벨 host:port=key=value (반복 지정, 예: 10.0.1.5:7000=zone=a)")
+	rootCmd.PersistentFlags().String("topology-file", "", "노드 라벨 파일 (YAML/JSON, nodes: {host:port: {zone: a}})")
+	rootCmd.PersistentFlags().String("inventory", "", "로컬 인벤토리 파일 (기본값: REDIS_INVENTORY 또는 사용자 설정 디렉터리)")
+	rootCmd.PersistentFlags().Bool("tls", false, "TLS로 연결 (tls-cluster yes 클러스터용)")
+	rootCmd.PersistentFlags().String("cacert", "", "서버 인증서 검증용 CA 인증서 파일 (PEM)")
+	rootCmd.PersistentFlags().String("cert", "", "mTLS 클라이언트 인증서 파일 (PEM)")
+	rootCmd.PersistentFlags().String("key", "", "mTLS 클라이언트 개인키 파일 (PEM)")
+	rootCmd.PersistentFlags().Bool("insecure-skip-verify", false, "서버 인증서 검증 생략 (테스트 환경 전용)")
+	rootCmd.PersistentFlags().String("sni", "", "TLS SNI 서버 이름 (기본값: 접속 호스트)")
+	rootCmd.PersistentFlags().Int("migrate-retries", 5, "키 마이그레이션 일시 오류 재시도 횟수")
+	rootCmd.PersistentFlags().Duration("migrate-backoff", 200*time.Millisecond, "키 마이그레이션 재시도 초기 백오프 (재시도마다 2배)")
 
 	// subcommands
 	rootCmd.AddCommand(
@@ -64,6 +157,9 @@ func main() {
 		cmd.NewCheckCommand(),
 		cmd.NewPopulateCommand(),
 		cmd.NewRebalanceCommand(),
+		cmd.NewApplyCommand(),
+		cmd.NewSandboxCommand(),
+		cmd.NewFixCommand(),
 		cmd.NewConfigCommand(),
 		cmd.NewVersionCommand(version, commit, date),
 	)
@@ -86,8 +182,16 @@ func main() {
 		},
 	})
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C / SIGTERM 한 번: 진행 중인 슬롯을 마무리하고 중단, 두 번: 즉시 종료
+	ctx, stop := cmd.NewSignalContext(context.Background())
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	if err != nil {
 		log.Error("명령 실행 실패", "error", err)
+		if errors.Is(err, cmd.ErrInterrupted) {
+			os.Exit(130)
+		}
 		os.Exit(1)
 	}
 }